@@ -78,8 +78,38 @@ func Migration(DB *gorm.DB, log *logger.Logger) {
 	log.FatalIfErr(err, errMigration)
 	log.Info().Msg("[Database] Successful Migration DeviceAnalytic Table")
 
+	err = DB.AutoMigrate(&domain.Tag{})
+	log.FatalIfErr(err, errMigration)
+	log.Info().Msg("[Database] Successful Migration Tag Table")
+
+	err = DB.AutoMigrate(&domain.Folder{})
+	log.FatalIfErr(err, errMigration)
+	log.Info().Msg("[Database] Successful Migration Folder Table")
+
+	err = DB.AutoMigrate(&domain.CustomDomain{})
+	log.FatalIfErr(err, errMigration)
+	log.Info().Msg("[Database] Successful Migration CustomDomain Table")
+
+	hasClickCount := DB.Migrator().HasColumn(&domain.CustomLink{}, "ClickCount")
 	err = DB.AutoMigrate(&domain.CustomLink{})
 	log.FatalIfErr(err, errMigration)
+
+	// Clicks used to be counted from the interactions on every check, the counter starts from them once.
+	if !hasClickCount && DB.Migrator().HasTable(&domain.CustomLinkInteraction{}) {
+		err = DB.Exec("UPDATE custom_links SET click_count = (SELECT COUNT(*) FROM custom_link_interactions " +
+			"WHERE custom_link_interactions.custom_link_id = custom_links.id AND custom_link_interactions.deleted_at IS NULL)").Error
+		log.FatalIfErr(err, errMigration)
+	}
+
+	// Short link codes used to be unique across every link, they're unique per domain now.
+	err = DB.Exec("ALTER TABLE custom_links DROP CONSTRAINT IF EXISTS custom_links_short_link_code_key").Error
+	log.FatalIfErr(err, errMigration)
+
+	// Case insensitive links can't share a short link code that only differs in case. The index can't cover a case
+	// sensitive link next to a case insensitive one, writers serialize on the lock of LockShortLinkCode for those.
+	err = DB.Exec("CREATE UNIQUE INDEX IF NOT EXISTS idx_custom_links_case_insensitive_short_link_code " +
+		"ON custom_links (COALESCE(domain_id, 0), LOWER(short_link_code)) WHERE case_insensitive").Error
+	log.FatalIfErr(err, errMigration)
 	log.Info().Msg("[Database] Successful Migration CustomLink Table")
 
 	err = DB.AutoMigrate(&domain.Thumbnail{})
@@ -98,8 +128,38 @@ func Migration(DB *gorm.DB, log *logger.Logger) {
 	log.FatalIfErr(err, errMigration)
 	log.Info().Msg("[Database] Successful Migration CustomLinkAnalytic Table")
 
+	err = DB.AutoMigrate(&domain.CustomLinkVariant{})
+	log.FatalIfErr(err, errMigration)
+	log.Info().Msg("[Database] Successful Migration CustomLinkVariant Table")
+
+	// Concurrent changes of a link could take the same revision number, the revisions are numbered again in their
+	// order before the unique index is added.
+	if DB.Migrator().HasTable(&domain.CustomLinkRevision{}) && !DB.Migrator().HasIndex(&domain.CustomLinkRevision{}, "idx_custom_link_revisions_link_revision") {
+		err = DB.Exec("UPDATE custom_link_revisions SET revision = numbered.revision FROM " +
+			"(SELECT id, ROW_NUMBER() OVER (PARTITION BY custom_link_id ORDER BY revision, id) AS revision FROM custom_link_revisions) AS numbered " +
+			"WHERE custom_link_revisions.id = numbered.id AND custom_link_revisions.revision <> numbered.revision").Error
+		log.FatalIfErr(err, errMigration)
+	}
+
+	err = DB.AutoMigrate(&domain.CustomLinkRevision{})
+	log.FatalIfErr(err, errMigration)
+	log.Info().Msg("[Database] Successful Migration CustomLinkRevision Table")
+
+	err = DB.AutoMigrate(&domain.CustomLinkTargetingRule{})
+	log.FatalIfErr(err, errMigration)
+	log.Info().Msg("[Database] Successful Migration CustomLinkTargetingRule Table")
+
+	err = DB.AutoMigrate(&domain.UtmTemplate{})
+	log.FatalIfErr(err, errMigration)
+	log.Info().Msg("[Database] Successful Migration UtmTemplate Table")
+
+	err = DB.AutoMigrate(&domain.ReservedWord{})
+	log.FatalIfErr(err, errMigration)
+	log.Info().Msg("[Database] Successful Migration ReservedWord Table")
+
 	CreateSocialMediaTypeEntries(DB, log)
 	CreateThumbnailEntries(DB, log)
+	CreateReservedWordEntries(DB, log)
 
 }
 
@@ -180,3 +240,25 @@ func CreateThumbnailEntries(DB *gorm.DB, log *logger.Logger) {
 	log.Info().Msg("[Database] Successful Create Thumbnail Entries")
 
 }
+
+// CreateReservedWordEntries seeds the default reserved words into an empty registry only,
+// so words an admin has removed don't come back on the next start.
+func CreateReservedWordEntries(DB *gorm.DB, log *logger.Logger) {
+	tx := DB.Begin()
+	ctx := context.Background()
+	defer helper.CommitOrRollback(tx)
+
+	reservedWordRepository := repository.NewReservedWordRepository(log)
+
+	count, repoErr := reservedWordRepository.Count(ctx, tx)
+	log.PanicIfErr(repoErr, "[Database] Failed Create ReservedWord Entries")
+	if count > 0 {
+		return
+	}
+
+	for _, reservedWordEntry := range helper.DefaultReservedWordEntries() {
+		_, err := reservedWordRepository.Create(ctx, tx, reservedWordEntry)
+		log.PanicIfErr(err, "[Database] Failed Create ReservedWord Entries")
+	}
+	log.Info().Msg("[Database] Successful Create ReservedWord Entries")
+}
@@ -2,6 +2,7 @@ package mail
 
 import (
 	"fmt"
+	"html"
 
 	"github.com/ilhamfzri/pendek.in/config"
 	"gopkg.in/gomail.v2"
@@ -13,6 +14,7 @@ type MailClient struct {
 }
 
 var subjectVerificationEmail = "Verify code to activate your pendek.in account"
+var subjectBrokenLinkEmail = "One of your pendek.in links looks broken"
 
 func NewMailClient(cfg config.MailConfig) *MailClient {
 	fmt.Println(cfg)
@@ -39,3 +41,14 @@ func (client *MailClient) SendVerificationEmail(email string, code string) error
 	err := client.Dialer.DialAndSend(mailer)
 	return err
 }
+
+func (client *MailClient) SendBrokenLinkEmail(email string, title string, shortLinkCode string, longLink string, reason string) error {
+	mailer := gomail.NewMessage()
+	mailer.SetHeader("From", client.SenderName)
+	mailer.SetHeader("To", email)
+	mailer.SetHeader("Subject", subjectBrokenLinkEmail)
+	mailer.SetBody("text/html", fmt.Sprintf("Your link %s (%s) keeps failing to reach %s : %s", html.EscapeString(title), html.EscapeString(shortLinkCode), html.EscapeString(longLink), html.EscapeString(reason)))
+
+	err := client.Dialer.DialAndSend(mailer)
+	return err
+}
@@ -1,16 +1,25 @@
 package main
 
 import (
+	"context"
 	"fmt"
+	"net"
 	"os"
+	"os/signal"
+	"syscall"
 
 	"github.com/ilhamfzri/pendek.in/app/cache"
 	"github.com/ilhamfzri/pendek.in/app/database"
 	"github.com/ilhamfzri/pendek.in/app/logger"
 	"github.com/ilhamfzri/pendek.in/app/mail"
 	"github.com/ilhamfzri/pendek.in/app/router"
+	"github.com/ilhamfzri/pendek.in/app/worker"
 	"github.com/ilhamfzri/pendek.in/config"
 	"github.com/ilhamfzri/pendek.in/helper"
+	"github.com/ilhamfzri/pendek.in/helper/domainverify"
+	"github.com/ilhamfzri/pendek.in/helper/geoip"
+	"github.com/ilhamfzri/pendek.in/helper/healthcheck"
+	"github.com/ilhamfzri/pendek.in/helper/urlsafety"
 	"github.com/ilhamfzri/pendek.in/internal/controller"
 	"github.com/ilhamfzri/pendek.in/internal/handler"
 	"github.com/ilhamfzri/pendek.in/internal/repository"
@@ -19,6 +28,10 @@ import (
 
 func main() {
 
+	//.- Shutdown Signal, stops the workers and the server
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
 	configPath := "config/config.json"
 	config := config.NewConfig(configPath)
 
@@ -52,10 +65,32 @@ func main() {
 	jwtConfig := config.GetJwtConfig()
 	jwt := helper.NewJwt(jwtConfig)
 
+	//.- Short Code Generator Initialize
+	shortCodeConfig := config.GetShortCodeConfig()
+	shortCodeGenerator, err := helper.NewShortCodeGenerator(shortCodeConfig)
+	logger.FatalIfErr(err, "[Short Code] Invalid Config")
+
+	//.- GeoIP Database Initialize
+	geoIPConfig := config.GetGeoIPConfig()
+	geoIPDatabase, err := geoip.NewDatabase(geoIPConfig)
+	logger.FatalIfErr(err, "[GeoIP] Failed To Load Database")
+
+	//.- URL Safety Checker Initialize
+	urlSafetyConfig := config.GetURLSafetyConfig()
+	urlChecker, err := urlsafety.NewChecker(urlSafetyConfig)
+	logger.FatalIfErr(err, "[URL Safety] Failed To Load Threat Feed")
+
+	//.- Domain Verifier Initialize
+	domainVerifier := domainverify.NewVerifier(net.DefaultResolver)
+
 	//.- MailClient Initialize
 	mailConfig := config.GetMailConfig()
 	mailClient := mail.NewMailClient(mailConfig)
 
+	//.- Link Health Checker Initialize
+	linkHealthConfig := config.GetLinkHealthConfig()
+	linkHealthChecker := healthcheck.NewChecker(linkHealthConfig)
+
 	//.- Recovery Handler
 	recoveryHandler := handler.NewRecoveryHandler(logger)
 	server.Router.Use(recoveryHandler)
@@ -79,19 +114,48 @@ func main() {
 	customLinkInteractionRepository := repository.NewCustomLinkInteractionRepository(logger)
 	customThumbnailRepository := repository.NewCustomThumbnailRepository(logger)
 	thumbnailRepository := repository.NewThumbnailRepository(logger)
+	utmTemplateRepository := repository.NewUtmTemplateRepository(logger)
+	customLinkTargetingRuleRepository := repository.NewCustomLinkTargetingRuleRepository(logger)
+	customLinkVariantRepository := repository.NewCustomLinkVariantRepository(logger)
+	tagRepository := repository.NewTagRepository(logger)
+	folderRepository := repository.NewFolderRepository(logger)
+	customLinkRevisionRepository := repository.NewCustomLinkRevisionRepository(logger)
+	customDomainRepository := repository.NewCustomDomainRepository(logger)
 	deviceAnalyticRepository := repository.NewDeviceAnalyticRepository(logger)
+	reservedWordRepository := repository.NewReservedWordRepository(logger)
 
 	//.- Service Initialize
-	userService := service.NewUserService(userRepository, mailClient, db, logger, jwt)
+	userService := service.NewUserService(userRepository, reservedWordRepository, customLinkRepository, mailClient, db, logger, jwt)
 	socialMediaLinkService := service.NewSocialMediaLinkService(userRepository, socialMediaLinkRepository, socialMediaTypeRepository, db, logger, jwt)
 	socialMediaAnalyticsService := service.NewSocialMediaAnalyticService(userRepository, socialMediaLinkRepository, socialMediaInteractionRepository, socialMediaAnalyticRepository, deviceAnalyticRepository, db, logger, jwt)
-	customLinkService := service.NewCustomLinkService(customLinkRepository, customThumbnailRepository, thumbnailRepository, db, logger, jwt)
-	customLinkAnalyticService := service.NewCustomLinkAnalyticService(customLinkRepository, customLinkAnalyticRepository, customLinkInteractionRepository, deviceAnalyticRepository, db, logger, jwt)
+	customLinkService := service.NewCustomLinkService(service.CustomLinkServiceDependencies{
+		CustomLinkRepository:              customLinkRepository,
+		CustomLinkAnalyticRepository:      customLinkAnalyticRepository,
+		CustomThumbnailRepository:         customThumbnailRepository,
+		ThumbnailRepository:               thumbnailRepository,
+		UtmTemplateRepository:             utmTemplateRepository,
+		CustomLinkTargetingRuleRepository: customLinkTargetingRuleRepository,
+		CustomLinkVariantRepository:       customLinkVariantRepository,
+		TagRepository:                     tagRepository,
+		FolderRepository:                  folderRepository,
+		CustomLinkRevisionRepository:      customLinkRevisionRepository,
+		CustomDomainRepository:            customDomainRepository,
+		ReservedWordRepository:            reservedWordRepository,
+		UserRepository:                    userRepository,
+		ShortCodeGenerator:                shortCodeGenerator,
+		GeoIP:                             geoIPDatabase,
+		URLChecker:                        urlChecker,
+		DomainVerifier:                    domainVerifier,
+	}, db, logger, jwt)
+	customLinkAnalyticService := service.NewCustomLinkAnalyticService(customLinkRepository, customLinkAnalyticRepository, customLinkInteractionRepository, customLinkVariantRepository, deviceAnalyticRepository, db, logger, jwt)
+	reservedWordService := service.NewReservedWordService(reservedWordRepository, db, logger)
+	linkHealthService := service.NewLinkHealthService(customLinkRepository, userRepository, mailClient, db, logger, linkHealthChecker, linkHealthConfig)
 
 	//.- Controller Initialize
 	userController := controller.NewUserController(userService, socialMediaLinkService, customLinkService, logger)
 	socialMediaLinkController := controller.NewSocialMediaLink(socialMediaLinkService, socialMediaAnalyticsService, redis, logger)
 	customLinkController := controller.NewCustomLinkController(customLinkService, customLinkAnalyticService, redis, logger)
+	adminController := controller.NewAdminController(reservedWordService, logger)
 
 	//.- User Router Initalize
 	router.AddUsersRoute(server, userController, jwt)
@@ -102,7 +166,21 @@ func main() {
 	//.- Custom Link Router Initialize
 	router.AddCustomLinkRoute(server, customLinkController, jwt)
 
+	//.- Admin Router Initialize
+	adminConfig := config.GetAdminConfig()
+	router.AddAdminRoute(server, adminController, jwt, adminConfig)
+
+	//.- Short Link Code Release Worker Initialize
+	shortLinkCodeReleaseWorker := worker.NewShortLinkCodeReleaseWorker(customLinkService, redis, logger)
+	shortLinkCodeReleaseWorker.Start(ctx)
+
+	//.- Link Health Worker Initialize
+	if linkHealthConfig.Enabled {
+		linkHealthWorker := worker.NewLinkHealthWorker(linkHealthService, linkHealthConfig, redis, logger)
+		linkHealthWorker.Start(ctx)
+	}
+
 	//.- Run Server
-	server.Run()
+	server.Run(ctx)
 
 }
@@ -0,0 +1,20 @@
+package router
+
+import (
+	"github.com/ilhamfzri/pendek.in/config"
+	"github.com/ilhamfzri/pendek.in/helper"
+	"github.com/ilhamfzri/pendek.in/internal/controller"
+	"github.com/ilhamfzri/pendek.in/internal/middleware"
+)
+
+func AddAdminRoute(server *Server, adminController controller.AdminController, jwt helper.IJwt, cfg config.AdminConfig) {
+	jwtMiddleware := middleware.NewJwtMiddleware(jwt.GetSigningKey())
+	adminMiddleware := middleware.NewAdminMiddleware(jwt, cfg.UserIDs)
+	adminRoute := server.Router.Group("/v1/admin")
+	adminRoute.Use(jwtMiddleware, adminMiddleware)
+	{
+		adminRoute.GET("/reserved-words", adminController.GetAllReservedWord)
+		adminRoute.POST("/reserved-words", adminController.CreateReservedWord)
+		adminRoute.DELETE("/reserved-words/:word_id", adminController.DeleteReservedWord)
+	}
+}
@@ -18,12 +18,39 @@ func AddCustomLinkRoute(server *Server, customLinkController controller.CustomLi
 	customLinkRouteAuth.Use(jwtMiddleware)
 	{
 		customLinkRouteAuth.POST("/", customLinkController.CreateLink)
+		customLinkRouteAuth.POST("/import", customLinkController.ImportLink)
 		customLinkRouteAuth.GET("/", customLinkController.GetAllLink)
+		customLinkRouteAuth.GET("/export", customLinkController.ExportLink)
+		customLinkRouteAuth.PUT("/reorder", customLinkController.ReorderLink)
+		customLinkRouteAuth.POST("/batch", customLinkController.BatchLink)
 		customLinkRouteAuth.GET("/:link_id", customLinkController.GetLink)
 		customLinkRouteAuth.PUT("/:link_id", customLinkController.UpdateLink)
+		customLinkRouteAuth.DELETE("/:link_id", customLinkController.DeleteLink)
+		customLinkRouteAuth.GET("/trash", customLinkController.GetAllDeletedLink)
+		customLinkRouteAuth.POST("/:link_id/restore", customLinkController.RestoreLink)
+		customLinkRouteAuth.GET("/:link_id/history", customLinkController.GetLinkHistory)
+		customLinkRouteAuth.POST("/:link_id/revert/:revision", customLinkController.RevertLink)
+		customLinkRouteAuth.POST("/:link_id/clone", customLinkController.CloneLink)
+		customLinkRouteAuth.GET("/:link_id/qr", customLinkController.GetLinkQRCode)
 		customLinkRouteAuth.POST("/upload-thumbnail", customLinkController.UploadCustomThumbnail)
 		customLinkRouteAuth.GET("/user-thumbnail-list", customLinkController.GetUserThumbnail)
 		customLinkRouteAuth.GET("/default-thumbnail-list", customLinkController.GetAllThumbnail)
+		customLinkRouteAuth.POST("/utm-template", customLinkController.CreateUtmTemplate)
+		customLinkRouteAuth.GET("/utm-template", customLinkController.GetAllUtmTemplate)
+		customLinkRouteAuth.PUT("/utm-template/:template_id", customLinkController.UpdateUtmTemplate)
+		customLinkRouteAuth.DELETE("/utm-template/:template_id", customLinkController.DeleteUtmTemplate)
+		customLinkRouteAuth.POST("/tag", customLinkController.CreateTag)
+		customLinkRouteAuth.GET("/tag", customLinkController.GetAllTag)
+		customLinkRouteAuth.PUT("/tag/:tag_id", customLinkController.UpdateTag)
+		customLinkRouteAuth.DELETE("/tag/:tag_id", customLinkController.DeleteTag)
+		customLinkRouteAuth.POST("/folder", customLinkController.CreateFolder)
+		customLinkRouteAuth.GET("/folder", customLinkController.GetAllFolder)
+		customLinkRouteAuth.PUT("/folder/:folder_id", customLinkController.UpdateFolder)
+		customLinkRouteAuth.DELETE("/folder/:folder_id", customLinkController.DeleteFolder)
+		customLinkRouteAuth.POST("/domain", customLinkController.CreateDomain)
+		customLinkRouteAuth.GET("/domain", customLinkController.GetAllDomain)
+		customLinkRouteAuth.POST("/domain/:domain_id/verify", customLinkController.VerifyDomain)
+		customLinkRouteAuth.DELETE("/domain/:domain_id", customLinkController.DeleteDomain)
 		customLinkRouteAuth.GET("/check-short-code", customLinkController.CheckShortLinkAvaibility)
 		customLinkRouteAuth.GET("/analytic", customLinkController.GetLinkAnalytic)
 		customLinkRouteAuth.GET("/analytic/summary", customLinkController.GetSummaryLinkAnalytic)
@@ -46,4 +73,5 @@ func AddCustomLinkRoute(server *Server, customLinkController controller.CustomLi
 
 	redirectCustomLinkRoute := server.Router.Group("/l")
 	redirectCustomLinkRoute.GET("/:short_link_code", customLinkController.RedirectLink)
+	redirectCustomLinkRoute.POST("/:short_link_code", customLinkController.UnlockLink)
 }
@@ -1,6 +1,7 @@
 package router
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"os"
@@ -10,8 +11,12 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/ilhamfzri/pendek.in/config"
+	"github.com/ilhamfzri/pendek.in/internal/view"
 )
 
+// ShutdownTimeout is how long the requests in flight get to finish once the server is stopped.
+var ShutdownTimeout = 10 * time.Second
+
 type Server struct {
 	Server *http.Server
 	Router *gin.Engine
@@ -19,6 +24,14 @@ type Server struct {
 
 func NewServer(cfg config.ServerConfig) *Server {
 	router := gin.Default()
+	router.SetHTMLTemplate(view.Templates)
+
+	// Without trusted proxies the client ip is the address of the connection, a client can't pick its own ip
+	// through X-Forwarded-For to get around the rate limits.
+	err := router.SetTrustedProxies(cfg.TrustedProxies)
+	if err != nil {
+		panic(err)
+	}
 
 	readTimeout := time.Duration(cfg.ReadTimeout)
 	writeTimeout := time.Duration(cfg.WriteTimeout)
@@ -48,6 +61,21 @@ func NewServer(cfg config.ServerConfig) *Server {
 	}
 }
 
-func (server *Server) Run() {
-	server.Server.ListenAndServe()
+// Run serves until the context is done, then shuts the server down gracefully.
+func (server *Server) Run(ctx context.Context) {
+	stopped := make(chan struct{})
+	go func() {
+		server.Server.ListenAndServe()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+		return
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), ShutdownTimeout)
+	defer cancel()
+	server.Server.Shutdown(shutdownCtx)
 }
@@ -15,6 +15,7 @@ func AddSocialMediaRoute(server *Server, socialMediaLinkController controller.So
 		socialMediaRouteAuth.POST("/", socialMediaLinkController.CreateLink)
 		socialMediaRouteAuth.PUT("/:type_id", socialMediaLinkController.UpdateLink)
 		socialMediaRouteAuth.GET("/", socialMediaLinkController.GetAllLink)
+		socialMediaRouteAuth.PUT("/reorder", socialMediaLinkController.ReorderLink)
 		socialMediaRouteAuth.GET("/analytic", socialMediaLinkController.GetLinkAnalytic)
 		socialMediaRouteAuth.GET("/analytic/summary", socialMediaLinkController.GetSummaryLinkAnalytic)
 	}
@@ -50,5 +50,6 @@ func AddUsersRoute(server *Server, userController controller.UserController, jwt
 
 	userRoutePublic := server.Router.Group("")
 	userRoutePublic.GET("/:username", userController.Profile)
+	userRoutePublic.GET("/:username/qr", userController.ProfileQRCode)
 
 }
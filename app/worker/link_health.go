@@ -0,0 +1,86 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/ilhamfzri/pendek.in/app/logger"
+	"github.com/ilhamfzri/pendek.in/config"
+	"github.com/ilhamfzri/pendek.in/internal/service"
+)
+
+var DefaultLinkHealthInterval = time.Hour
+
+type LinkHealthWorker struct {
+	Service  service.LinkHealthService
+	Interval time.Duration
+	Lock     *Lock
+	Logger   *logger.Logger
+}
+
+// NewLinkHealthWorker builds the worker, every replica starts one but the lock lets a single replica check per interval.
+func NewLinkHealthWorker(linkHealthService service.LinkHealthService, cfg config.LinkHealthConfig, redis *redis.Client, logger *logger.Logger) *LinkHealthWorker {
+	interval := time.Duration(cfg.IntervalMinutes) * time.Minute
+	if interval <= 0 {
+		interval = DefaultLinkHealthInterval
+	}
+
+	return &LinkHealthWorker{
+		Service:  linkHealthService,
+		Interval: interval,
+		Lock:     NewLock(redis, "link-health", interval),
+		Logger:   logger,
+	}
+}
+
+// Start checks the links right away and then once every interval until the context is done,
+// a run that takes longer than the interval delays the next one instead of overlapping it.
+func (worker *LinkHealthWorker) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(worker.Interval)
+		defer ticker.Stop()
+
+		for {
+			worker.RunOnce(ctx)
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// RunOnce runs a single check of all links unless another replica holds the lock,
+// a panic is logged so it doesn't take the server down.
+func (worker *LinkHealthWorker) RunOnce(ctx context.Context) {
+	defer func() {
+		if r := recover(); r != nil {
+			worker.Logger.Error().Interface("panic", r).Msg("[Link Health Worker] Run Panicked")
+		}
+	}()
+
+	token, acquired, err := worker.Lock.Acquire(ctx)
+	if err != nil {
+		worker.Logger.Error().Err(err).Msg("[Link Health Worker] Failed Acquire Lock")
+		return
+	}
+
+	if !acquired {
+		worker.Logger.Info().Msg("[Link Health Worker] Skipped, Another Instance Holds The Lock")
+		return
+	}
+
+	stop := worker.Lock.KeepAlive(ctx, token)
+	defer stop()
+
+	start := time.Now()
+	err = worker.Service.CheckAllLinks(ctx)
+	if err != nil {
+		worker.Logger.Error().Err(err).Msg("[Link Health Worker] Failed Check Links")
+		return
+	}
+	worker.Logger.Info().Dur("duration", time.Since(start)).Msg("[Link Health Worker] Checked Links")
+}
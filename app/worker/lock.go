@@ -0,0 +1,66 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/ilhamfzri/pendek.in/helper"
+)
+
+// Extends the lock only while it's still held by the same run, an expired lock may belong to another replica by now.
+var extendLockScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("PEXPIRE", KEYS[1], ARGV[2])
+end
+return 0
+`)
+
+// Lock makes a worker run on one replica at a time. It's kept for the whole ttl even when the run is done
+// earlier, so the other replicas skip the interval instead of running right after.
+type Lock struct {
+	Redis *redis.Client
+	Key   string
+	TTL   time.Duration
+}
+
+func NewLock(redis *redis.Client, name string, ttl time.Duration) *Lock {
+	return &Lock{
+		Redis: redis,
+		Key:   helper.GenerateCacheKeyWorkerLock(name),
+		TTL:   ttl,
+	}
+}
+
+// Acquire takes the lock and returns the token of the run, false when another replica holds the lock.
+func (lock *Lock) Acquire(ctx context.Context) (string, bool, error) {
+	hostname, _ := os.Hostname()
+	token := fmt.Sprintf("%s:%d:%d", hostname, os.Getpid(), time.Now().UnixNano())
+
+	acquired, err := lock.Redis.SetNX(ctx, lock.Key, token, lock.TTL).Result()
+	if err != nil || !acquired {
+		return "", false, err
+	}
+	return token, true, nil
+}
+
+// KeepAlive extends the lock every half ttl until stop is called, a run longer than the ttl keeps the lock.
+func (lock *Lock) KeepAlive(ctx context.Context, token string) (stop func()) {
+	ctx, cancel := context.WithCancel(ctx)
+	go func() {
+		ticker := time.NewTicker(lock.TTL / 2)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				extendLockScript.Run(ctx, lock.Redis, []string{lock.Key}, token, lock.TTL.Milliseconds())
+			}
+		}
+	}()
+	return cancel
+}
@@ -0,0 +1,79 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/ilhamfzri/pendek.in/app/logger"
+	"github.com/ilhamfzri/pendek.in/internal/service"
+)
+
+var DefaultShortLinkCodeReleaseInterval = time.Hour
+
+type ShortLinkCodeReleaseWorker struct {
+	Service  service.CustomLinkService
+	Interval time.Duration
+	Lock     *Lock
+	Logger   *logger.Logger
+}
+
+// NewShortLinkCodeReleaseWorker builds the worker releasing the short link codes of deleted links,
+// every replica starts one but the lock lets a single replica release per interval.
+func NewShortLinkCodeReleaseWorker(customLinkService service.CustomLinkService, redis *redis.Client, logger *logger.Logger) *ShortLinkCodeReleaseWorker {
+	return &ShortLinkCodeReleaseWorker{
+		Service:  customLinkService,
+		Interval: DefaultShortLinkCodeReleaseInterval,
+		Lock:     NewLock(redis, "short-link-code-release", DefaultShortLinkCodeReleaseInterval),
+		Logger:   logger,
+	}
+}
+
+// Start releases the codes right away and then once every interval until the context is done.
+func (worker *ShortLinkCodeReleaseWorker) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(worker.Interval)
+		defer ticker.Stop()
+
+		for {
+			worker.RunOnce(ctx)
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// RunOnce releases the codes whose retention period is over unless another replica holds the lock,
+// a panic is logged so it doesn't take the server down.
+func (worker *ShortLinkCodeReleaseWorker) RunOnce(ctx context.Context) {
+	defer func() {
+		if r := recover(); r != nil {
+			worker.Logger.Error().Interface("panic", r).Msg("[Short Link Code Release Worker] Run Panicked")
+		}
+	}()
+
+	token, acquired, err := worker.Lock.Acquire(ctx)
+	if err != nil {
+		worker.Logger.Error().Err(err).Msg("[Short Link Code Release Worker] Failed Acquire Lock")
+		return
+	}
+
+	if !acquired {
+		worker.Logger.Info().Msg("[Short Link Code Release Worker] Skipped, Another Instance Holds The Lock")
+		return
+	}
+
+	stop := worker.Lock.KeepAlive(ctx, token)
+	defer stop()
+
+	released, err := worker.Service.ReleaseShortLinkCodes(ctx)
+	if err != nil {
+		worker.Logger.Error().Err(err).Msg("[Short Link Code Release Worker] Failed Release Short Link Codes")
+		return
+	}
+	worker.Logger.Info().Int64("released", released).Msg("[Short Link Code Release Worker] Released Short Link Codes")
+}
@@ -36,10 +36,11 @@ func (config *Config) GetDatabaseConfig() DatabaseConfig {
 }
 
 type ServerConfig struct {
-	Port             int    `mapstructure:"port"`
-	WriteTimeout     int    `mapstructure:"write_timeout"`
-	ReadTimeout      int    `mapstructure:"read_timeout"`
-	ResourcesDirPath string `mapstructure:"resource_dir_path"`
+	Port             int      `mapstructure:"port"`
+	WriteTimeout     int      `mapstructure:"write_timeout"`
+	ReadTimeout      int      `mapstructure:"read_timeout"`
+	ResourcesDirPath string   `mapstructure:"resource_dir_path"`
+	TrustedProxies   []string `mapstructure:"trusted_proxies"` // the client ip is only read from the headers set by these proxies
 }
 
 func (config *Config) GetServerConfig() ServerConfig {
@@ -135,6 +136,75 @@ func (config *Config) GetMailConfig() MailConfig {
 	return mailConfig
 }
 
+type ShortCodeConfig struct {
+	Alphabet string `mapstructure:"alphabet"`
+	Length   int    `mapstructure:"length"`
+	MaxRetry int    `mapstructure:"max_retry"`
+}
+
+func (config *Config) GetShortCodeConfig() ShortCodeConfig {
+	shortCodeConfig := ShortCodeConfig{}
+	err := config.Viper.UnmarshalKey("short_code", &shortCodeConfig)
+	panicIfError(err)
+	return shortCodeConfig
+}
+
+type GeoIPConfig struct {
+	DatabasePath string `mapstructure:"database_path"`
+}
+
+func (config *Config) GetGeoIPConfig() GeoIPConfig {
+	geoIPConfig := GeoIPConfig{}
+	err := config.Viper.UnmarshalKey("geoip", &geoIPConfig)
+	panicIfError(err)
+	return geoIPConfig
+}
+
+type URLSafetyConfig struct {
+	AllowedSchemes []string `mapstructure:"allowed_schemes"`
+	BlockedDomains []string `mapstructure:"blocked_domains"`
+	AllowedDomains []string `mapstructure:"allowed_domains"`
+	OwnHosts       []string `mapstructure:"own_hosts"`
+	ThreatFeedPath string   `mapstructure:"threat_feed_path"`
+}
+
+func (config *Config) GetURLSafetyConfig() URLSafetyConfig {
+	urlSafetyConfig := URLSafetyConfig{}
+	err := config.Viper.UnmarshalKey("url_safety", &urlSafetyConfig)
+	panicIfError(err)
+	return urlSafetyConfig
+}
+
+type AdminConfig struct {
+	UserIDs []string `mapstructure:"user_ids"`
+}
+
+func (config *Config) GetAdminConfig() AdminConfig {
+	adminConfig := AdminConfig{}
+	err := config.Viper.UnmarshalKey("admin", &adminConfig)
+	panicIfError(err)
+	return adminConfig
+}
+
+type LinkHealthConfig struct {
+	Enabled              bool   `mapstructure:"enabled"`
+	IntervalMinutes      int    `mapstructure:"interval_minutes"`
+	TimeoutSeconds       int    `mapstructure:"timeout_seconds"`
+	Concurrency          int    `mapstructure:"concurrency"`
+	MaxRedirects         int    `mapstructure:"max_redirects"`
+	FailureThreshold     int    `mapstructure:"failure_threshold"`
+	BatchSize            int    `mapstructure:"batch_size"`
+	UserAgent            string `mapstructure:"user_agent"`
+	AllowPrivateNetworks bool   `mapstructure:"allow_private_networks"`
+}
+
+func (config *Config) GetLinkHealthConfig() LinkHealthConfig {
+	linkHealthConfig := LinkHealthConfig{}
+	err := config.Viper.UnmarshalKey("link_health", &linkHealthConfig)
+	panicIfError(err)
+	return linkHealthConfig
+}
+
 func panicIfError(err error) {
 	if err != nil {
 		panic(err)
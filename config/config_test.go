@@ -41,4 +41,29 @@ func TestGetConfig(t *testing.T) {
 		assert.IsType(t, JwtConfig{}, jwtConfig)
 	})
 
+	t.Run("GetShortCodeConfig", func(t *testing.T) {
+		shortCodeConfig := config.GetShortCodeConfig()
+		assert.IsType(t, ShortCodeConfig{}, shortCodeConfig)
+	})
+
+	t.Run("GetGeoIPConfig", func(t *testing.T) {
+		geoIPConfig := config.GetGeoIPConfig()
+		assert.IsType(t, GeoIPConfig{}, geoIPConfig)
+	})
+
+	t.Run("GetURLSafetyConfig", func(t *testing.T) {
+		urlSafetyConfig := config.GetURLSafetyConfig()
+		assert.IsType(t, URLSafetyConfig{}, urlSafetyConfig)
+	})
+
+	t.Run("GetAdminConfig", func(t *testing.T) {
+		adminConfig := config.GetAdminConfig()
+		assert.IsType(t, AdminConfig{}, adminConfig)
+	})
+
+	t.Run("GetLinkHealthConfig", func(t *testing.T) {
+		linkHealthConfig := config.GetLinkHealthConfig()
+		assert.IsType(t, LinkHealthConfig{}, linkHealthConfig)
+	})
+
 }
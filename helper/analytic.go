@@ -42,3 +42,13 @@ func CustomLinkInteractionsToDeviceAnalytic(customLinkInteractions *[]domain.Cus
 	}
 	return deviceAnalytic
 }
+
+func CountCustomLinkInteractionsBySource(customLinkInteractions *[]domain.CustomLinkInteraction, source string) int {
+	count := 0
+	for _, customLinkInteraction := range *customLinkInteractions {
+		if customLinkInteraction.Source == source {
+			count += 1
+		}
+	}
+	return count
+}
@@ -0,0 +1,22 @@
+package helper
+
+import "github.com/ilhamfzri/pendek.in/internal/model/web"
+
+// BatchResults reports the outcome for every requested id in the order of the request, the ids that weren't
+// found failed with the message.
+func BatchResults(requested []uint, found []uint, message string) []web.CustomLinkBatchResultResponse {
+	isFound := make(map[uint]bool, len(found))
+	for _, id := range found {
+		isFound[id] = true
+	}
+
+	results := make([]web.CustomLinkBatchResultResponse, 0, len(requested))
+	for _, id := range requested {
+		if isFound[id] {
+			results = append(results, web.CustomLinkBatchResultResponse{LinkID: id, Status: "success"})
+		} else {
+			results = append(results, web.CustomLinkBatchResultResponse{LinkID: id, Status: "failed", Message: message})
+		}
+	}
+	return results
+}
@@ -0,0 +1,21 @@
+package helper
+
+import (
+	"testing"
+
+	"github.com/ilhamfzri/pendek.in/internal/model/web"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBatchResults(t *testing.T) {
+	results := BatchResults([]uint{5, 1, 9, 3}, []uint{1, 3, 5}, "link is not registered")
+	assert.Equal(t, []web.CustomLinkBatchResultResponse{
+		{LinkID: 5, Status: "success"},
+		{LinkID: 1, Status: "success"},
+		{LinkID: 9, Status: "failed", Message: "link is not registered"},
+		{LinkID: 3, Status: "success"},
+	}, results)
+
+	results = BatchResults([]uint{2}, nil, "link is not registered")
+	assert.Equal(t, []web.CustomLinkBatchResultResponse{{LinkID: 2, Status: "failed", Message: "link is not registered"}}, results)
+}
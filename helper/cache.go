@@ -2,10 +2,26 @@ package helper
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 )
 
+// GenerateCacheKeyUnlockAttempt keys the unlock attempts per domain, short link code and client. The code is lower
+// cased so a case insensitive link can't be guessed through every casing of its code.
+func GenerateCacheKeyUnlockAttempt(domainName string, shortLinkCode string, clientIP string) string {
+	return fmt.Sprintf("unlock-attempt:%s:%s:%s", NormalizeDomainName(domainName), strings.ToLower(shortLinkCode), clientIP)
+}
+
+// GenerateCacheKeyUnlockAttemptLink keys the unlock attempts of a link from every client.
+func GenerateCacheKeyUnlockAttemptLink(domainName string, shortLinkCode string) string {
+	return fmt.Sprintf("unlock-attempt-link:%s:%s", NormalizeDomainName(domainName), strings.ToLower(shortLinkCode))
+}
+
+func GenerateCacheKeyWorkerLock(name string) string {
+	return fmt.Sprintf("worker-lock:%s", name)
+}
+
 func GenerateCacheKeyByJwt(c *gin.Context) string {
 	requestUri := c.Request.URL.RequestURI()
 	jwt := ExtractTokenFromRequestHeader(c)
@@ -0,0 +1,16 @@
+package helper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateCacheKeyUnlockAttempt(t *testing.T) {
+	assert.Equal(t, "unlock-attempt:pendek.in:promo1:10.0.0.1", GenerateCacheKeyUnlockAttempt("Pendek.In:443", "Promo1", "10.0.0.1"))
+	assert.NotEqual(t, GenerateCacheKeyUnlockAttempt("pendek.in", "promo1", "10.0.0.1"), GenerateCacheKeyUnlockAttempt("brand.example", "promo1", "10.0.0.1"))
+}
+
+func TestGenerateCacheKeyUnlockAttemptLink(t *testing.T) {
+	assert.Equal(t, "unlock-attempt-link:pendek.in:promo1", GenerateCacheKeyUnlockAttemptLink("Pendek.In:443", "Promo1"))
+}
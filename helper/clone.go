@@ -0,0 +1,61 @@
+package helper
+
+import "github.com/ilhamfzri/pendek.in/internal/model/domain"
+
+// CloneCustomLink copies the configuration of the link into a new link, the short link code, the position
+// and everything tracked per link such as analytics and interactions start empty.
+func CloneCustomLink(source *domain.CustomLink) domain.CustomLink {
+	return domain.CustomLink{
+		UserID:              source.UserID,
+		Title:               source.Title,
+		DomainID:            source.DomainID,
+		LongLink:            source.LongLink,
+		CaseInsensitive:     source.CaseInsensitive,
+		ShowOnProfile:       source.ShowOnProfile,
+		Activate:            source.Activate,
+		RedirectStatus:      source.RedirectStatus,
+		ForwardQuery:        source.ForwardQuery,
+		Interstitial:        source.Interstitial,
+		ExpiresAt:           source.ExpiresAt,
+		MaxClicks:           source.MaxClicks,
+		Password:            source.Password,
+		ActiveFrom:          source.ActiveFrom,
+		ActiveUntil:         source.ActiveUntil,
+		Utm:                 source.Utm,
+		CustomThumbnailID:   source.CustomThumbnailID,
+		ThumbnailID:         source.ThumbnailID,
+		OgTitle:             source.OgTitle,
+		OgDescription:       source.OgDescription,
+		OgCustomThumbnailID: source.OgCustomThumbnailID,
+		FolderID:            source.FolderID,
+		Tags:                source.Tags,
+	}
+}
+
+func CloneTargetingRules(sources []domain.CustomLinkTargetingRule) []domain.CustomLinkTargetingRule {
+	var targetingRules []domain.CustomLinkTargetingRule
+	for _, source := range sources {
+		targetingRules = append(targetingRules, domain.CustomLinkTargetingRule{
+			Position:   source.Position,
+			Device:     source.Device,
+			OS:         source.OS,
+			Country:    source.Country,
+			Language:   source.Language,
+			TargetLink: source.TargetLink,
+		})
+	}
+	return targetingRules
+}
+
+func CloneVariants(sources []domain.CustomLinkVariant) []domain.CustomLinkVariant {
+	var variants []domain.CustomLinkVariant
+	for _, source := range sources {
+		variants = append(variants, domain.CustomLinkVariant{
+			Position:   source.Position,
+			Name:       source.Name,
+			TargetLink: source.TargetLink,
+			Weight:     source.Weight,
+		})
+	}
+	return variants
+}
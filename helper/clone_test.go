@@ -0,0 +1,86 @@
+package helper
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ilhamfzri/pendek.in/internal/model/domain"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestCloneCustomLink(t *testing.T) {
+	thumbnailID := uint(2)
+	folderID := uint(3)
+	maxClicks := uint(100)
+	expiresAt := time.Now().Add(time.Hour)
+	source := domain.CustomLink{
+		Model:                 gorm.Model{ID: 7, CreatedAt: time.Now()},
+		UserID:                "user",
+		Title:                 "Promo",
+		ShortLinkCode:         "promo",
+		ReleasedShortLinkCode: "oldpromo",
+		LongLink:              "https://example.com",
+		ShowOnProfile:         true,
+		Activate:              true,
+		Position:              4,
+		RedirectStatus:        301,
+		ForwardQuery:          true,
+		Interstitial:          true,
+		ExpiresAt:             &expiresAt,
+		MaxClicks:             &maxClicks,
+		Password:              "hash",
+		Utm:                   domain.Utm{Source: "newsletter"},
+		ThumbnailID:           &thumbnailID,
+		Thumbnail:             domain.Thumbnail{Name: "Home"},
+		OgTitle:               "Og",
+		FolderID:              &folderID,
+		Tags:                  []domain.Tag{{Name: "summer"}},
+		CustomLinkAnalytic:    []domain.CustomLinkAnalytic{{ClickCount: 10}},
+	}
+
+	clone := CloneCustomLink(&source)
+	assert.Zero(t, clone.ID)
+	assert.Zero(t, clone.CreatedAt)
+	assert.Empty(t, clone.ShortLinkCode)
+	assert.Empty(t, clone.ReleasedShortLinkCode)
+	assert.Zero(t, clone.Position)
+	assert.Empty(t, clone.CustomLinkAnalytic)
+	assert.Empty(t, clone.Thumbnail.Name)
+
+	assert.Equal(t, source.UserID, clone.UserID)
+	assert.Equal(t, source.Title, clone.Title)
+	assert.Equal(t, source.LongLink, clone.LongLink)
+	assert.Equal(t, source.RedirectStatus, clone.RedirectStatus)
+	assert.Equal(t, source.ForwardQuery, clone.ForwardQuery)
+	assert.Equal(t, source.Interstitial, clone.Interstitial)
+	assert.Equal(t, source.ExpiresAt, clone.ExpiresAt)
+	assert.Equal(t, source.MaxClicks, clone.MaxClicks)
+	assert.Equal(t, source.Password, clone.Password)
+	assert.Equal(t, source.Utm, clone.Utm)
+	assert.Equal(t, source.ThumbnailID, clone.ThumbnailID)
+	assert.Equal(t, source.OgTitle, clone.OgTitle)
+	assert.Equal(t, source.FolderID, clone.FolderID)
+	assert.Equal(t, source.Tags, clone.Tags)
+}
+
+func TestCloneTargetingRulesAndVariants(t *testing.T) {
+	targetingRules := CloneTargetingRules([]domain.CustomLinkTargetingRule{
+		{Model: gorm.Model{ID: 1}, CustomLinkID: 7, Device: "mobile", TargetLink: "https://m.example.com"},
+	})
+	assert.Len(t, targetingRules, 1)
+	assert.Zero(t, targetingRules[0].ID)
+	assert.Zero(t, targetingRules[0].CustomLinkID)
+	assert.Equal(t, "mobile", targetingRules[0].Device)
+	assert.Equal(t, "https://m.example.com", targetingRules[0].TargetLink)
+
+	variants := CloneVariants([]domain.CustomLinkVariant{
+		{Model: gorm.Model{ID: 1}, CustomLinkID: 7, Name: "A", TargetLink: "https://a.example.com", Weight: 3},
+	})
+	assert.Len(t, variants, 1)
+	assert.Zero(t, variants[0].ID)
+	assert.Zero(t, variants[0].CustomLinkID)
+	assert.Equal(t, uint(3), variants[0].Weight)
+
+	assert.Nil(t, CloneVariants(nil))
+}
@@ -0,0 +1,87 @@
+package domainverify
+
+// A domain is verified by a TXT record at _pendekin.<domain> holding "pendekin-verification=<token>".
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"net"
+	"strings"
+	"time"
+)
+
+var (
+	RecordNamePrefix  = "_pendekin."
+	RecordValuePrefix = "pendekin-verification="
+	LookupTimeout     = 5 * time.Second
+)
+
+var (
+	ErrRecordNotFound = errors.New("verification TXT record is not found")
+	ErrLookupFailed   = errors.New("failed to look up the verification TXT record, please try again")
+)
+
+// Resolver looks up TXT records, *net.Resolver satisfies it.
+type Resolver interface {
+	LookupTXT(ctx context.Context, name string) ([]string, error)
+}
+
+// StubResolver answers from its map, it stands in for the DNS in tests and local setups.
+type StubResolver map[string][]string
+
+func (resolver StubResolver) LookupTXT(ctx context.Context, name string) ([]string, error) {
+	records, ok := resolver[strings.TrimSuffix(name, ".")]
+	if !ok {
+		return nil, &net.DNSError{Err: "no such host", Name: name, IsNotFound: true}
+	}
+	return records, nil
+}
+
+type Verifier struct {
+	Resolver Resolver
+}
+
+func NewVerifier(resolver Resolver) *Verifier {
+	return &Verifier{Resolver: resolver}
+}
+
+func NewToken() (string, error) {
+	token := make([]byte, 16)
+	if _, err := rand.Read(token); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(token), nil
+}
+
+func RecordName(domainName string) string {
+	return RecordNamePrefix + domainName
+}
+
+func RecordValue(token string) string {
+	return RecordValuePrefix + token
+}
+
+// Verify looks for the record of the token, a missing domain and a missing record are both ErrRecordNotFound.
+func (verifier *Verifier) Verify(ctx context.Context, domainName string, token string) error {
+	ctx, cancel := context.WithTimeout(ctx, LookupTimeout)
+	defer cancel()
+
+	records, err := verifier.Resolver.LookupTXT(ctx, RecordName(domainName))
+	if err != nil {
+		var dnsError *net.DNSError
+		if errors.As(err, &dnsError) && dnsError.IsNotFound {
+			return ErrRecordNotFound
+		}
+		return ErrLookupFailed
+	}
+
+	expected := RecordValue(token)
+	for _, record := range records {
+		if strings.TrimSpace(record) == expected {
+			return nil
+		}
+	}
+	return ErrRecordNotFound
+}
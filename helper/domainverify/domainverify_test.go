@@ -0,0 +1,37 @@
+package domainverify
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type failingResolver struct{}
+
+func (resolver failingResolver) LookupTXT(ctx context.Context, name string) ([]string, error) {
+	return nil, errors.New("i/o timeout")
+}
+
+func TestVerify(t *testing.T) {
+	verifier := NewVerifier(StubResolver{
+		"_pendekin.brand.test": {"v=spf1 -all", RecordValue("abc123")},
+		"_pendekin.other.test": {RecordValue("zzz")},
+	})
+	ctx := context.Background()
+
+	assert.Nil(t, verifier.Verify(ctx, "brand.test", "abc123"))
+	assert.Equal(t, ErrRecordNotFound, verifier.Verify(ctx, "other.test", "abc123"))
+	assert.Equal(t, ErrRecordNotFound, verifier.Verify(ctx, "missing.test", "abc123"))
+	assert.Equal(t, ErrLookupFailed, NewVerifier(failingResolver{}).Verify(ctx, "brand.test", "abc123"))
+}
+
+func TestNewToken(t *testing.T) {
+	first, err := NewToken()
+	assert.Nil(t, err)
+	second, err := NewToken()
+	assert.Nil(t, err)
+	assert.Len(t, first, 32)
+	assert.NotEqual(t, first, second)
+}
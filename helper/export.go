@@ -0,0 +1,141 @@
+package helper
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/ilhamfzri/pendek.in/helper/xlsx"
+	"github.com/ilhamfzri/pendek.in/internal/model/web"
+)
+
+var ErrExportFormat = errors.New("export format must be csv, json or xlsx")
+
+var customLinkExportContentTypes = map[string]string{
+	"csv":  "text/csv; charset=utf-8",
+	"json": "application/json; charset=utf-8",
+	"xlsx": "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+}
+
+// CustomLinkExportWriter writes exported links one row at a time, so large accounts can be streamed.
+type CustomLinkExportWriter interface {
+	Write(row web.CustomLinkExportResponse) error
+	Close() error
+}
+
+func CustomLinkExportContentType(format string) string {
+	return customLinkExportContentTypes[format]
+}
+
+func NewCustomLinkExportWriter(format string, w io.Writer, withAnalytic bool) (CustomLinkExportWriter, error) {
+	switch format {
+	case "csv":
+		writer := &customLinkExportTableWriter{writeRow: csvWriteRow(csv.NewWriter(w)), withAnalytic: withAnalytic}
+		return writer, writer.writeHeader()
+	case "xlsx":
+		xlsxWriter, err := xlsx.NewWriter(w)
+		if err != nil {
+			return nil, err
+		}
+		writer := &customLinkExportTableWriter{writeRow: xlsxWriter.WriteRow, close: xlsxWriter.Close, withAnalytic: withAnalytic}
+		return writer, writer.writeHeader()
+	case "json":
+		_, err := io.WriteString(w, "[")
+		return &customLinkExportJsonWriter{writer: w}, err
+	default:
+		return nil, ErrExportFormat
+	}
+}
+
+type customLinkExportTableWriter struct {
+	writeRow     func(cells []string) error
+	close        func() error
+	withAnalytic bool
+}
+
+func csvWriteRow(csvWriter *csv.Writer) func(cells []string) error {
+	return func(cells []string) error {
+		if err := csvWriter.Write(cells); err != nil {
+			return err
+		}
+		csvWriter.Flush()
+		return csvWriter.Error()
+	}
+}
+
+func (writer *customLinkExportTableWriter) writeHeader() error {
+	header := []string{"id", "title", "short_link_code", "long_link", "redirect_link", "thumbnail_url",
+		"show_on_profile", "activate", "expired", "password_protected", "expires_at", "created_at", "updated_at"}
+	if writer.withAnalytic {
+		header = append(header, "total_click_count")
+	}
+	return writer.writeRow(header)
+}
+
+func (writer *customLinkExportTableWriter) Write(row web.CustomLinkExportResponse) error {
+	var expiresAt string
+	if row.ExpiresAt != nil {
+		expiresAt = row.ExpiresAt.Format(time.RFC3339)
+	}
+
+	cells := []string{
+		strconv.Itoa(int(row.ID)),
+		row.Title,
+		row.ShortLinkCode,
+		row.LongLink,
+		row.RedirectLink,
+		row.ThumbnailUrl,
+		strconv.FormatBool(row.ShowOnProfile),
+		strconv.FormatBool(row.Activate),
+		strconv.FormatBool(row.Expired),
+		strconv.FormatBool(row.PasswordProtected),
+		expiresAt,
+		row.CreatedAt.Format(time.RFC3339),
+		row.UpdatedAt.Format(time.RFC3339),
+	}
+
+	if writer.withAnalytic {
+		var totalClickCount int
+		if row.TotalClickCount != nil {
+			totalClickCount = *row.TotalClickCount
+		}
+		cells = append(cells, strconv.Itoa(totalClickCount))
+	}
+	return writer.writeRow(cells)
+}
+
+func (writer *customLinkExportTableWriter) Close() error {
+	if writer.close != nil {
+		return writer.close()
+	}
+	return nil
+}
+
+type customLinkExportJsonWriter struct {
+	writer   io.Writer
+	rowCount int
+}
+
+func (writer *customLinkExportJsonWriter) Write(row web.CustomLinkExportResponse) error {
+	if writer.rowCount > 0 {
+		if _, err := io.WriteString(writer.writer, ","); err != nil {
+			return err
+		}
+	}
+	writer.rowCount += 1
+
+	bytes, err := json.Marshal(row)
+	if err != nil {
+		return err
+	}
+	_, err = writer.writer.Write(bytes)
+	return err
+}
+
+func (writer *customLinkExportJsonWriter) Close() error {
+	_, err := io.WriteString(writer.writer, "]")
+	return err
+}
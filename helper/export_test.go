@@ -0,0 +1,59 @@
+package helper
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/ilhamfzri/pendek.in/internal/model/web"
+	"github.com/stretchr/testify/assert"
+)
+
+var exportRows = []web.CustomLinkExportResponse{
+	{ID: 1, Title: "Home", ShortLinkCode: "home01", LongLink: "https://example.com", Activate: true},
+	{ID: 2, Title: "Blog, News", ShortLinkCode: "blog01", LongLink: "https://example.com/blog"},
+}
+
+func TestCustomLinkExportWriter(t *testing.T) {
+	t.Run("[ExportCSV][Success]", func(t *testing.T) {
+		var buffer bytes.Buffer
+		writer, err := NewCustomLinkExportWriter("csv", &buffer, true)
+		assert.Nil(t, err)
+		for _, row := range exportRows {
+			assert.Nil(t, writer.Write(row))
+		}
+		assert.Nil(t, writer.Close())
+
+		lines := strings.Split(strings.TrimSpace(buffer.String()), "\n")
+		assert.Len(t, lines, 3)
+		assert.True(t, strings.HasSuffix(lines[0], ",total_click_count"))
+		assert.Contains(t, lines[2], `"Blog, News"`)
+	})
+
+	t.Run("[ExportJSON][Success]", func(t *testing.T) {
+		var buffer bytes.Buffer
+		writer, err := NewCustomLinkExportWriter("json", &buffer, false)
+		assert.Nil(t, err)
+		for _, row := range exportRows {
+			assert.Nil(t, writer.Write(row))
+		}
+		assert.Nil(t, writer.Close())
+
+		var result []web.CustomLinkExportResponse
+		assert.Nil(t, json.Unmarshal(buffer.Bytes(), &result))
+		assert.Len(t, result, 2)
+	})
+
+	t.Run("[ExportJSON][Empty]", func(t *testing.T) {
+		var buffer bytes.Buffer
+		writer, _ := NewCustomLinkExportWriter("json", &buffer, false)
+		assert.Nil(t, writer.Close())
+		assert.Equal(t, "[]", buffer.String())
+	})
+
+	t.Run("[Export][Failed: Invalid Format]", func(t *testing.T) {
+		_, err := NewCustomLinkExportWriter("pdf", &bytes.Buffer{}, false)
+		assert.Equal(t, ErrExportFormat, err)
+	})
+}
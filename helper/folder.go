@@ -0,0 +1,63 @@
+package helper
+
+import (
+	"github.com/ilhamfzri/pendek.in/internal/model/domain"
+	"github.com/ilhamfzri/pendek.in/internal/model/web"
+)
+
+// FoldersToTree nests the folders of a user under their parents, folders with a missing parent end up at the root.
+func FoldersToTree(folders []domain.Folder) []web.FolderResponse {
+	exists := make(map[uint]bool)
+	for _, folder := range folders {
+		exists[folder.ID] = true
+	}
+
+	children := make(map[uint][]domain.Folder)
+	var roots []domain.Folder
+	for _, folder := range folders {
+		if folder.ParentID == nil || !exists[*folder.ParentID] {
+			roots = append(roots, folder)
+			continue
+		}
+		children[*folder.ParentID] = append(children[*folder.ParentID], folder)
+	}
+
+	var buildTree func(folders []domain.Folder) []web.FolderResponse
+	buildTree = func(folders []domain.Folder) []web.FolderResponse {
+		var foldersResponse []web.FolderResponse
+		for _, folder := range folders {
+			folderResponse := FolderDomainToResponse(&folder)
+			folderResponse.Children = buildTree(children[folder.ID])
+			foldersResponse = append(foldersResponse, folderResponse)
+		}
+		return foldersResponse
+	}
+	return buildTree(roots)
+}
+
+// FolderDescendantIDs returns the ids of every folder nested below the folder, at any depth.
+func FolderDescendantIDs(folders []domain.Folder, folderID uint) []uint {
+	children := make(map[uint][]uint)
+	for _, folder := range folders {
+		if folder.ParentID != nil {
+			children[*folder.ParentID] = append(children[*folder.ParentID], folder.ID)
+		}
+	}
+
+	var descendantIDs []uint
+	visited := map[uint]bool{folderID: true}
+	queue := []uint{folderID}
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		for _, childID := range children[id] {
+			if visited[childID] {
+				continue
+			}
+			visited[childID] = true
+			descendantIDs = append(descendantIDs, childID)
+			queue = append(queue, childID)
+		}
+	}
+	return descendantIDs
+}
@@ -0,0 +1,37 @@
+package helper
+
+import (
+	"testing"
+
+	"github.com/ilhamfzri/pendek.in/internal/model/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func folderParent(id uint) *uint {
+	return &id
+}
+
+var folders = []domain.Folder{
+	{ID: 1, Name: "Marketing"},
+	{ID: 2, Name: "Campaign", ParentID: folderParent(1)},
+	{ID: 3, Name: "Summer", ParentID: folderParent(2)},
+	{ID: 4, Name: "Personal"},
+	{ID: 5, Name: "Orphan", ParentID: folderParent(99)},
+}
+
+func TestFoldersToTree(t *testing.T) {
+	tree := FoldersToTree(folders)
+
+	assert.Len(t, tree, 3)
+	assert.Equal(t, "Marketing", tree[0].Name)
+	assert.Equal(t, "Campaign", tree[0].Children[0].Name)
+	assert.Equal(t, "Summer", tree[0].Children[0].Children[0].Name)
+	assert.Empty(t, tree[1].Children)
+	assert.Equal(t, "Orphan", tree[2].Name)
+}
+
+func TestFolderDescendantIDs(t *testing.T) {
+	assert.ElementsMatch(t, []uint{2, 3}, FolderDescendantIDs(folders, 1))
+	assert.ElementsMatch(t, []uint{3}, FolderDescendantIDs(folders, 2))
+	assert.Empty(t, FolderDescendantIDs(folders, 4))
+}
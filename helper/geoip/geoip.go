@@ -0,0 +1,126 @@
+package geoip
+
+// The database is a local csv file, each row holds a network in CIDR notation and the ISO 3166-1 alpha-2
+// code of its country, e.g. "1.0.0.0/24,AU". This is the layout produced when joining the GeoLite2
+// country blocks with the country locations, a header row is allowed.
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/netip"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/ilhamfzri/pendek.in/config"
+)
+
+type ipRange struct {
+	Start   netip.Addr
+	End     netip.Addr
+	Country string
+}
+
+type Database struct {
+	ranges []ipRange
+}
+
+// NewDatabase loads the database from the configured path, an empty path gives a database that doesn't know any country.
+func NewDatabase(cfg config.GeoIPConfig) (*Database, error) {
+	if cfg.DatabasePath == "" {
+		return &Database{}, nil
+	}
+
+	file, err := os.Open(cfg.DatabasePath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return Load(file)
+}
+
+func Load(reader io.Reader) (*Database, error) {
+	csvReader := csv.NewReader(reader)
+	csvReader.TrimLeadingSpace = true
+	csvReader.FieldsPerRecord = -1
+
+	var ranges []ipRange
+	for row := 1; ; row++ {
+		record, err := csvReader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(record) < 2 {
+			return nil, fmt.Errorf("row %d: expected network and country columns", row)
+		}
+
+		prefix, err := netip.ParsePrefix(strings.TrimSpace(record[0]))
+		if err != nil {
+			// It's the header row.
+			if row == 1 {
+				continue
+			}
+			return nil, fmt.Errorf("row %d: %w", row, err)
+		}
+
+		country := strings.ToUpper(strings.TrimSpace(record[1]))
+		if country == "" {
+			continue
+		}
+
+		prefix = prefix.Masked()
+		ranges = append(ranges, ipRange{
+			Start:   prefix.Addr(),
+			End:     lastAddr(prefix),
+			Country: country,
+		})
+	}
+
+	sort.Slice(ranges, func(i, j int) bool {
+		return ranges[i].Start.Less(ranges[j].Start)
+	})
+
+	return &Database{ranges: ranges}, nil
+}
+
+// Country returns the country code of the ip address, or an empty string when it's unknown.
+func (database *Database) Country(ip string) string {
+	if database == nil {
+		return ""
+	}
+
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return ""
+	}
+	addr = addr.Unmap()
+
+	// It's finding the last range starting at or before the address.
+	i := sort.Search(len(database.ranges), func(i int) bool {
+		return addr.Less(database.ranges[i].Start)
+	}) - 1
+	if i < 0 {
+		return ""
+	}
+
+	ipRange := database.ranges[i]
+	if ipRange.End.Less(addr) || ipRange.Start.BitLen() != addr.BitLen() {
+		return ""
+	}
+	return ipRange.Country
+}
+
+func lastAddr(prefix netip.Prefix) netip.Addr {
+	bytes := prefix.Addr().AsSlice()
+	for bit := prefix.Bits(); bit < len(bytes)*8; bit++ {
+		bytes[bit/8] |= 0x80 >> (bit % 8)
+	}
+	addr, _ := netip.AddrFromSlice(bytes)
+	return addr
+}
@@ -0,0 +1,52 @@
+package geoip
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ilhamfzri/pendek.in/config"
+	"github.com/stretchr/testify/assert"
+)
+
+var database = `network,country_iso_code
+36.64.0.0/11,ID
+1.0.0.0/24,au
+2001:df0::/32,JP
+`
+
+func TestCountry(t *testing.T) {
+	db, err := Load(strings.NewReader(database))
+	assert.Nil(t, err)
+
+	tests := []struct {
+		Name     string
+		IP       string
+		Expected string
+	}{
+		{Name: "[Start Of Network]", IP: "36.64.0.0", Expected: "ID"},
+		{Name: "[End Of Network]", IP: "36.95.255.255", Expected: "ID"},
+		{Name: "[Lower Case Country]", IP: "1.0.0.10", Expected: "AU"},
+		{Name: "[IPv6]", IP: "2001:df0::1", Expected: "JP"},
+		{Name: "[IPv4 Mapped IPv6]", IP: "::ffff:36.70.1.1", Expected: "ID"},
+		{Name: "[Between Networks]", IP: "36.96.0.0", Expected: ""},
+		{Name: "[Before Networks]", IP: "0.0.0.1", Expected: ""},
+		{Name: "[Invalid IP]", IP: "localhost", Expected: ""},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			assert.Equal(t, test.Expected, db.Country(test.IP))
+		})
+	}
+}
+
+func TestLoadInvalidNetwork(t *testing.T) {
+	_, err := Load(strings.NewReader("1.0.0.0/24,AU\nnot-a-network,ID\n"))
+	assert.NotNil(t, err)
+}
+
+func TestNewDatabaseWithoutPath(t *testing.T) {
+	db, err := NewDatabase(config.GeoIPConfig{})
+	assert.Nil(t, err)
+	assert.Equal(t, "", db.Country("36.64.0.0"))
+}
@@ -0,0 +1,42 @@
+package helper
+
+import (
+	"time"
+
+	"github.com/ilhamfzri/pendek.in/helper/healthcheck"
+	"github.com/ilhamfzri/pendek.in/internal/model/domain"
+)
+
+// ApplyLinkHealth records the result of a check on the health of the link. The link is flagged as broken
+// once it fails threshold times in a row, notify is true when the owner hasn't been told about it yet.
+func ApplyLinkHealth(health domain.LinkHealth, result healthcheck.Result, threshold int, now time.Time) (domain.LinkHealth, bool) {
+	health.StatusCode = result.StatusCode
+	health.LatencyMs = result.Latency.Milliseconds()
+	health.CheckedAt = &now
+
+	health.RedirectChain = nil
+	for _, hop := range result.RedirectChain {
+		health.RedirectChain = append(health.RedirectChain, domain.LinkRedirectHop{Url: hop.Url, StatusCode: hop.StatusCode})
+	}
+
+	health.Error = ""
+	if result.Err != nil {
+		health.Error = result.Err.Error()
+	}
+
+	if result.Healthy() {
+		health.FailureCount = 0
+		health.Broken = false
+		health.NotifiedAt = nil
+		return health, false
+	}
+
+	health.FailureCount++
+	health.Broken = health.FailureCount >= threshold
+	if !health.Broken || health.NotifiedAt != nil {
+		return health, false
+	}
+
+	health.NotifiedAt = &now
+	return health, true
+}
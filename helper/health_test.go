@@ -0,0 +1,60 @@
+package helper
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/ilhamfzri/pendek.in/helper/healthcheck"
+	"github.com/ilhamfzri/pendek.in/internal/model/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplyLinkHealth(t *testing.T) {
+	now := time.Date(2023, 1, 10, 8, 0, 0, 0, time.UTC)
+	failed := healthcheck.Result{Err: errors.New("connection refused"), Latency: 15 * time.Millisecond}
+	healthy := healthcheck.Result{
+		StatusCode: http.StatusOK,
+		Latency:    120 * time.Millisecond,
+		RedirectChain: []healthcheck.Hop{
+			{Url: "http://example.com", StatusCode: http.StatusMovedPermanently},
+			{Url: "https://example.com", StatusCode: http.StatusOK},
+		},
+	}
+
+	health, notify := ApplyLinkHealth(domain.LinkHealth{}, healthy, 3, now)
+	assert.False(t, notify)
+	assert.Equal(t, http.StatusOK, health.StatusCode)
+	assert.Equal(t, int64(120), health.LatencyMs)
+	assert.Equal(t, []domain.LinkRedirectHop{
+		{Url: "http://example.com", StatusCode: http.StatusMovedPermanently},
+		{Url: "https://example.com", StatusCode: http.StatusOK},
+	}, health.RedirectChain)
+	assert.Equal(t, now, *health.CheckedAt)
+
+	health, notify = ApplyLinkHealth(health, failed, 3, now)
+	assert.False(t, notify)
+	assert.False(t, health.Broken)
+	assert.Equal(t, 1, health.FailureCount)
+	assert.Equal(t, "connection refused", health.Error)
+	assert.Nil(t, health.RedirectChain)
+
+	health, _ = ApplyLinkHealth(health, failed, 3, now)
+	health, notify = ApplyLinkHealth(health, failed, 3, now)
+	assert.True(t, notify)
+	assert.True(t, health.Broken)
+	assert.Equal(t, now, *health.NotifiedAt)
+
+	health, notify = ApplyLinkHealth(health, failed, 3, now)
+	assert.False(t, notify, "the owner is notified once per breakage")
+	assert.True(t, health.Broken)
+	assert.Equal(t, 4, health.FailureCount)
+
+	health, notify = ApplyLinkHealth(health, healthy, 3, now)
+	assert.False(t, notify)
+	assert.False(t, health.Broken)
+	assert.Equal(t, 0, health.FailureCount)
+	assert.Nil(t, health.NotifiedAt)
+	assert.Empty(t, health.Error)
+}
@@ -0,0 +1,191 @@
+package healthcheck
+
+// A check sends a HEAD request to the destination and follows its redirects one hop at a time so the chain can be
+// recorded. Servers that don't handle HEAD well are common, so an unhealthy HEAD check is repeated once with GET.
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/url"
+	"syscall"
+	"time"
+
+	"github.com/ilhamfzri/pendek.in/config"
+)
+
+var (
+	DefaultTimeout      = 10 * time.Second
+	DefaultMaxRedirects = 10
+	DefaultUserAgent    = "PendekInLinkChecker/1.0"
+)
+
+var (
+	ErrTooManyRedirects = errors.New("too many redirects")
+	ErrRedirectLocation = errors.New("redirect without a valid location")
+	ErrPrivateAddress   = errors.New("destination resolves to a private address")
+)
+
+type Hop struct {
+	Url        string
+	StatusCode int
+}
+
+type Result struct {
+	Method        string
+	StatusCode    int // status code of the last hop, 0 when no response was received
+	Latency       time.Duration
+	RedirectChain []Hop
+	Err           error
+}
+
+// Healthy reports whether the destination answered, responses asking for credentials or throttling
+// the checker still prove the destination exists.
+func (result Result) Healthy() bool {
+	if result.Err != nil || result.StatusCode == 0 {
+		return false
+	}
+
+	switch result.StatusCode {
+	case http.StatusUnauthorized, http.StatusForbidden, http.StatusTooManyRequests:
+		return true
+	}
+	return result.StatusCode < http.StatusBadRequest
+}
+
+type Checker struct {
+	Client       *http.Client
+	Timeout      time.Duration
+	MaxRedirects int
+	UserAgent    string
+}
+
+// NewChecker builds the checker from the config, requests to private networks are refused
+// unless the config allows them so the checker can't be used to probe internal services.
+func NewChecker(cfg config.LinkHealthConfig) *Checker {
+	checker := &Checker{
+		Timeout:      time.Duration(cfg.TimeoutSeconds) * time.Second,
+		MaxRedirects: cfg.MaxRedirects,
+		UserAgent:    cfg.UserAgent,
+	}
+
+	if checker.Timeout <= 0 {
+		checker.Timeout = DefaultTimeout
+	}
+
+	if checker.MaxRedirects <= 0 {
+		checker.MaxRedirects = DefaultMaxRedirects
+	}
+
+	if checker.UserAgent == "" {
+		checker.UserAgent = DefaultUserAgent
+	}
+
+	dialer := &net.Dialer{Timeout: checker.Timeout}
+	if !cfg.AllowPrivateNetworks {
+		dialer.Control = refusePrivateAddress
+	}
+
+	checker.Client = &http.Client{
+		Transport: &http.Transport{
+			Proxy:               http.ProxyFromEnvironment,
+			DialContext:         dialer.DialContext,
+			TLSHandshakeTimeout: checker.Timeout,
+			MaxIdleConnsPerHost: 2,
+		},
+		// Redirects are followed by Check itself to record every hop.
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+	return checker
+}
+
+// Check checks the destination with HEAD first and falls back to GET when HEAD isn't healthy,
+// every attempt is limited by the timeout of the checker.
+func (checker *Checker) Check(ctx context.Context, rawUrl string) Result {
+	result := checker.follow(ctx, http.MethodHead, rawUrl)
+	if !result.Healthy() {
+		result = checker.follow(ctx, http.MethodGet, rawUrl)
+	}
+	return result
+}
+
+func (checker *Checker) follow(ctx context.Context, method string, rawUrl string) Result {
+	ctx, cancel := context.WithTimeout(ctx, checker.Timeout)
+	defer cancel()
+
+	start := time.Now()
+	result := Result{Method: method}
+	current := rawUrl
+	for {
+		statusCode, location, err := checker.do(ctx, method, current)
+		if err != nil {
+			result.Err = err
+			break
+		}
+
+		result.StatusCode = statusCode
+		result.RedirectChain = append(result.RedirectChain, Hop{Url: current, StatusCode: statusCode})
+		if !isRedirect(statusCode) {
+			break
+		}
+
+		if location == "" {
+			result.Err = ErrRedirectLocation
+			break
+		}
+
+		if len(result.RedirectChain) > checker.MaxRedirects {
+			result.Err = ErrTooManyRedirects
+			break
+		}
+		current = location
+	}
+
+	result.Latency = time.Since(start)
+	return result
+}
+
+// do sends one request and returns the status code and the absolute location of a redirect.
+func (checker *Checker) do(ctx context.Context, method string, rawUrl string) (int, string, error) {
+	req, err := http.NewRequestWithContext(ctx, method, rawUrl, nil)
+	if err != nil {
+		return 0, "", err
+	}
+	req.Header.Set("User-Agent", checker.UserAgent)
+
+	resp, err := checker.Client.Do(req)
+	if err != nil {
+		return 0, "", err
+	}
+	resp.Body.Close()
+
+	var location string
+	if locationUrl, errLocation := resp.Location(); errLocation == nil {
+		location = locationUrl.String()
+	}
+	return resp.StatusCode, location, nil
+}
+
+func isRedirect(statusCode int) bool {
+	switch statusCode {
+	case http.StatusMovedPermanently, http.StatusFound, http.StatusSeeOther, http.StatusTemporaryRedirect, http.StatusPermanentRedirect:
+		return true
+	}
+	return false
+}
+
+func refusePrivateAddress(network string, address string, conn syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+
+	ip := net.ParseIP(host)
+	if ip == nil || ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() {
+		return &url.Error{Op: "dial", URL: address, Err: ErrPrivateAddress}
+	}
+	return nil
+}
@@ -0,0 +1,137 @@
+package healthcheck
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/ilhamfzri/pendek.in/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestChecker() *Checker {
+	return NewChecker(config.LinkHealthConfig{
+		TimeoutSeconds:       2,
+		MaxRedirects:         3,
+		AllowPrivateNetworks: true,
+	})
+}
+
+func newTestServer() *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/ok", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	mux.HandleFunc("/first", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/second", http.StatusMovedPermanently)
+	})
+	mux.HandleFunc("/second", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/ok", http.StatusFound)
+	})
+	mux.HandleFunc("/loop", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/loop", http.StatusFound)
+	})
+	mux.HandleFunc("/missing", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+	mux.HandleFunc("/get-only", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+	mux.HandleFunc("/slow", func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(5 * time.Second):
+		case <-r.Context().Done():
+		}
+	})
+	return httptest.NewServer(mux)
+}
+
+func TestCheck(t *testing.T) {
+	server := newTestServer()
+	defer server.Close()
+
+	checker := newTestChecker()
+	ctx := context.Background()
+
+	t.Run("Healthy", func(t *testing.T) {
+		result := checker.Check(ctx, server.URL+"/ok")
+		assert.Nil(t, result.Err)
+		assert.True(t, result.Healthy())
+		assert.Equal(t, http.MethodHead, result.Method)
+		assert.Equal(t, http.StatusOK, result.StatusCode)
+		assert.Equal(t, []Hop{{Url: server.URL + "/ok", StatusCode: http.StatusOK}}, result.RedirectChain)
+		assert.Greater(t, result.Latency, time.Duration(0))
+	})
+
+	t.Run("RedirectChain", func(t *testing.T) {
+		result := checker.Check(ctx, server.URL+"/first")
+		assert.True(t, result.Healthy())
+		assert.Equal(t, []Hop{
+			{Url: server.URL + "/first", StatusCode: http.StatusMovedPermanently},
+			{Url: server.URL + "/second", StatusCode: http.StatusFound},
+			{Url: server.URL + "/ok", StatusCode: http.StatusOK},
+		}, result.RedirectChain)
+	})
+
+	t.Run("TooManyRedirects", func(t *testing.T) {
+		result := checker.Check(ctx, server.URL+"/loop")
+		assert.False(t, result.Healthy())
+		assert.Equal(t, ErrTooManyRedirects, result.Err)
+		assert.Len(t, result.RedirectChain, 4)
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		result := checker.Check(ctx, server.URL+"/missing")
+		assert.False(t, result.Healthy())
+		assert.Equal(t, http.MethodGet, result.Method)
+		assert.Equal(t, http.StatusNotFound, result.StatusCode)
+	})
+
+	t.Run("FallbackToGet", func(t *testing.T) {
+		result := checker.Check(ctx, server.URL+"/get-only")
+		assert.True(t, result.Healthy())
+		assert.Equal(t, http.MethodGet, result.Method)
+		assert.Equal(t, http.StatusOK, result.StatusCode)
+	})
+
+	t.Run("Timeout", func(t *testing.T) {
+		timeoutChecker := newTestChecker()
+		timeoutChecker.Timeout = 100 * time.Millisecond
+
+		result := timeoutChecker.Check(ctx, server.URL+"/slow")
+		assert.False(t, result.Healthy())
+		assert.True(t, errors.Is(result.Err, context.DeadlineExceeded))
+		assert.Equal(t, 0, result.StatusCode)
+	})
+}
+
+func TestCheckPrivateNetwork(t *testing.T) {
+	server := newTestServer()
+	defer server.Close()
+
+	checker := NewChecker(config.LinkHealthConfig{TimeoutSeconds: 2})
+	result := checker.Check(context.Background(), server.URL+"/ok")
+	assert.False(t, result.Healthy())
+
+	var urlErr *url.Error
+	assert.True(t, errors.As(result.Err, &urlErr))
+	assert.True(t, errors.Is(result.Err, ErrPrivateAddress))
+}
+
+func TestHealthy(t *testing.T) {
+	assert.True(t, Result{StatusCode: http.StatusNoContent}.Healthy())
+	assert.True(t, Result{StatusCode: http.StatusForbidden}.Healthy())
+	assert.True(t, Result{StatusCode: http.StatusTooManyRequests}.Healthy())
+	assert.False(t, Result{StatusCode: http.StatusGone}.Healthy())
+	assert.False(t, Result{StatusCode: http.StatusBadGateway}.Healthy())
+	assert.False(t, Result{}.Healthy())
+	assert.False(t, Result{StatusCode: http.StatusOK, Err: ErrTooManyRedirects}.Healthy())
+}
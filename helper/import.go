@@ -0,0 +1,101 @@
+package helper
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/ilhamfzri/pendek.in/internal/model/web"
+)
+
+var ErrImportMissingColumn = errors.New("import file must contain title and long_link columns")
+
+// ParseCustomLinkImportCSV reads custom links from a csv file, the first row is the header and columns are matched by name.
+func ParseCustomLinkImportCSV(reader io.Reader) ([]web.CustomLinkCreateRequest, error) {
+	csvReader := csv.NewReader(reader)
+	csvReader.TrimLeadingSpace = true
+	csvReader.FieldsPerRecord = -1
+
+	header, err := csvReader.Read()
+	if err != nil {
+		return nil, err
+	}
+
+	columns := map[string]int{}
+	for i, column := range header {
+		columns[strings.ToLower(strings.TrimSpace(column))] = i
+	}
+
+	if _, ok := columns["title"]; !ok {
+		return nil, ErrImportMissingColumn
+	}
+	if _, ok := columns["long_link"]; !ok {
+		return nil, ErrImportMissingColumn
+	}
+
+	getValue := func(record []string, column string) string {
+		i, ok := columns[column]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	var requests []web.CustomLinkCreateRequest
+	for row := 1; ; row++ {
+		record, err := csvReader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		request := web.CustomLinkCreateRequest{
+			Title:         getValue(record, "title"),
+			LongLink:      getValue(record, "long_link"),
+			ShortLinkCode: getValue(record, "short_link_code"),
+			UtmSource:     getValue(record, "utm_source"),
+			UtmMedium:     getValue(record, "utm_medium"),
+			UtmCampaign:   getValue(record, "utm_campaign"),
+			UtmTerm:       getValue(record, "utm_term"),
+			UtmContent:    getValue(record, "utm_content"),
+		}
+
+		request.ThumbnailID, err = parseOptionalUint(getValue(record, "thumbnail_id"))
+		if err != nil {
+			return nil, fmt.Errorf("row %d: thumbnail_id %w", row, err)
+		}
+
+		request.UserThumbnailID, err = parseOptionalUint(getValue(record, "user_thumbnail_id"))
+		if err != nil {
+			return nil, fmt.Errorf("row %d: user_thumbnail_id %w", row, err)
+		}
+
+		requests = append(requests, request)
+	}
+	return requests, nil
+}
+
+// ParseCustomLinkImportJSON reads custom links from a json array with the same fields as the create link request.
+func ParseCustomLinkImportJSON(reader io.Reader) ([]web.CustomLinkCreateRequest, error) {
+	var requests []web.CustomLinkCreateRequest
+	err := json.NewDecoder(reader).Decode(&requests)
+	return requests, err
+}
+
+func parseOptionalUint(value string) (*uint, error) {
+	if value == "" {
+		return nil, nil
+	}
+	number, err := strconv.ParseUint(value, 10, 32)
+	if err != nil {
+		return nil, errors.New("must be a positive number")
+	}
+	result := uint(number)
+	return &result, nil
+}
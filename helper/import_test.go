@@ -0,0 +1,41 @@
+package helper
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCustomLinkImportCSV(t *testing.T) {
+	t.Run("[ParseCSV][Success]", func(t *testing.T) {
+		file := "title,long_link,short_link_code,thumbnail_id\n" +
+			"Home,https://example.com,home01,1\n" +
+			"Blog,https://example.com/blog,,\n"
+
+		requests, err := ParseCustomLinkImportCSV(strings.NewReader(file))
+		assert.Nil(t, err)
+		assert.Len(t, requests, 2)
+		assert.Equal(t, "home01", requests[0].ShortLinkCode)
+		assert.Equal(t, uint(1), *requests[0].ThumbnailID)
+		assert.Equal(t, "", requests[1].ShortLinkCode)
+		assert.Nil(t, requests[1].ThumbnailID)
+	})
+
+	t.Run("[ParseCSV][Failed: Missing Column]", func(t *testing.T) {
+		_, err := ParseCustomLinkImportCSV(strings.NewReader("title\nHome\n"))
+		assert.Equal(t, ErrImportMissingColumn, err)
+	})
+
+	t.Run("[ParseCSV][Failed: Invalid Thumbnail]", func(t *testing.T) {
+		_, err := ParseCustomLinkImportCSV(strings.NewReader("title,long_link,thumbnail_id\nHome,https://example.com,abc\n"))
+		assert.NotNil(t, err)
+	})
+}
+
+func TestParseCustomLinkImportJSON(t *testing.T) {
+	requests, err := ParseCustomLinkImportJSON(strings.NewReader(`[{"title":"Home","long_link":"https://example.com"}]`))
+	assert.Nil(t, err)
+	assert.Len(t, requests, 1)
+	assert.Equal(t, "Home", requests[0].Title)
+}
@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"github.com/go-playground/validator/v10"
+	"github.com/ilhamfzri/pendek.in/internal/model/domain"
 )
 
 var validate *validator.Validate
@@ -73,6 +74,38 @@ func GetCustomLinkUrl(domain string, shortLinkCode string) string {
 	return fmt.Sprintf("%s/l/%s", domain, shortLinkCode)
 }
 
+// GetLinkDomain is the domain the link is served on, links of the shared domain are served on the default domain.
+// The scheme of the default domain is kept when it has one.
+func GetLinkDomain(l *domain.CustomLink, defaultDomain string) string {
+	if l.DomainID == nil || l.Domain.Name == "" {
+		return defaultDomain
+	}
+
+	if i := strings.Index(defaultDomain, "://"); i >= 0 {
+		return defaultDomain[:i+3] + l.Domain.Name
+	}
+	return l.Domain.Name
+}
+
+// NormalizeDomainName lower cases the domain name and strips the port and the trailing dot, so it can be
+// compared with the Host header.
+func NormalizeDomainName(name string) string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if i := strings.LastIndex(name, ":"); i >= 0 && !strings.Contains(name[i:], "]") {
+		name = name[:i]
+	}
+	return strings.TrimSuffix(name, ".")
+}
+
+// GetCustomLinkQRCodeUrl is the url inside the qr code of a link, visits through it are counted as scans.
+func GetCustomLinkQRCodeUrl(domain string, shortLinkCode string) string {
+	return fmt.Sprintf("%s/l/%s?%s=qr", domain, shortLinkCode, SourceQueryKey)
+}
+
+func GetProfileUrl(domain string, username string) string {
+	return fmt.Sprintf("%s/%s", domain, username)
+}
+
 func GetProfilePictureUrl(domain string, imageID string) string {
 	profileUrl := fmt.Sprintf("%s/%s/%s.jpg", domain, profileResourceEndpointPath, imageID)
 	return profileUrl
@@ -0,0 +1,26 @@
+package helper
+
+import (
+	"testing"
+
+	"github.com/ilhamfzri/pendek.in/internal/model/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetLinkDomain(t *testing.T) {
+	domainID := uint(1)
+	sharedLink := domain.CustomLink{ShortLinkCode: "promo1"}
+	brandedLink := domain.CustomLink{ShortLinkCode: "promo1", DomainID: &domainID, Domain: domain.CustomDomain{Name: "go.brand.test"}}
+
+	assert.Equal(t, "pendek.in", GetLinkDomain(&sharedLink, "pendek.in"))
+	assert.Equal(t, "go.brand.test", GetLinkDomain(&brandedLink, "pendek.in"))
+	assert.Equal(t, "https://go.brand.test", GetLinkDomain(&brandedLink, "https://pendek.in"))
+	assert.Equal(t, "go.brand.test/l/promo1", GetCustomLinkUrl(GetLinkDomain(&brandedLink, "localhost:8080"), brandedLink.ShortLinkCode))
+}
+
+func TestNormalizeDomainName(t *testing.T) {
+	assert.Equal(t, "go.brand.test", NormalizeDomainName("Go.Brand.Test."))
+	assert.Equal(t, "go.brand.test", NormalizeDomainName("go.brand.test:8080"))
+	assert.Equal(t, "[::1]", NormalizeDomainName("[::1]:8080"))
+	assert.Equal(t, "[::1]", NormalizeDomainName("[::1]"))
+}
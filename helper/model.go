@@ -3,18 +3,20 @@ package helper
 import (
 	"fmt"
 
+	"github.com/ilhamfzri/pendek.in/helper/domainverify"
 	"github.com/ilhamfzri/pendek.in/internal/model/domain"
 	"github.com/ilhamfzri/pendek.in/internal/model/web"
 )
 
 func UserDomainToResponse(user *domain.User) web.UserResponse {
 	return web.UserResponse{
-		ID:         user.ID,
-		Username:   user.Username,
-		FullName:   user.FullName,
-		Bio:        user.Bio,
-		Email:      user.Email,
-		ProfilePic: user.ProfilePic,
+		ID:                       user.ID,
+		Username:                 user.Username,
+		FullName:                 user.FullName,
+		Bio:                      user.Bio,
+		Email:                    user.Email,
+		ProfilePic:               user.ProfilePic,
+		CaseInsensitiveShortCode: user.CaseInsensitiveShortCode,
 	}
 }
 
@@ -24,6 +26,7 @@ func SocialMediaLinkDomainToResponse(smld *domain.SocialMediaLink, host string,
 		SocialMediaName: smld.SocialMediaType.Name,
 		LinkOrUsername:  smld.LinkOrUsername,
 		Activate:        smld.Activate,
+		Position:        smld.Position,
 		RedirectLink:    GenerateRedirectLink(host, username, smld.SocialMediaType.Name),
 	}
 }
@@ -67,12 +70,35 @@ func ThumbnailDomainToResponse(t *domain.Thumbnail) web.ThumbnailResponse {
 
 func CustomLinkDomainToResponse(l *domain.CustomLink) web.CustomLinkResponse {
 	customLinkResponse := web.CustomLinkResponse{
-		ID:            l.ID,
-		Title:         l.Title,
-		ShortLinkCode: l.ShortLinkCode,
-		LongLink:      l.LongLink,
-		ShowOnProfile: l.ShowOnProfile,
-		Activate:      l.Activate,
+		ID:                l.ID,
+		Title:             l.Title,
+		ShortLinkCode:     l.ShortLinkCode,
+		LongLink:          l.LongLink,
+		ShowOnProfile:     l.ShowOnProfile,
+		Activate:          l.Activate,
+		Position:          l.Position,
+		RedirectStatus:    RedirectStatusCode(l.RedirectStatus),
+		ForwardQuery:      l.ForwardQuery,
+		Interstitial:      l.Interstitial,
+		DomainID:          l.DomainID,
+		ExpiresAt:         l.ExpiresAt,
+		MaxClicks:         l.MaxClicks,
+		PasswordProtected: l.Password != "",
+		ActiveFrom:        l.ActiveFrom,
+		ActiveUntil:       l.ActiveUntil,
+		UtmSource:         l.Utm.Source,
+		UtmMedium:         l.Utm.Medium,
+		UtmCampaign:       l.Utm.Campaign,
+		UtmTerm:           l.Utm.Term,
+		UtmContent:        l.Utm.Content,
+		FolderID:          l.FolderID,
+		OgTitle:           l.OgTitle,
+		OgDescription:     l.OgDescription,
+		Broken:            l.Health.Broken,
+	}
+
+	for _, tag := range l.Tags {
+		customLinkResponse.Tags = append(customLinkResponse.Tags, TagDomainToResponse(&tag))
 	}
 
 	if l.ThumbnailID != nil {
@@ -83,16 +109,108 @@ func CustomLinkDomainToResponse(l *domain.CustomLink) web.CustomLinkResponse {
 		customLinkResponse.CustomThumbnailID = *l.CustomThumbnailID
 	}
 
+	if l.OgCustomThumbnailID != nil {
+		customLinkResponse.OgCustomThumbnailID = *l.OgCustomThumbnailID
+	}
+
+	if l.Health.CheckedAt != nil {
+		customLinkResponse.Health = &web.CustomLinkHealthResponse{
+			StatusCode:   l.Health.StatusCode,
+			LatencyMs:    l.Health.LatencyMs,
+			Error:        l.Health.Error,
+			FailureCount: l.Health.FailureCount,
+			CheckedAt:    l.Health.CheckedAt,
+		}
+		for _, hop := range l.Health.RedirectChain {
+			customLinkResponse.Health.RedirectChain = append(customLinkResponse.Health.RedirectChain, web.CustomLinkRedirectHopResponse{Url: hop.Url, StatusCode: hop.StatusCode})
+		}
+	}
+
+	if l.DeletedAt.Valid {
+		deletedAt := l.DeletedAt.Time
+		customLinkResponse.DeletedAt = &deletedAt
+	}
+
 	return customLinkResponse
 }
 
+func CustomDomainDomainToResponse(d *domain.CustomDomain) web.CustomDomainResponse {
+	return web.CustomDomainResponse{
+		ID:         d.ID,
+		Name:       d.Name,
+		Verified:   d.VerifiedAt != nil,
+		VerifiedAt: d.VerifiedAt,
+		VerificationRecord: web.CustomDomainRecordResponse{
+			Type:  "TXT",
+			Name:  domainverify.RecordName(d.Name),
+			Value: domainverify.RecordValue(d.VerificationToken),
+		},
+	}
+}
+
+func TagDomainToResponse(t *domain.Tag) web.TagResponse {
+	return web.TagResponse{
+		ID:   t.ID,
+		Name: t.Name,
+	}
+}
+
+func FolderDomainToResponse(f *domain.Folder) web.FolderResponse {
+	return web.FolderResponse{
+		ID:       f.ID,
+		Name:     f.Name,
+		ParentID: f.ParentID,
+	}
+}
+
+func CustomLinkVariantDomainToResponse(v *domain.CustomLinkVariant) web.CustomLinkVariantResponse {
+	return web.CustomLinkVariantResponse{
+		ID:         v.ID,
+		Name:       v.Name,
+		TargetLink: v.TargetLink,
+		Weight:     v.Weight,
+	}
+}
+
+func CustomLinkTargetingRuleDomainToResponse(r *domain.CustomLinkTargetingRule) web.CustomLinkTargetingRuleResponse {
+	return web.CustomLinkTargetingRuleResponse{
+		Device:     r.Device,
+		OS:         r.OS,
+		Country:    r.Country,
+		Language:   r.Language,
+		TargetLink: r.TargetLink,
+	}
+}
+
+func UtmTemplateDomainToResponse(ut *domain.UtmTemplate) web.UtmTemplateResponse {
+	return web.UtmTemplateResponse{
+		ID:          ut.ID,
+		Name:        ut.Name,
+		IsDefault:   ut.IsDefault,
+		UtmSource:   ut.Utm.Source,
+		UtmMedium:   ut.Utm.Medium,
+		UtmCampaign: ut.Utm.Campaign,
+		UtmTerm:     ut.Utm.Term,
+		UtmContent:  ut.Utm.Content,
+	}
+}
+
 func CustomLinkAnalyticDomainToResponse(cla *domain.CustomLinkAnalytic) web.CustomLinkAnalyticResponse {
 	return web.CustomLinkAnalyticResponse{
 		LinkID:         cla.CustomLinkID,
 		ClickCount:     cla.ClickCount,
+		ScanCount:      cla.ScanCount,
 		ViewCount:      cla.ViewCount,
 		DeviceAnalytic: DeviceAnalyticDomainToResponse(&cla.DeviceAnalytic),
 		Datetime:       cla.Date.Format("2006-01-02"),
 		LastUpdated:    cla.UpdatedAt,
 	}
 }
+
+func ReservedWordDomainToResponse(w *domain.ReservedWord) web.ReservedWordResponse {
+	return web.ReservedWordResponse{
+		ID:       w.ID,
+		Word:     w.Word,
+		Category: w.Category,
+	}
+}
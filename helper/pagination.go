@@ -0,0 +1,36 @@
+package helper
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+)
+
+var ErrPageCursorInvalid = errors.New("cursor invalid, make sure cursor is taken from the previous page with the same sort")
+
+// PageCursor points at the last row of a page, the next page starts right after it in the sort order.
+type PageCursor struct {
+	Sort  string `json:"s"`
+	Value string `json:"v"`
+	ID    uint   `json:"id"`
+}
+
+// EncodePageCursor returns an opaque url-safe token of the cursor.
+func EncodePageCursor(cursor PageCursor) string {
+	data, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodePageCursor reads a token made by EncodePageCursor, the cursor must be made for the same sort.
+func DecodePageCursor(token string, sort string) (PageCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return PageCursor{}, ErrPageCursorInvalid
+	}
+
+	var cursor PageCursor
+	if err := json.Unmarshal(data, &cursor); err != nil || cursor.Sort != sort || cursor.ID == 0 {
+		return PageCursor{}, ErrPageCursorInvalid
+	}
+	return cursor, nil
+}
@@ -0,0 +1,31 @@
+package helper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPageCursor(t *testing.T) {
+	cursor := PageCursor{Sort: "title", Value: "My Link", ID: 42}
+	token := EncodePageCursor(cursor)
+
+	t.Run("[Decode][Success]", func(t *testing.T) {
+		decoded, err := DecodePageCursor(token, "title")
+		assert.Nil(t, err)
+		assert.Equal(t, cursor, decoded)
+	})
+
+	t.Run("[Decode][Other Sort]", func(t *testing.T) {
+		_, err := DecodePageCursor(token, "clicks")
+		assert.ErrorIs(t, err, ErrPageCursorInvalid)
+	})
+
+	t.Run("[Decode][Malformed]", func(t *testing.T) {
+		_, err := DecodePageCursor("not a cursor!", "title")
+		assert.ErrorIs(t, err, ErrPageCursorInvalid)
+
+		_, err = DecodePageCursor(EncodePageCursor(PageCursor{Sort: "title"}), "title")
+		assert.ErrorIs(t, err, ErrPageCursorInvalid)
+	})
+}
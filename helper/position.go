@@ -0,0 +1,26 @@
+package helper
+
+// ReorderIDs puts the ordered ids first and keeps the remaining current ids after them in their current order.
+// It returns false when an ordered id isn't one of the current ids.
+func ReorderIDs(current []uint, ordered []uint) ([]uint, bool) {
+	remaining := make(map[uint]bool, len(current))
+	for _, id := range current {
+		remaining[id] = true
+	}
+
+	result := make([]uint, 0, len(current))
+	for _, id := range ordered {
+		if !remaining[id] {
+			return nil, false
+		}
+		remaining[id] = false
+		result = append(result, id)
+	}
+
+	for _, id := range current {
+		if remaining[id] {
+			result = append(result, id)
+		}
+	}
+	return result, true
+}
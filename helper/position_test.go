@@ -0,0 +1,33 @@
+package helper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReorderIDs(t *testing.T) {
+	current := []uint{1, 2, 3, 4}
+
+	t.Run("[Reorder][All]", func(t *testing.T) {
+		result, ok := ReorderIDs(current, []uint{4, 3, 2, 1})
+		assert.True(t, ok)
+		assert.Equal(t, []uint{4, 3, 2, 1}, result)
+	})
+
+	t.Run("[Reorder][Partial]", func(t *testing.T) {
+		result, ok := ReorderIDs(current, []uint{3, 1})
+		assert.True(t, ok)
+		assert.Equal(t, []uint{3, 1, 2, 4}, result)
+	})
+
+	t.Run("[Reorder][Unknown ID]", func(t *testing.T) {
+		_, ok := ReorderIDs(current, []uint{3, 9})
+		assert.False(t, ok)
+	})
+
+	t.Run("[Reorder][Duplicate ID]", func(t *testing.T) {
+		_, ok := ReorderIDs(current, []uint{3, 3})
+		assert.False(t, ok)
+	})
+}
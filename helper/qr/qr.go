@@ -0,0 +1,191 @@
+package qr
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/nfnt/resize"
+	"github.com/skip2/go-qrcode"
+)
+
+// Error correction levels, a higher level survives more damage, e.g. a center logo, but makes a denser code.
+const (
+	LevelLow      = "l"
+	LevelMedium   = "m"
+	LevelQuartile = "q"
+	LevelHigh     = "h"
+)
+
+var (
+	DefaultSize   = 256
+	LogoSizeRatio = 0.2 // the center logo covers at most this fraction of the code width
+
+	ErrLevelInvalid = errors.New("qr level must be one of l, m, q or h")
+	ErrLogoLevel    = errors.New("qr code with a center logo needs level q or h")
+	ErrColorInvalid = errors.New("qr color must be a hex color like 000000 or #ff8800")
+)
+
+var recoveryLevels = map[string]qrcode.RecoveryLevel{
+	LevelLow:      qrcode.Low,
+	LevelMedium:   qrcode.Medium,
+	LevelQuartile: qrcode.High,
+	LevelHigh:     qrcode.Highest,
+}
+
+// Options of a rendered qr code, zero values use black on white at DefaultSize.
+// The level defaults to m, or q when there is a logo.
+type Options struct {
+	Size       int
+	Level      string
+	Foreground color.Color
+	Background color.Color
+	Logo       image.Image
+}
+
+// EncodePNG writes the qr code of the content as a png image of Size x Size pixels.
+func EncodePNG(w io.Writer, content string, options Options) error {
+	code, err := newCode(content, options)
+	if err != nil {
+		return err
+	}
+
+	size := sizeOf(options)
+	img := image.NewRGBA(image.Rect(0, 0, size, size))
+	draw.Draw(img, img.Bounds(), code.Image(size), image.Point{}, draw.Src)
+
+	if options.Logo != nil {
+		logo := resizeLogo(options.Logo, int(float64(size)*LogoSizeRatio))
+		logoBounds := logo.Bounds()
+		offset := image.Pt((size-logoBounds.Dx())/2, (size-logoBounds.Dy())/2)
+		padding := image.Rectangle{Min: offset, Max: offset.Add(logoBounds.Size())}.Inset(-size / 100)
+
+		draw.Draw(img, padding, image.NewUniform(code.BackgroundColor), image.Point{}, draw.Src)
+		draw.Draw(img, logoBounds.Add(offset), logo, logoBounds.Min, draw.Over)
+	}
+
+	return png.Encode(w, img)
+}
+
+// EncodeSVG writes the qr code of the content as an svg document of Size x Size pixels, one unit per module.
+func EncodeSVG(w io.Writer, content string, options Options) error {
+	code, err := newCode(content, options)
+	if err != nil {
+		return err
+	}
+
+	size := sizeOf(options)
+	bitmap := code.Bitmap()
+	modules := len(bitmap)
+
+	var path strings.Builder
+	for y, row := range bitmap {
+		for x, isSet := range row {
+			if isSet {
+				fmt.Fprintf(&path, "M%d %dh1v1h-1z", x, y)
+			}
+		}
+	}
+
+	var svg bytes.Buffer
+	fmt.Fprintf(&svg, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" shape-rendering="crispEdges">`, size, size, modules, modules)
+	fmt.Fprintf(&svg, `<rect width="%d" height="%d" fill="%s"/>`, modules, modules, hexColor(code.BackgroundColor))
+	fmt.Fprintf(&svg, `<path d="%s" fill="%s"/>`, path.String(), hexColor(code.ForegroundColor))
+
+	if options.Logo != nil {
+		logoPixels := int(float64(size) * LogoSizeRatio)
+		logo := resizeLogo(options.Logo, logoPixels)
+
+		var logoPNG bytes.Buffer
+		if err := png.Encode(&logoPNG, logo); err != nil {
+			return err
+		}
+
+		scale := float64(modules) / float64(size)
+		logoWidth := float64(logo.Bounds().Dx()) * scale
+		logoHeight := float64(logo.Bounds().Dy()) * scale
+		logoX := (float64(modules) - logoWidth) / 2
+		logoY := (float64(modules) - logoHeight) / 2
+		padding := float64(size/100) * scale
+
+		fmt.Fprintf(&svg, `<rect x="%.2f" y="%.2f" width="%.2f" height="%.2f" fill="%s"/>`,
+			logoX-padding, logoY-padding, logoWidth+2*padding, logoHeight+2*padding, hexColor(code.BackgroundColor))
+		fmt.Fprintf(&svg, `<image x="%.2f" y="%.2f" width="%.2f" height="%.2f" href="data:image/png;base64,%s"/>`,
+			logoX, logoY, logoWidth, logoHeight, base64.StdEncoding.EncodeToString(logoPNG.Bytes()))
+	}
+
+	svg.WriteString(`</svg>`)
+	_, err = w.Write(svg.Bytes())
+	return err
+}
+
+// ParseHexColor reads a 6 digit hex color, the leading # is optional.
+func ParseHexColor(value string) (color.RGBA, error) {
+	value = strings.TrimPrefix(value, "#")
+	if len(value) != 6 {
+		return color.RGBA{}, ErrColorInvalid
+	}
+
+	rgb, err := strconv.ParseUint(value, 16, 32)
+	if err != nil {
+		return color.RGBA{}, ErrColorInvalid
+	}
+	return color.RGBA{R: uint8(rgb >> 16), G: uint8(rgb >> 8), B: uint8(rgb), A: 0xff}, nil
+}
+
+func newCode(content string, options Options) (*qrcode.QRCode, error) {
+	level := strings.ToLower(options.Level)
+	if level == "" {
+		level = LevelMedium
+		if options.Logo != nil {
+			level = LevelQuartile
+		}
+	}
+
+	recoveryLevel, ok := recoveryLevels[level]
+	if !ok {
+		return nil, ErrLevelInvalid
+	}
+
+	// It's making sure the modules hidden by the logo can be recovered.
+	if options.Logo != nil && (level == LevelLow || level == LevelMedium) {
+		return nil, ErrLogoLevel
+	}
+
+	code, err := qrcode.New(content, recoveryLevel)
+	if err != nil {
+		return nil, err
+	}
+
+	if options.Foreground != nil {
+		code.ForegroundColor = options.Foreground
+	}
+	if options.Background != nil {
+		code.BackgroundColor = options.Background
+	}
+	return code, nil
+}
+
+func sizeOf(options Options) int {
+	if options.Size <= 0 {
+		return DefaultSize
+	}
+	return options.Size
+}
+
+func resizeLogo(logo image.Image, maxSize int) image.Image {
+	return resize.Thumbnail(uint(maxSize), uint(maxSize), logo, resize.Lanczos3)
+}
+
+func hexColor(c color.Color) string {
+	r, g, b, _ := c.RGBA()
+	return fmt.Sprintf("#%02x%02x%02x", r>>8, g>>8, b>>8)
+}
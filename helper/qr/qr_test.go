@@ -0,0 +1,95 @@
+package qr
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncodePNG(t *testing.T) {
+	t.Run("[PNG][Size]", func(t *testing.T) {
+		var buffer bytes.Buffer
+		err := EncodePNG(&buffer, "https://pendek.in/l/promo1?source=qr", Options{Size: 300})
+		assert.Nil(t, err)
+
+		img, err := png.Decode(&buffer)
+		assert.Nil(t, err)
+		assert.Equal(t, image.Rect(0, 0, 300, 300), img.Bounds())
+	})
+
+	t.Run("[PNG][Colors And Logo]", func(t *testing.T) {
+		logo := image.NewRGBA(image.Rect(0, 0, 40, 20))
+		for x := 0; x < 40; x++ {
+			for y := 0; y < 20; y++ {
+				logo.Set(x, y, color.RGBA{R: 0xff, A: 0xff})
+			}
+		}
+
+		var buffer bytes.Buffer
+		err := EncodePNG(&buffer, "https://pendek.in/l/promo1?source=qr", Options{
+			Size:       200,
+			Level:      LevelHigh,
+			Foreground: color.RGBA{B: 0xff, A: 0xff},
+			Background: color.RGBA{R: 0xee, G: 0xee, B: 0xee, A: 0xff},
+			Logo:       logo,
+		})
+		assert.Nil(t, err)
+
+		img, err := png.Decode(&buffer)
+		assert.Nil(t, err)
+
+		// The logo sits in the center and the quiet zone keeps the background color.
+		r, g, b, _ := img.At(100, 100).RGBA()
+		assert.Equal(t, []uint32{0xff, 0, 0}, []uint32{r >> 8, g >> 8, b >> 8})
+		r, g, b, _ = img.At(1, 1).RGBA()
+		assert.Equal(t, []uint32{0xee, 0xee, 0xee}, []uint32{r >> 8, g >> 8, b >> 8})
+	})
+
+	t.Run("[PNG][Logo Needs High Level]", func(t *testing.T) {
+		var buffer bytes.Buffer
+		err := EncodePNG(&buffer, "https://pendek.in/l/promo1?source=qr", Options{Level: LevelLow, Logo: image.NewRGBA(image.Rect(0, 0, 10, 10))})
+		assert.ErrorIs(t, err, ErrLogoLevel)
+	})
+
+	t.Run("[PNG][Invalid Level]", func(t *testing.T) {
+		var buffer bytes.Buffer
+		err := EncodePNG(&buffer, "https://pendek.in/l/promo1?source=qr", Options{Level: "x"})
+		assert.ErrorIs(t, err, ErrLevelInvalid)
+	})
+}
+
+func TestEncodeSVG(t *testing.T) {
+	var buffer bytes.Buffer
+	err := EncodeSVG(&buffer, "https://pendek.in/l/promo1?source=qr", Options{
+		Size:       512,
+		Foreground: color.RGBA{R: 0x12, G: 0x34, B: 0x56, A: 0xff},
+		Logo:       image.NewRGBA(image.Rect(0, 0, 10, 10)),
+	})
+	assert.Nil(t, err)
+
+	svg := buffer.String()
+	assert.Contains(t, svg, `width="512" height="512"`)
+	assert.Contains(t, svg, `fill="#123456"`)
+	assert.Contains(t, svg, `fill="#ffffff"`)
+	assert.Contains(t, svg, `href="data:image/png;base64,`)
+	assert.Contains(t, svg, "</svg>")
+}
+
+func TestParseHexColor(t *testing.T) {
+	c, err := ParseHexColor("#ff8800")
+	assert.Nil(t, err)
+	assert.Equal(t, color.RGBA{R: 0xff, G: 0x88, A: 0xff}, c)
+
+	c, err = ParseHexColor("0A0B0C")
+	assert.Nil(t, err)
+	assert.Equal(t, color.RGBA{R: 0x0a, G: 0x0b, B: 0x0c, A: 0xff}, c)
+
+	for _, value := range []string{"", "#fff", "zzzzzz", "#12345678"} {
+		_, err = ParseHexColor(value)
+		assert.ErrorIs(t, err, ErrColorInvalid, value)
+	}
+}
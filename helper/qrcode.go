@@ -0,0 +1,47 @@
+package helper
+
+import (
+	"bytes"
+	"image"
+
+	"github.com/ilhamfzri/pendek.in/helper/qr"
+	"github.com/ilhamfzri/pendek.in/internal/model/web"
+)
+
+// RenderQRCode renders the qr code of the content with the options of a request, logo is optional.
+func RenderQRCode(content string, request web.QRCodeOptionRequest, logo image.Image) (web.QRCodeResponse, error) {
+	options := qr.Options{
+		Size:  request.Size,
+		Level: request.Level,
+		Logo:  logo,
+	}
+
+	if request.Foreground != "" {
+		foreground, err := qr.ParseHexColor(request.Foreground)
+		if err != nil {
+			return web.QRCodeResponse{}, err
+		}
+		options.Foreground = foreground
+	}
+
+	if request.Background != "" {
+		background, err := qr.ParseHexColor(request.Background)
+		if err != nil {
+			return web.QRCodeResponse{}, err
+		}
+		options.Background = background
+	}
+
+	var buffer bytes.Buffer
+	if request.Format == "svg" {
+		if err := qr.EncodeSVG(&buffer, content, options); err != nil {
+			return web.QRCodeResponse{}, err
+		}
+		return web.QRCodeResponse{ContentType: "image/svg+xml", Data: buffer.Bytes()}, nil
+	}
+
+	if err := qr.EncodePNG(&buffer, content, options); err != nil {
+		return web.QRCodeResponse{}, err
+	}
+	return web.QRCodeResponse{ContentType: "image/png", Data: buffer.Bytes()}, nil
+}
@@ -0,0 +1,78 @@
+package helper
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// PreviewSuffix is appended to a short link code to see where the link goes instead of being redirected.
+const PreviewSuffix = "+"
+
+// SourceQueryKey marks where a visit of a short link comes from, the url inside the qr code of a link carries
+// source=qr. The marker isn't forwarded to the destination.
+const SourceQueryKey = "source"
+
+// RedirectStatusCode is the status code a link redirects with, links without a setting use 302 Found.
+func RedirectStatusCode(status int) int {
+	switch status {
+	case http.StatusMovedPermanently, http.StatusFound, http.StatusTemporaryRedirect, http.StatusPermanentRedirect:
+		return status
+	default:
+		return http.StatusFound
+	}
+}
+
+// ForwardQuery adds the query string of the visit to the destination, parameters the destination already
+// carries are kept as is so the incoming query can't override them.
+func ForwardQuery(destination string, query url.Values) (string, error) {
+	if len(query) == 0 {
+		return destination, nil
+	}
+
+	parsedLink, err := url.Parse(destination)
+	if err != nil {
+		return "", err
+	}
+
+	destinationQuery := parsedLink.Query()
+	for key, values := range query {
+		if !destinationQuery.Has(key) {
+			destinationQuery[key] = values
+		}
+	}
+
+	parsedLink.RawQuery = destinationQuery.Encode()
+	return parsedLink.String(), nil
+}
+
+// ParseSourceQuery returns the query without the source marker, ok is false when the visit doesn't carry the
+// given source and the query is returned as is.
+func ParseSourceQuery(query url.Values, source string) (url.Values, bool) {
+	if query.Get(SourceQueryKey) != source {
+		return query, false
+	}
+
+	strippedQuery := url.Values{}
+	for key, values := range query {
+		if key != SourceQueryKey {
+			strippedQuery[key] = values
+		}
+	}
+	return strippedQuery, true
+}
+
+// ParsePreviewCode strips the preview suffix from the short link code, ok is false for a regular visit.
+func ParsePreviewCode(shortLinkCode string) (string, bool) {
+	code := strings.TrimSuffix(shortLinkCode, PreviewSuffix)
+	return code, code != shortLinkCode && code != ""
+}
+
+// DestinationHost is the host shown to a visitor before leaving, the destination itself when it can't be parsed.
+func DestinationHost(destination string) string {
+	parsedLink, err := url.Parse(destination)
+	if err != nil || parsedLink.Hostname() == "" {
+		return destination
+	}
+	return strings.TrimPrefix(parsedLink.Hostname(), "www.")
+}
@@ -0,0 +1,85 @@
+package helper
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedirectStatusCode(t *testing.T) {
+	assert.Equal(t, http.StatusFound, RedirectStatusCode(0))
+	assert.Equal(t, http.StatusFound, RedirectStatusCode(http.StatusSeeOther))
+	assert.Equal(t, http.StatusMovedPermanently, RedirectStatusCode(http.StatusMovedPermanently))
+	assert.Equal(t, http.StatusTemporaryRedirect, RedirectStatusCode(http.StatusTemporaryRedirect))
+	assert.Equal(t, http.StatusPermanentRedirect, RedirectStatusCode(http.StatusPermanentRedirect))
+}
+
+func TestForwardQuery(t *testing.T) {
+	tests := []struct {
+		Name        string
+		Destination string
+		Query       url.Values
+		Expected    string
+	}{
+		{
+			Name:        "Without Query",
+			Destination: "https://example.com/page?a=1",
+			Expected:    "https://example.com/page?a=1",
+		},
+		{
+			Name:        "Added Parameter",
+			Destination: "https://example.com/page",
+			Query:       url.Values{"ref": {"x"}},
+			Expected:    "https://example.com/page?ref=x",
+		},
+		{
+			Name:        "Destination Parameter Wins",
+			Destination: "https://example.com/page?utm_source=mail&a=1#top",
+			Query:       url.Values{"utm_source": {"ads"}, "ref": {"x", "y"}},
+			Expected:    "https://example.com/page?a=1&ref=x&ref=y&utm_source=mail#top",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			destination, err := ForwardQuery(test.Destination, test.Query)
+			assert.Nil(t, err)
+			assert.Equal(t, test.Expected, destination)
+		})
+	}
+}
+
+func TestParsePreviewCode(t *testing.T) {
+	code, ok := ParsePreviewCode("promo1+")
+	assert.True(t, ok)
+	assert.Equal(t, "promo1", code)
+
+	code, ok = ParsePreviewCode("promo1")
+	assert.False(t, ok)
+	assert.Equal(t, "promo1", code)
+
+	_, ok = ParsePreviewCode("+")
+	assert.False(t, ok)
+}
+
+func TestParseSourceQuery(t *testing.T) {
+	query, ok := ParseSourceQuery(url.Values{"source": {"qr"}, "utm_medium": {"print"}}, "qr")
+	assert.True(t, ok)
+	assert.Equal(t, url.Values{"utm_medium": {"print"}}, query)
+
+	query, ok = ParseSourceQuery(url.Values{"source": {"newsletter"}}, "qr")
+	assert.False(t, ok)
+	assert.Equal(t, url.Values{"source": {"newsletter"}}, query)
+
+	query, ok = ParseSourceQuery(url.Values{}, "qr")
+	assert.False(t, ok)
+	assert.Empty(t, query)
+}
+
+func TestDestinationHost(t *testing.T) {
+	assert.Equal(t, "example.com", DestinationHost("https://www.example.com/page?a=1"))
+	assert.Equal(t, "shop.example.com", DestinationHost("http://shop.example.com:8080"))
+	assert.Equal(t, "not a url", DestinationHost("not a url"))
+}
@@ -4,6 +4,7 @@ import (
 	"strings"
 
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
 )
 
 func ExtractTokenFromRequestHeader(c *gin.Context) string {
@@ -12,3 +13,17 @@ func ExtractTokenFromRequestHeader(c *gin.Context) string {
 	jwtToken := splitToken[1]
 	return jwtToken
 }
+
+// GetBaseUrl returns the scheme and host the request was made to, e.g. https://pendek.in.
+func GetBaseUrl(c *gin.Context) string {
+	scheme := "http"
+	if c.Request.TLS != nil || c.GetHeader("X-Forwarded-Proto") == "https" {
+		scheme = "https"
+	}
+	return scheme + "://" + c.Request.Host
+}
+
+// ValidateRequest validates a request that isn't bound by gin, with the same binding rules.
+func ValidateRequest(request interface{}) error {
+	return binding.Validator.ValidateStruct(request)
+}
@@ -0,0 +1,45 @@
+package helper
+
+import (
+	"strings"
+
+	"github.com/ilhamfzri/pendek.in/internal/model/domain"
+)
+
+const (
+	ReservedCategoryRoute     = "route"     // paths served by the app, e.g. /v1 or /l
+	ReservedCategoryBrand     = "brand"     // names that impersonate the service
+	ReservedCategoryOffensive = "offensive" // matched anywhere in the name, not only as the whole name
+)
+
+var ReservedSubstringCategories = []string{ReservedCategoryOffensive}
+
+// DefaultReservedWords is the registry a new database starts with, admins manage it at runtime afterwards.
+var DefaultReservedWords = map[string][]string{
+	ReservedCategoryRoute: {
+		"v1", "v2", "l", "q", "api", "admin", "administrator", "root", "static", "assets", "resources",
+		"users", "user", "login", "logout", "signin", "signup", "register", "settings", "dashboard",
+		"account", "profile", "help", "support", "about", "terms", "privacy", "status", "health",
+		"robots", "sitemap", "favicon", "www", "mail", "email", "docs",
+	},
+	ReservedCategoryBrand: {
+		"pendekin", "pendek", "official", "security", "billing", "staff", "moderator", "system",
+	},
+	ReservedCategoryOffensive: {
+		"fuck", "shit", "bitch", "cunt", "porn", "nazi",
+	},
+}
+
+func NormalizeReservedWord(word string) string {
+	return strings.ToLower(strings.TrimSpace(word))
+}
+
+func DefaultReservedWordEntries() []domain.ReservedWord {
+	var words []domain.ReservedWord
+	for _, category := range []string{ReservedCategoryRoute, ReservedCategoryBrand, ReservedCategoryOffensive} {
+		for _, word := range DefaultReservedWords[category] {
+			words = append(words, domain.ReservedWord{Word: word, Category: category})
+		}
+	}
+	return words
+}
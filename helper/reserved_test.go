@@ -0,0 +1,26 @@
+package helper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeReservedWord(t *testing.T) {
+	assert.Equal(t, "admin", NormalizeReservedWord(" Admin "))
+	assert.Equal(t, "pendekin", NormalizeReservedWord("PENDEKIN"))
+}
+
+func TestDefaultReservedWordEntries(t *testing.T) {
+	entries := DefaultReservedWordEntries()
+
+	seen := map[string]bool{}
+	for _, entry := range entries {
+		assert.Equal(t, NormalizeReservedWord(entry.Word), entry.Word)
+		assert.False(t, seen[entry.Word], entry.Word)
+		seen[entry.Word] = true
+	}
+	assert.True(t, seen["v1"])
+	assert.True(t, seen["l"])
+	assert.True(t, seen["q"])
+}
@@ -0,0 +1,79 @@
+package helper
+
+import (
+	"github.com/ilhamfzri/pendek.in/internal/model/domain"
+	"github.com/ilhamfzri/pendek.in/internal/model/web"
+)
+
+var revisionFieldKeys = append([]string{"title", "short_link_code", "long_link"}, utmParameterKeys...)
+
+func revisionFieldValues(r *domain.CustomLinkRevision) []string {
+	return append([]string{r.Title, r.ShortLinkCode, r.LongLink}, utmParameterValues(r.Utm)...)
+}
+
+// CustomLinkSnapshot takes the fields of the link that are kept in a revision.
+func CustomLinkSnapshot(l *domain.CustomLink) domain.CustomLinkRevision {
+	return domain.CustomLinkRevision{
+		CustomLinkID:  l.ID,
+		Title:         l.Title,
+		ShortLinkCode: l.ShortLinkCode,
+		LongLink:      l.LongLink,
+		Utm:           l.Utm,
+	}
+}
+
+// IsSameRevision reports whether both revisions hold the same snapshot.
+func IsSameRevision(a *domain.CustomLinkRevision, b *domain.CustomLinkRevision) bool {
+	return len(DiffCustomLinkRevision(a, b)) == 0
+}
+
+// DiffCustomLinkRevision lists the fields changed from the previous revision, every non empty field
+// is listed when there is no previous revision.
+func DiffCustomLinkRevision(previous *domain.CustomLinkRevision, current *domain.CustomLinkRevision) []web.CustomLinkRevisionChangeResponse {
+	previousValues := make([]string, len(revisionFieldKeys))
+	if previous != nil {
+		previousValues = revisionFieldValues(previous)
+	}
+
+	changes := []web.CustomLinkRevisionChangeResponse{}
+	for i, value := range revisionFieldValues(current) {
+		if value != previousValues[i] {
+			changes = append(changes, web.CustomLinkRevisionChangeResponse{
+				Field: revisionFieldKeys[i],
+				From:  previousValues[i],
+				To:    value,
+			})
+		}
+	}
+	return changes
+}
+
+// CustomLinkRevisionsToResponse converts the revisions of a link in revision order, each with its diff.
+func CustomLinkRevisionsToResponse(revisions []domain.CustomLinkRevision) []web.CustomLinkRevisionResponse {
+	revisionsResponse := []web.CustomLinkRevisionResponse{}
+	for i := range revisions {
+		var previous *domain.CustomLinkRevision
+		if i > 0 {
+			previous = &revisions[i-1]
+		}
+
+		revision := &revisions[i]
+		revisionsResponse = append(revisionsResponse, web.CustomLinkRevisionResponse{
+			Revision:      revision.Revision,
+			Action:        revision.Action,
+			RevertedFrom:  revision.RevertedFrom,
+			ChangedBy:     revision.Username,
+			ChangedAt:     revision.CreatedAt,
+			Title:         revision.Title,
+			ShortLinkCode: revision.ShortLinkCode,
+			LongLink:      revision.LongLink,
+			UtmSource:     revision.Utm.Source,
+			UtmMedium:     revision.Utm.Medium,
+			UtmCampaign:   revision.Utm.Campaign,
+			UtmTerm:       revision.Utm.Term,
+			UtmContent:    revision.Utm.Content,
+			Changes:       DiffCustomLinkRevision(previous, revision),
+		})
+	}
+	return revisionsResponse
+}
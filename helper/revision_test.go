@@ -0,0 +1,52 @@
+package helper
+
+import (
+	"testing"
+
+	"github.com/ilhamfzri/pendek.in/internal/model/domain"
+	"github.com/ilhamfzri/pendek.in/internal/model/web"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffCustomLinkRevision(t *testing.T) {
+	first := domain.CustomLinkRevision{Title: "Promo", ShortLinkCode: "promo1", LongLink: "https://example.com"}
+	second := domain.CustomLinkRevision{Title: "Promo", ShortLinkCode: "promo1", LongLink: "https://example.com/sale", Utm: domain.Utm{Source: "mail"}}
+
+	t.Run("First Revision", func(t *testing.T) {
+		changes := DiffCustomLinkRevision(nil, &first)
+		assert.Equal(t, []web.CustomLinkRevisionChangeResponse{
+			{Field: "title", From: "", To: "Promo"},
+			{Field: "short_link_code", From: "", To: "promo1"},
+			{Field: "long_link", From: "", To: "https://example.com"},
+		}, changes)
+	})
+
+	t.Run("Changed Fields", func(t *testing.T) {
+		changes := DiffCustomLinkRevision(&first, &second)
+		assert.Equal(t, []web.CustomLinkRevisionChangeResponse{
+			{Field: "long_link", From: "https://example.com", To: "https://example.com/sale"},
+			{Field: "utm_source", From: "", To: "mail"},
+		}, changes)
+	})
+
+	t.Run("Same Snapshot", func(t *testing.T) {
+		assert.True(t, IsSameRevision(&first, &domain.CustomLinkRevision{Title: "Promo", ShortLinkCode: "promo1", LongLink: "https://example.com", Revision: 5}))
+		assert.False(t, IsSameRevision(&first, &second))
+	})
+}
+
+func TestCustomLinkRevisionsToResponse(t *testing.T) {
+	revertedFrom := uint(1)
+	revisions := []domain.CustomLinkRevision{
+		{Revision: 1, Action: "create", Username: "john", LongLink: "https://example.com"},
+		{Revision: 2, Action: "update", Username: "john", LongLink: "https://example.org"},
+		{Revision: 3, Action: "revert", Username: "john", LongLink: "https://example.com", RevertedFrom: &revertedFrom},
+	}
+
+	revisionsResponse := CustomLinkRevisionsToResponse(revisions)
+	assert.Len(t, revisionsResponse, 3)
+	assert.Equal(t, "john", revisionsResponse[0].ChangedBy)
+	assert.Equal(t, []web.CustomLinkRevisionChangeResponse{{Field: "long_link", From: "https://example.org", To: "https://example.com"}}, revisionsResponse[2].Changes)
+	assert.Equal(t, &revertedFrom, revisionsResponse[2].RevertedFrom)
+	assert.Empty(t, CustomLinkRevisionsToResponse(nil))
+}
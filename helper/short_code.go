@@ -0,0 +1,75 @@
+package helper
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ilhamfzri/pendek.in/config"
+)
+
+// base62 without look-alike characters (0, O, o, 1, l, I)
+const defaultShortCodeAlphabet = "23456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnpqrstuvwxyz"
+const defaultShortCodeLength = 7
+const defaultShortCodeMaxRetry = 5
+
+var ErrShortCodeAlphabetInvalid = errors.New("short code alphabet must only contain ascii letters and digits")
+var ErrShortCodeLengthInvalid = fmt.Errorf("short code length must be between %d and %d", MinShortCodeLength, MaxShortCodeLength)
+
+type ShortCodeGenerator struct {
+	Alphabet string
+	Length   int
+	MaxRetry int
+}
+
+// NewShortCodeGenerator fails on a config generating codes the requests would reject, every generated code has
+// to pass the alphanum and length validation of a short link code.
+func NewShortCodeGenerator(cfg config.ShortCodeConfig) (*ShortCodeGenerator, error) {
+	generator := &ShortCodeGenerator{
+		Alphabet: cfg.Alphabet,
+		Length:   cfg.Length,
+		MaxRetry: cfg.MaxRetry,
+	}
+
+	if generator.Alphabet == "" {
+		generator.Alphabet = defaultShortCodeAlphabet
+	}
+
+	if generator.Length <= 0 {
+		generator.Length = defaultShortCodeLength
+	}
+
+	if generator.MaxRetry <= 0 {
+		generator.MaxRetry = defaultShortCodeMaxRetry
+	}
+
+	for _, char := range generator.Alphabet {
+		if !isAsciiAlphanumeric(char) {
+			return nil, ErrShortCodeAlphabetInvalid
+		}
+	}
+
+	if generator.Length < MinShortCodeLength || generator.Length > MaxShortCodeLength {
+		return nil, ErrShortCodeLengthInvalid
+	}
+
+	return generator, nil
+}
+
+func isAsciiAlphanumeric(char rune) bool {
+	return (char >= '0' && char <= '9') || (char >= 'A' && char <= 'Z') || (char >= 'a' && char <= 'z')
+}
+
+func (generator *ShortCodeGenerator) Generate() (string, error) {
+	alphabetLength := big.NewInt(int64(len(generator.Alphabet)))
+	buffer := make([]byte, generator.Length)
+	for i := range buffer {
+		n, err := rand.Int(rand.Reader, alphabetLength)
+		if err != nil {
+			return "", err
+		}
+		buffer[i] = generator.Alphabet[n.Int64()]
+	}
+	return string(buffer), nil
+}
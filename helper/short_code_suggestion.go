@@ -0,0 +1,177 @@
+package helper
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+const (
+	MinShortCodeLength = 5 // same bounds as the short_link_code validation of the requests
+	MaxShortCodeLength = 20
+)
+
+var ShortCodeSuggestionPrefixes = []string{"my", "get", "go", "the", "try"}
+
+// ShortCodeSynonyms are swapped in for a word found inside the taken code, e.g. summerpromo becomes summerdeal.
+var ShortCodeSynonyms = map[string][]string{
+	"promo":    {"deal", "offer", "sale"},
+	"sale":     {"deal", "promo", "offer"},
+	"deal":     {"offer", "promo", "sale"},
+	"offer":    {"deal", "promo"},
+	"discount": {"deal", "sale", "promo"},
+	"event":    {"meetup", "party"},
+	"shop":     {"store", "market"},
+	"store":    {"shop", "market"},
+	"blog":     {"post", "news"},
+	"news":     {"update", "blog"},
+	"info":     {"about", "details"},
+	"launch":   {"release", "debut"},
+	"free":     {"gratis", "bonus"},
+	"join":     {"enroll", "apply"},
+}
+
+// ShortCodeSuggestions returns alternatives for a taken code ranked by taking one candidate of every strategy
+// in turn: a numeric suffix, the title slug, a synonym and a prefix, so the first suggestions aren't all alike.
+// Every candidate is a valid short link code, availability is left to the caller.
+func ShortCodeSuggestions(code string, title string) []string {
+	strategies := [][]string{
+		shortCodeSuffixCandidates(code),
+		shortCodeTitleCandidates(code, title),
+		shortCodeSynonymCandidates(code),
+		shortCodePrefixCandidates(code),
+	}
+
+	var suggestions []string
+	seen := map[string]bool{strings.ToLower(code): true}
+	for i := 0; ; i++ {
+		exhausted := true
+		for _, candidates := range strategies {
+			if i >= len(candidates) {
+				continue
+			}
+			exhausted = false
+
+			candidate := candidates[i]
+			if !isValidShortCode(candidate) || seen[strings.ToLower(candidate)] {
+				continue
+			}
+			seen[strings.ToLower(candidate)] = true
+			suggestions = append(suggestions, candidate)
+		}
+		if exhausted {
+			return suggestions
+		}
+	}
+}
+
+// ShortCodeSlug turns the title into a code made of its ascii letters and digits, words are dropped from the end until it fits.
+func ShortCodeSlug(title string) string {
+	words := strings.FieldsFunc(strings.ToLower(title), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for i, word := range words {
+		words[i] = strings.Map(func(r rune) rune {
+			if isShortCodeRune(r) {
+				return r
+			}
+			return -1
+		}, word)
+	}
+
+	var slug string
+	for _, word := range words {
+		if len(slug)+len(word) > MaxShortCodeLength {
+			break
+		}
+		slug += word
+	}
+	return slug
+}
+
+func shortCodeSuffixCandidates(code string) []string {
+	var candidates []string
+	for i := 1; i <= 9; i++ {
+		suffix := strconv.Itoa(i)
+		candidates = append(candidates, trimShortCode(code, len(suffix))+suffix)
+	}
+	return candidates
+}
+
+func shortCodeTitleCandidates(code string, title string) []string {
+	slug := ShortCodeSlug(title)
+	if slug == "" {
+		return nil
+	}
+
+	candidates := []string{slug}
+	if len(slug) < MinShortCodeLength {
+		candidates[0] = trimShortCode(code, len(slug)) + slug
+	}
+	return append(candidates, trimShortCode(slug, 1)+"1")
+}
+
+func shortCodeSynonymCandidates(code string) []string {
+	lowerCode := strings.ToLower(code)
+
+	words := make([]string, 0, len(ShortCodeSynonyms))
+	for word := range ShortCodeSynonyms {
+		words = append(words, word)
+	}
+	sort.Strings(words)
+
+	var candidates []string
+	for _, word := range words {
+		i := strings.Index(lowerCode, word)
+		if i < 0 {
+			continue
+		}
+		for _, synonym := range ShortCodeSynonyms[word] {
+			synonym = matchShortCodeCase(synonym, code[i:i+len(word)])
+			candidates = append(candidates, code[:i]+synonym+code[i+len(word):])
+		}
+	}
+	return candidates
+}
+
+func shortCodePrefixCandidates(code string) []string {
+	var candidates []string
+	for _, prefix := range ShortCodeSuggestionPrefixes {
+		prefix = matchShortCodeCase(prefix, code)
+		candidates = append(candidates, prefix+trimShortCode(code, len(prefix)))
+	}
+	return candidates
+}
+
+// trimShortCode cuts the end of the code so that reserved more characters still fit in the maximum length.
+func trimShortCode(code string, reserved int) string {
+	if len(code)+reserved > MaxShortCodeLength {
+		return code[:MaxShortCodeLength-reserved]
+	}
+	return code
+}
+
+// matchShortCodeCase writes the word in upper case when the pattern is all upper case, otherwise in lower case.
+func matchShortCodeCase(word string, pattern string) string {
+	if strings.ToUpper(pattern) == pattern && strings.ToLower(pattern) != pattern {
+		return strings.ToUpper(word)
+	}
+	return word
+}
+
+func isValidShortCode(code string) bool {
+	if len(code) < MinShortCodeLength || len(code) > MaxShortCodeLength {
+		return false
+	}
+	for _, r := range code {
+		if !isShortCodeRune(r) {
+			return false
+		}
+	}
+	return true
+}
+
+func isShortCodeRune(r rune) bool {
+	return r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r))
+}
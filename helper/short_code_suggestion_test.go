@@ -0,0 +1,55 @@
+package helper
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShortCodeSlug(t *testing.T) {
+	assert.Equal(t, "summersale2026", ShortCodeSlug("Summer Sale 2026!"))
+	assert.Equal(t, "theverylongtitleofa", ShortCodeSlug("The very long title of a blog post"))
+	assert.Equal(t, "caflatte", ShortCodeSlug("Café Latte")) // non ascii letters are dropped
+	assert.Equal(t, "", ShortCodeSlug("!!!"))
+}
+
+func TestShortCodeSuggestions(t *testing.T) {
+	t.Run("Ranked By Strategy", func(t *testing.T) {
+		suggestions := ShortCodeSuggestions("promo", "Summer Sale")
+		assert.Equal(t, []string{"promo1", "summersale", "mypromo", "promo2", "summersale1", "offer", "getpromo", "promo3"}, suggestions[:8])
+		assert.NotContains(t, suggestions, "deal") // shorter than a short link code can be
+	})
+
+	t.Run("Synonym Keeps The Rest Of The Code", func(t *testing.T) {
+		suggestions := ShortCodeSuggestions("summerpromo", "")
+		assert.Contains(t, suggestions, "summerdeal")
+		assert.Contains(t, suggestions, "summeroffer")
+	})
+
+	t.Run("Upper Case Code", func(t *testing.T) {
+		suggestions := ShortCodeSuggestions("PROMO", "")
+		assert.Contains(t, suggestions, "OFFER")
+		assert.Contains(t, suggestions, "MYPROMO")
+	})
+
+	t.Run("Only Valid Codes", func(t *testing.T) {
+		code := strings.Repeat("a", MaxShortCodeLength)
+		suggestions := ShortCodeSuggestions(code, "Hi")
+		assert.NotEmpty(t, suggestions)
+		for _, suggestion := range suggestions {
+			assert.True(t, isValidShortCode(suggestion), suggestion)
+			assert.NotEqual(t, code, suggestion)
+		}
+	})
+
+	t.Run("Without Duplicates", func(t *testing.T) {
+		suggestions := ShortCodeSuggestions("promo", "promo")
+		seen := map[string]bool{}
+		for _, suggestion := range suggestions {
+			assert.False(t, seen[strings.ToLower(suggestion)], suggestion)
+			seen[strings.ToLower(suggestion)] = true
+		}
+		assert.NotContains(t, suggestions, "promo")
+	})
+}
@@ -0,0 +1,51 @@
+package helper
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ilhamfzri/pendek.in/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShortCodeGenerator(t *testing.T) {
+	t.Run("[Generate][Default Config]", func(t *testing.T) {
+		generator, err := NewShortCodeGenerator(config.ShortCodeConfig{})
+		assert.Nil(t, err)
+		code, err := generator.Generate()
+		assert.Nil(t, err)
+		assert.Len(t, code, defaultShortCodeLength)
+		for _, char := range code {
+			assert.True(t, strings.ContainsRune(defaultShortCodeAlphabet, char))
+		}
+	})
+
+	t.Run("[Generate][Custom Config]", func(t *testing.T) {
+		generator, err := NewShortCodeGenerator(config.ShortCodeConfig{Alphabet: "ab", Length: 12})
+		assert.Nil(t, err)
+		code, err := generator.Generate()
+		assert.Nil(t, err)
+		assert.Len(t, code, 12)
+		assert.Empty(t, strings.Trim(code, "ab"))
+	})
+
+	tests := []struct {
+		TestName string
+		Config   config.ShortCodeConfig
+		Expected error
+	}{
+		{TestName: "[Config][Shortest Length]", Config: config.ShortCodeConfig{Length: MinShortCodeLength}, Expected: nil},
+		{TestName: "[Config][Longest Length]", Config: config.ShortCodeConfig{Length: MaxShortCodeLength}, Expected: nil},
+		{TestName: "[Config][Too Short]", Config: config.ShortCodeConfig{Length: MinShortCodeLength - 1}, Expected: ErrShortCodeLengthInvalid},
+		{TestName: "[Config][Too Long]", Config: config.ShortCodeConfig{Length: MaxShortCodeLength + 1}, Expected: ErrShortCodeLengthInvalid},
+		{TestName: "[Config][Preview Suffix In Alphabet]", Config: config.ShortCodeConfig{Alphabet: "ab+"}, Expected: ErrShortCodeAlphabetInvalid},
+		{TestName: "[Config][Non Ascii Alphabet]", Config: config.ShortCodeConfig{Alphabet: "abé"}, Expected: ErrShortCodeAlphabetInvalid},
+	}
+
+	for _, test := range tests {
+		t.Run(test.TestName, func(t *testing.T) {
+			_, err := NewShortCodeGenerator(test.Config)
+			assert.Equal(t, test.Expected, err)
+		})
+	}
+}
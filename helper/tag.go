@@ -0,0 +1,37 @@
+package helper
+
+import (
+	"sort"
+
+	"github.com/ilhamfzri/pendek.in/internal/model/domain"
+	"github.com/ilhamfzri/pendek.in/internal/model/web"
+)
+
+// TagAnalytic sums the link totals per tag, totals must be in the same order as the custom links.
+func TagAnalytic(customLinks []domain.CustomLink, totals []web.TotalCustomLinkAnalyticResponse) []web.TagCustomLinkAnalyticResponse {
+	tagAnalytics := map[uint]*web.TagCustomLinkAnalyticResponse{}
+	for i, customLink := range customLinks {
+		if i >= len(totals) {
+			break
+		}
+		for _, tag := range customLink.Tags {
+			tagAnalytic, ok := tagAnalytics[tag.ID]
+			if !ok {
+				tagAnalytic = &web.TagCustomLinkAnalyticResponse{TagID: tag.ID, Name: tag.Name}
+				tagAnalytics[tag.ID] = tagAnalytic
+			}
+			tagAnalytic.LinkCount++
+			tagAnalytic.TotalClickCount += totals[i].TotalClickCount
+			tagAnalytic.TotalViewCount += totals[i].TotalViewCount
+		}
+	}
+
+	tagAnalyticResponses := []web.TagCustomLinkAnalyticResponse{}
+	for _, tagAnalytic := range tagAnalytics {
+		tagAnalyticResponses = append(tagAnalyticResponses, *tagAnalytic)
+	}
+	sort.Slice(tagAnalyticResponses, func(i, j int) bool {
+		return tagAnalyticResponses[i].TagID < tagAnalyticResponses[j].TagID
+	})
+	return tagAnalyticResponses
+}
@@ -0,0 +1,33 @@
+package helper
+
+import (
+	"testing"
+
+	"github.com/ilhamfzri/pendek.in/internal/model/domain"
+	"github.com/ilhamfzri/pendek.in/internal/model/web"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTagAnalytic(t *testing.T) {
+	promo := domain.Tag{ID: 1, Name: "promo"}
+	event := domain.Tag{ID: 2, Name: "event"}
+
+	customLinks := []domain.CustomLink{
+		{Tags: []domain.Tag{event, promo}},
+		{Tags: []domain.Tag{promo}},
+		{},
+	}
+	totals := []web.TotalCustomLinkAnalyticResponse{
+		{TotalClickCount: 3, TotalViewCount: 10},
+		{TotalClickCount: 5, TotalViewCount: 20},
+		{TotalClickCount: 7, TotalViewCount: 30},
+	}
+
+	tagAnalytics := TagAnalytic(customLinks, totals)
+	assert.Equal(t, []web.TagCustomLinkAnalyticResponse{
+		{TagID: 1, Name: "promo", LinkCount: 2, TotalClickCount: 8, TotalViewCount: 30},
+		{TagID: 2, Name: "event", LinkCount: 1, TotalClickCount: 3, TotalViewCount: 10},
+	}, tagAnalytics)
+
+	assert.Empty(t, TagAnalytic(nil, nil))
+}
@@ -0,0 +1,99 @@
+package helper
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ilhamfzri/pendek.in/helper/uaparser"
+	"github.com/ilhamfzri/pendek.in/internal/model/domain"
+)
+
+type TargetingVisitor struct {
+	Device   string
+	OS       string
+	Country  string
+	Language string
+}
+
+func NewTargetingVisitor(userAgent string, acceptLanguage string, country string) TargetingVisitor {
+	ua := uaparser.Parse(userAgent)
+
+	visitor := TargetingVisitor{
+		OS:       strings.ToLower(ua.OS),
+		Country:  strings.ToUpper(country),
+		Language: PreferredLanguage(acceptLanguage),
+	}
+
+	if ua.Desktop {
+		visitor.Device = "desktop"
+	} else if ua.Mobile {
+		visitor.Device = "mobile"
+	} else if ua.Tablet {
+		visitor.Device = "tablet"
+	}
+	return visitor
+}
+
+// PreferredLanguage returns the language with the highest quality value of an Accept-Language header.
+func PreferredLanguage(acceptLanguage string) string {
+	type language struct {
+		Tag     string
+		Quality float64
+	}
+
+	var languages []language
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" || tag == "*" {
+			continue
+		}
+
+		quality := 1.0
+		if params = strings.TrimSpace(params); strings.HasPrefix(params, "q=") {
+			parsedQuality, err := strconv.ParseFloat(strings.TrimPrefix(params, "q="), 64)
+			if err != nil {
+				continue
+			}
+			quality = parsedQuality
+		}
+
+		if quality > 0 {
+			languages = append(languages, language{Tag: tag, Quality: quality})
+		}
+	}
+
+	if len(languages) == 0 {
+		return ""
+	}
+
+	sort.SliceStable(languages, func(i, j int) bool {
+		return languages[i].Quality > languages[j].Quality
+	})
+	return languages[0].Tag
+}
+
+// MatchTargetingRule checks every condition set on the rule against the visitor, empty conditions match anything.
+// A language condition without a region like "ja" also matches "ja-jp".
+func MatchTargetingRule(rule *domain.CustomLinkTargetingRule, visitor *TargetingVisitor) bool {
+	if rule.Device != "" && !strings.EqualFold(rule.Device, visitor.Device) {
+		return false
+	}
+
+	if rule.OS != "" && !strings.EqualFold(rule.OS, visitor.OS) {
+		return false
+	}
+
+	if rule.Country != "" && !strings.EqualFold(rule.Country, visitor.Country) {
+		return false
+	}
+
+	if rule.Language != "" {
+		language := strings.ToLower(rule.Language)
+		if visitor.Language != language && !strings.HasPrefix(visitor.Language, language+"-") {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,57 @@
+package helper
+
+import (
+	"testing"
+
+	"github.com/ilhamfzri/pendek.in/internal/model/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	iPhoneUserAgent  = "Mozilla/5.0 (iPhone; CPU iPhone OS 16_0 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/16.0 Mobile/15E148 Safari/604.1"
+	androidUserAgent = "Mozilla/5.0 (Linux; Android 13; Pixel 7 Build/TQ1A.221205.011) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/108.0.0.0 Mobile Safari/537.36"
+	windowsUserAgent = "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/108.0.0.0 Safari/537.36"
+)
+
+func TestPreferredLanguage(t *testing.T) {
+	assert.Equal(t, "", PreferredLanguage(""))
+	assert.Equal(t, "ja-jp", PreferredLanguage("ja-JP"))
+	assert.Equal(t, "en-us", PreferredLanguage("en-US,en;q=0.9,ja;q=0.8"))
+	assert.Equal(t, "id", PreferredLanguage("en;q=0.5, id, *;q=0.1"))
+	assert.Equal(t, "ja", PreferredLanguage("en;q=0, ja;q=0.3"))
+}
+
+func TestNewTargetingVisitor(t *testing.T) {
+	visitor := NewTargetingVisitor(iPhoneUserAgent, "ja-JP,ja;q=0.9", "jp")
+	assert.Equal(t, TargetingVisitor{Device: "mobile", OS: "ios", Country: "JP", Language: "ja-jp"}, visitor)
+
+	visitor = NewTargetingVisitor(windowsUserAgent, "", "")
+	assert.Equal(t, "desktop", visitor.Device)
+	assert.Equal(t, "windows", visitor.OS)
+}
+
+func TestMatchTargetingRule(t *testing.T) {
+	iPhoneVisitor := NewTargetingVisitor(iPhoneUserAgent, "ja-JP", "JP")
+	androidVisitor := NewTargetingVisitor(androidUserAgent, "id-ID", "ID")
+
+	tests := []struct {
+		Name     string
+		Rule     domain.CustomLinkTargetingRule
+		Visitor  TargetingVisitor
+		Expected bool
+	}{
+		{Name: "[OS Match]", Rule: domain.CustomLinkTargetingRule{OS: "iOS"}, Visitor: iPhoneVisitor, Expected: true},
+		{Name: "[OS Mismatch]", Rule: domain.CustomLinkTargetingRule{OS: "ios"}, Visitor: androidVisitor, Expected: false},
+		{Name: "[Country Match]", Rule: domain.CustomLinkTargetingRule{Country: "ID"}, Visitor: androidVisitor, Expected: true},
+		{Name: "[Language Prefix Match]", Rule: domain.CustomLinkTargetingRule{Language: "ja"}, Visitor: iPhoneVisitor, Expected: true},
+		{Name: "[Language Region Mismatch]", Rule: domain.CustomLinkTargetingRule{Language: "ja-kr"}, Visitor: iPhoneVisitor, Expected: false},
+		{Name: "[All Conditions Match]", Rule: domain.CustomLinkTargetingRule{Device: "mobile", OS: "android", Country: "ID"}, Visitor: androidVisitor, Expected: true},
+		{Name: "[One Condition Mismatch]", Rule: domain.CustomLinkTargetingRule{Device: "desktop", OS: "android"}, Visitor: androidVisitor, Expected: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			assert.Equal(t, test.Expected, MatchTargetingRule(&test.Rule, &test.Visitor))
+		})
+	}
+}
@@ -16,6 +16,12 @@ func IsNeedUpdate(lastUpdate time.Time, threshold time.Duration) bool {
 	return lastUpdate.Before(now)
 }
 
+// IsRetentionOver reports whether the retention period of something deleted at deletedAt has passed, it's over
+// from deletedAt + retention on.
+func IsRetentionOver(deletedAt time.Time, retention time.Duration, now time.Time) bool {
+	return !now.Before(deletedAt.Add(retention))
+}
+
 func IsLast30Days(t time.Time) bool {
 	timeNow := time.Now()
 	dateNow := time.Date(timeNow.Year(), timeNow.Month(), timeNow.Day(), 0, 0, 0, 0, timeNow.Location())
@@ -36,6 +42,24 @@ func IsFutureDate(t time.Time) bool {
 	return t.After(dateNow)
 }
 
+// IsWithinWindow checks t against an optional window, a nil bound means the window is open on that side.
+func IsWithinWindow(t time.Time, from *time.Time, until *time.Time) bool {
+	if from != nil && t.Before(*from) {
+		return false
+	}
+	if until != nil && !t.Before(*until) {
+		return false
+	}
+	return true
+}
+
+func IsValidWindow(from *time.Time, until *time.Time) bool {
+	if from == nil || until == nil {
+		return true
+	}
+	return until.After(*from)
+}
+
 func ToDate(t time.Time) time.Time {
 	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
 }
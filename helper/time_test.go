@@ -0,0 +1,65 @@
+package helper
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsWithinWindow(t *testing.T) {
+	now := time.Now()
+	before := now.Add(-time.Hour)
+	after := now.Add(time.Hour)
+
+	tests := []struct {
+		Name     string
+		From     *time.Time
+		Until    *time.Time
+		Expected bool
+	}{
+		{Name: "[Open Window]", From: nil, Until: nil, Expected: true},
+		{Name: "[Inside Window]", From: &before, Until: &after, Expected: true},
+		{Name: "[Not Started]", From: &after, Until: nil, Expected: false},
+		{Name: "[Already Ended]", From: nil, Until: &before, Expected: false},
+		{Name: "[Ends Exactly Now]", From: &before, Until: &now, Expected: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			assert.Equal(t, test.Expected, IsWithinWindow(now, test.From, test.Until))
+		})
+	}
+}
+
+func TestIsValidWindow(t *testing.T) {
+	now := time.Now()
+	after := now.Add(time.Hour)
+
+	assert.True(t, IsValidWindow(nil, &now))
+	assert.True(t, IsValidWindow(&now, &after))
+	assert.False(t, IsValidWindow(&after, &now))
+	assert.False(t, IsValidWindow(&now, &now))
+}
+
+func TestIsRetentionOver(t *testing.T) {
+	deletedAt := time.Date(2022, 11, 1, 10, 0, 0, 0, time.UTC)
+	retention := 30 * 24 * time.Hour
+
+	tests := []struct {
+		Name     string
+		Now      time.Time
+		Expected bool
+	}{
+		{Name: "[Just Deleted]", Now: deletedAt, Expected: false},
+		{Name: "[Just Before The End]", Now: deletedAt.Add(retention - time.Nanosecond), Expected: false},
+		{Name: "[Exactly At The End]", Now: deletedAt.Add(retention), Expected: true},
+		{Name: "[After The End]", Now: deletedAt.Add(retention + time.Hour), Expected: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			assert.Equal(t, test.Expected, IsRetentionOver(deletedAt, retention, test.Now))
+		})
+	}
+}
@@ -0,0 +1,210 @@
+package urlsafety
+
+// The threat feed is a local text file with one domain per line, lines starting with # are comments.
+// Hosts file rows such as "0.0.0.0 bad.example" and full urls are accepted too, so most public
+// feeds can be used without converting them first.
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"net"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/ilhamfzri/pendek.in/config"
+)
+
+var (
+	DefaultAllowedSchemes = []string{"http", "https"}
+	RedirectPathPrefixes  = []string{"/l/"} // paths of our own host that redirect again
+)
+
+var (
+	ErrURLInvalid       = errors.New("url is invalid")
+	ErrSchemeNotAllowed = errors.New("url scheme is not allowed")
+	ErrRedirectLoop     = errors.New("url points to a short link of this site")
+	ErrDomainBlocked    = errors.New("url domain is blocked")
+	ErrDomainNotAllowed = errors.New("url domain is not allowed")
+	ErrDomainThreat     = errors.New("url domain is listed as a threat")
+)
+
+// ThreatFeed is the hook for lists of known bad domains, the host given is lower case without a port.
+type ThreatFeed interface {
+	Contains(host string) bool
+}
+
+// HostLookup is the hook for hosts only known when a link is checked, e.g. the verified custom domains
+// of the database. The host given is lower case without a port.
+type HostLookup func(host string) bool
+
+// DomainSet is a ThreatFeed matching the listed domains and their subdomains.
+type DomainSet map[string]struct{}
+
+func (set DomainSet) Contains(host string) bool {
+	for {
+		if _, ok := set[host]; ok {
+			return true
+		}
+		i := strings.IndexByte(host, '.')
+		if i < 0 {
+			return false
+		}
+		host = host[i+1:]
+	}
+}
+
+func NewDomainSet(domains []string) DomainSet {
+	set := DomainSet{}
+	for _, domain := range domains {
+		if domain = normalizeHost(domain); domain != "" {
+			set[domain] = struct{}{}
+		}
+	}
+	return set
+}
+
+// LoadThreatFeed reads a threat feed file, see the top of this file for the format.
+func LoadThreatFeed(reader io.Reader) (DomainSet, error) {
+	set := DomainSet{}
+	scanner := bufio.NewScanner(reader)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		entry := fields[len(fields)-1]
+		if strings.Contains(entry, "://") {
+			if parsedUrl, err := url.Parse(entry); err == nil {
+				entry = parsedUrl.Hostname()
+			}
+		}
+
+		if domain := normalizeHost(entry); domain != "" {
+			set[domain] = struct{}{}
+		}
+	}
+	return set, scanner.Err()
+}
+
+type Checker struct {
+	schemes  map[string]bool
+	blocked  DomainSet
+	allowed  DomainSet
+	ownHosts DomainSet
+	feeds    []ThreatFeed
+}
+
+// NewChecker builds the checker from the config, the threat feed file is loaded when a path is configured.
+func NewChecker(cfg config.URLSafetyConfig) (*Checker, error) {
+	schemes := cfg.AllowedSchemes
+	if len(schemes) == 0 {
+		schemes = DefaultAllowedSchemes
+	}
+
+	checker := &Checker{
+		schemes:  map[string]bool{},
+		blocked:  NewDomainSet(cfg.BlockedDomains),
+		allowed:  NewDomainSet(cfg.AllowedDomains),
+		ownHosts: DomainSet{},
+	}
+	for _, scheme := range schemes {
+		checker.schemes[strings.ToLower(scheme)] = true
+	}
+	for _, host := range cfg.OwnHosts {
+		if host = normalizeHost(host); host != "" {
+			checker.ownHosts[host] = struct{}{}
+		}
+	}
+
+	if cfg.ThreatFeedPath != "" {
+		file, err := os.Open(cfg.ThreatFeedPath)
+		if err != nil {
+			return nil, err
+		}
+		defer file.Close()
+
+		feed, err := LoadThreatFeed(file)
+		if err != nil {
+			return nil, err
+		}
+		checker.AddThreatFeed(feed)
+	}
+	return checker, nil
+}
+
+func (checker *Checker) AddThreatFeed(feed ThreatFeed) {
+	checker.feeds = append(checker.feeds, feed)
+}
+
+// Check returns the reason a destination is unsafe, requestHost is the host the link is created on and
+// counts as one of our own hosts, so do the hosts matched by customDomains when it isn't nil.
+// Domains of the allowlist skip the blocklist and the threat feeds.
+func (checker *Checker) Check(rawUrl string, requestHost string, customDomains HostLookup) error {
+	parsedUrl, err := url.Parse(strings.TrimSpace(rawUrl))
+	if err != nil {
+		return ErrURLInvalid
+	}
+
+	if !checker.schemes[strings.ToLower(parsedUrl.Scheme)] {
+		return ErrSchemeNotAllowed
+	}
+
+	host := normalizeHost(parsedUrl.Hostname())
+	if host == "" {
+		return ErrURLInvalid
+	}
+
+	// The path is checked first, looking up the custom domains may cost a query.
+	if isRedirectPath(parsedUrl.Path) && checker.isOwnHost(host, requestHost, customDomains) {
+		return ErrRedirectLoop
+	}
+
+	if checker.allowed.Contains(host) {
+		return nil
+	}
+
+	if checker.blocked.Contains(host) {
+		return ErrDomainBlocked
+	}
+
+	for _, feed := range checker.feeds {
+		if feed.Contains(host) {
+			return ErrDomainThreat
+		}
+	}
+	return nil
+}
+
+func (checker *Checker) isOwnHost(host string, requestHost string, customDomains HostLookup) bool {
+	if _, ok := checker.ownHosts[host]; ok {
+		return true
+	}
+
+	if requestHost != "" && host == normalizeHost(requestHost) {
+		return true
+	}
+	return customDomains != nil && customDomains(host)
+}
+
+func isRedirectPath(urlPath string) bool {
+	urlPath = "/" + strings.TrimLeft(urlPath, "/")
+	for _, prefix := range RedirectPathPrefixes {
+		if strings.HasPrefix(strings.ToLower(urlPath), prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeHost lower cases the host and strips the port and the trailing dot.
+func normalizeHost(host string) string {
+	host = strings.ToLower(strings.TrimSpace(host))
+	if splitHost, _, err := net.SplitHostPort(host); err == nil {
+		host = splitHost
+	}
+	return strings.Trim(strings.TrimSuffix(host, "."), "[]")
+}
@@ -0,0 +1,87 @@
+package urlsafety
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ilhamfzri/pendek.in/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadThreatFeed(t *testing.T) {
+	feed, err := LoadThreatFeed(strings.NewReader("# feed\n\nBad.Example\n0.0.0.0 malware.test\nhttps://phish.test/login\n"))
+	assert.Nil(t, err)
+	assert.True(t, feed.Contains("bad.example"))
+	assert.True(t, feed.Contains("cdn.bad.example"))
+	assert.True(t, feed.Contains("malware.test"))
+	assert.True(t, feed.Contains("phish.test"))
+	assert.False(t, feed.Contains("example"))
+	assert.False(t, feed.Contains("notbad.example"))
+}
+
+func TestChecker(t *testing.T) {
+	checker, err := NewChecker(config.URLSafetyConfig{
+		BlockedDomains: []string{"blocked.test"},
+		AllowedDomains: []string{"good.blocked.test"},
+		OwnHosts:       []string{"pendek.in"},
+	})
+	assert.Nil(t, err)
+	checker.AddThreatFeed(NewDomainSet([]string{"threat.test"}))
+	customDomains := func(host string) bool {
+		return host == "brand.example"
+	}
+
+	tests := []struct {
+		Name        string
+		Url         string
+		RequestHost string
+		Expected    error
+	}{
+		{Name: "Safe", Url: "https://example.com/page", Expected: nil},
+		{Name: "Javascript Scheme", Url: "javascript:alert(1)", Expected: ErrSchemeNotAllowed},
+		{Name: "Data Scheme", Url: "data:text/html,<script>", Expected: ErrSchemeNotAllowed},
+		{Name: "Upper Case Scheme", Url: "HTTPS://example.com", Expected: nil},
+		{Name: "No Host", Url: "https:///path", Expected: ErrURLInvalid},
+		{Name: "Own Short Link", Url: "https://PENDEK.IN/l/promo1", Expected: ErrRedirectLoop},
+		{Name: "Own QR Link", Url: "https://pendek.in/l/promo1?source=qr", Expected: ErrRedirectLoop},
+		{Name: "Own Profile", Url: "https://pendek.in/john", Expected: nil},
+		{Name: "Own Profile Named Q", Url: "https://pendek.in/q/instagram", Expected: nil},
+		{Name: "Custom Domain Short Link", Url: "https://Brand.Example:443/l/promo1", Expected: ErrRedirectLoop},
+		{Name: "Custom Domain Page", Url: "https://brand.example/about", Expected: nil},
+		{Name: "Custom Domain Subdomain", Url: "https://www.brand.example/l/promo1", Expected: nil},
+		{Name: "Request Host", Url: "http://localhost:8080/l/promo1", RequestHost: "localhost:8080", Expected: ErrRedirectLoop},
+		{Name: "Blocked Subdomain", Url: "https://www.blocked.test", Expected: ErrDomainBlocked},
+		{Name: "Allowlist Wins", Url: "https://good.blocked.test", Expected: nil},
+		{Name: "Threat Feed", Url: "https://threat.test.", Expected: ErrDomainThreat},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			assert.Equal(t, test.Expected, checker.Check(test.Url, test.RequestHost, customDomains))
+		})
+	}
+}
+
+func TestNewChecker(t *testing.T) {
+	t.Run("Threat Feed File", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "feed.txt")
+		assert.Nil(t, os.WriteFile(path, []byte("bad.example\n"), 0600))
+
+		checker, err := NewChecker(config.URLSafetyConfig{ThreatFeedPath: path})
+		assert.Nil(t, err)
+		assert.Equal(t, ErrDomainThreat, checker.Check("https://bad.example", "", nil))
+	})
+
+	t.Run("Missing Threat Feed File", func(t *testing.T) {
+		_, err := NewChecker(config.URLSafetyConfig{ThreatFeedPath: filepath.Join(t.TempDir(), "missing.txt")})
+		assert.NotNil(t, err)
+	})
+
+	t.Run("Custom Schemes", func(t *testing.T) {
+		checker, err := NewChecker(config.URLSafetyConfig{AllowedSchemes: []string{"https"}})
+		assert.Nil(t, err)
+		assert.Equal(t, ErrSchemeNotAllowed, checker.Check("http://example.com", "", nil))
+	})
+}
@@ -0,0 +1,68 @@
+package helper
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/ilhamfzri/pendek.in/internal/model/domain"
+)
+
+var utmParameterKeys = []string{"utm_source", "utm_medium", "utm_campaign", "utm_term", "utm_content"}
+
+func utmParameterValues(utm domain.Utm) []string {
+	return []string{utm.Source, utm.Medium, utm.Campaign, utm.Term, utm.Content}
+}
+
+// BuildUtmLink merges the UTM parameters into the query string of the long link. Values set on the
+// link replace the ones already present on the long link, values from the default template only
+// fill the parameters the long link doesn't carry yet. The other query parameters are kept as is.
+func BuildUtmLink(longLink string, utm domain.Utm, defaultUtm domain.Utm) (string, error) {
+	parsedLink, err := url.Parse(longLink)
+	if err != nil {
+		return "", err
+	}
+
+	existingQuery := parsedLink.Query()
+	values := utmParameterValues(utm)
+	defaultValues := utmParameterValues(defaultUtm)
+
+	parameters := make(map[string]string)
+	for i, key := range utmParameterKeys {
+		if values[i] != "" {
+			parameters[key] = values[i]
+		} else if defaultValues[i] != "" && !existingQuery.Has(key) {
+			parameters[key] = defaultValues[i]
+		}
+	}
+
+	if len(parameters) == 0 {
+		return longLink, nil
+	}
+
+	var pairs []string
+	for _, pair := range strings.Split(parsedLink.RawQuery, "&") {
+		if pair == "" {
+			continue
+		}
+
+		key, _, _ := strings.Cut(pair, "=")
+		if unescapedKey, err := url.QueryUnescape(key); err == nil {
+			key = unescapedKey
+		}
+
+		if _, ok := parameters[key]; ok {
+			continue
+		}
+		pairs = append(pairs, pair)
+	}
+
+	for _, key := range utmParameterKeys {
+		if value, ok := parameters[key]; ok {
+			pairs = append(pairs, key+"="+url.QueryEscape(value))
+		}
+	}
+
+	parsedLink.RawQuery = strings.Join(pairs, "&")
+	parsedLink.ForceQuery = false
+	return parsedLink.String(), nil
+}
@@ -0,0 +1,57 @@
+package helper
+
+import (
+	"testing"
+
+	"github.com/ilhamfzri/pendek.in/internal/model/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildUtmLink(t *testing.T) {
+	tests := []struct {
+		Name       string
+		LongLink   string
+		Utm        domain.Utm
+		DefaultUtm domain.Utm
+		Expected   string
+	}{
+		{
+			Name:     "[Without Utm]",
+			LongLink: "https://example.com/page?b=2&a=1",
+			Expected: "https://example.com/page?b=2&a=1",
+		},
+		{
+			Name:     "[Link Utm]",
+			LongLink: "https://example.com/page",
+			Utm:      domain.Utm{Source: "newsletter", Campaign: "summer sale"},
+			Expected: "https://example.com/page?utm_source=newsletter&utm_campaign=summer+sale",
+		},
+		{
+			Name:     "[Keep Other Parameters And Fragment]",
+			LongLink: "https://example.com/page?b=2&a=1#top",
+			Utm:      domain.Utm{Medium: "email"},
+			Expected: "https://example.com/page?b=2&a=1&utm_medium=email#top",
+		},
+		{
+			Name:     "[Link Utm Replaces Existing]",
+			LongLink: "https://example.com/page?utm_source=old&id=7&utm_source=older",
+			Utm:      domain.Utm{Source: "new"},
+			Expected: "https://example.com/page?id=7&utm_source=new",
+		},
+		{
+			Name:       "[Default Utm Fills Gaps Only]",
+			LongLink:   "https://example.com/page?utm_source=partner",
+			Utm:        domain.Utm{Campaign: "launch"},
+			DefaultUtm: domain.Utm{Source: "pendekin", Medium: "shortlink", Campaign: "default"},
+			Expected:   "https://example.com/page?utm_source=partner&utm_medium=shortlink&utm_campaign=launch",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			link, err := BuildUtmLink(test.LongLink, test.Utm, test.DefaultUtm)
+			assert.Nil(t, err)
+			assert.Equal(t, test.Expected, link)
+		})
+	}
+}
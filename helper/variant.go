@@ -0,0 +1,33 @@
+package helper
+
+import (
+	"hash/fnv"
+
+	"github.com/ilhamfzri/pendek.in/internal/model/domain"
+)
+
+// VariantAssignmentKey identifies a visitor of a link when there is no sticky cookie yet.
+func VariantAssignmentKey(clientIP string, userAgent string, shortLinkCode string) string {
+	return clientIP + "|" + userAgent + "|" + shortLinkCode
+}
+
+// PickVariant picks a variant by weight, the same key always lands on the same variant as long as the variants don't change.
+// The variants must not be empty and every weight must be at least 1.
+func PickVariant(variants []domain.CustomLinkVariant, key string) domain.CustomLinkVariant {
+	var totalWeight uint64
+	for _, variant := range variants {
+		totalWeight += uint64(variant.Weight)
+	}
+
+	hash := fnv.New64a()
+	hash.Write([]byte(key))
+	bucket := hash.Sum64() % totalWeight
+
+	for _, variant := range variants {
+		if bucket < uint64(variant.Weight) {
+			return variant
+		}
+		bucket -= uint64(variant.Weight)
+	}
+	return variants[len(variants)-1]
+}
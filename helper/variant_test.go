@@ -0,0 +1,38 @@
+package helper
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/ilhamfzri/pendek.in/internal/model/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPickVariant(t *testing.T) {
+	variants := []domain.CustomLinkVariant{
+		{Name: "A", TargetLink: "https://example.com/a", Weight: 70},
+		{Name: "B", TargetLink: "https://example.com/b", Weight: 30},
+	}
+
+	t.Run("[Sticky]", func(t *testing.T) {
+		key := VariantAssignmentKey("10.0.0.1", "Mozilla/5.0", "abcde")
+		variant := PickVariant(variants, key)
+		for i := 0; i < 10; i++ {
+			assert.Equal(t, variant.Name, PickVariant(variants, key).Name)
+		}
+	})
+
+	t.Run("[Weighted]", func(t *testing.T) {
+		counts := map[string]int{}
+		for i := 0; i < 10000; i++ {
+			key := VariantAssignmentKey(fmt.Sprintf("10.0.%d.%d", i/256, i%256), "Mozilla/5.0", "abcde")
+			counts[PickVariant(variants, key).Name]++
+		}
+		assert.InDelta(t, 7000, counts["A"], 300)
+		assert.InDelta(t, 3000, counts["B"], 300)
+	})
+
+	t.Run("[Single Variant]", func(t *testing.T) {
+		assert.Equal(t, "A", PickVariant(variants[:1], "any").Name)
+	})
+}
@@ -0,0 +1,125 @@
+package xlsx
+
+// Minimal streaming xlsx writer, rows are written straight into the zip stream
+// so the whole sheet is never kept in memory. Every cell is written as an inline string.
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"io"
+	"strconv"
+)
+
+const contentTypesXml = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">
+<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>
+<Default Extension="xml" ContentType="application/xml"/>
+<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>
+<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>
+</Types>`
+
+const relsXml = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>
+</Relationships>`
+
+const workbookXml = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">
+<sheets><sheet name="Sheet1" sheetId="1" r:id="rId1"/></sheets>
+</workbook>`
+
+const workbookRelsXml = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>
+</Relationships>`
+
+const sheetHeaderXml = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`
+
+const sheetFooterXml = `</sheetData></worksheet>`
+
+type Writer struct {
+	zipWriter   *zip.Writer
+	sheetWriter io.Writer
+	rowCount    int
+}
+
+func NewWriter(w io.Writer) (*Writer, error) {
+	zipWriter := zip.NewWriter(w)
+
+	staticFiles := []struct {
+		Name    string
+		Content string
+	}{
+		{Name: "[Content_Types].xml", Content: contentTypesXml},
+		{Name: "_rels/.rels", Content: relsXml},
+		{Name: "xl/workbook.xml", Content: workbookXml},
+		{Name: "xl/_rels/workbook.xml.rels", Content: workbookRelsXml},
+	}
+
+	for _, staticFile := range staticFiles {
+		fileWriter, err := zipWriter.Create(staticFile.Name)
+		if err != nil {
+			return nil, err
+		}
+		if _, err = io.WriteString(fileWriter, staticFile.Content); err != nil {
+			return nil, err
+		}
+	}
+
+	// The sheet must be the last file, zip entries can't be written concurrently.
+	sheetWriter, err := zipWriter.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return nil, err
+	}
+	if _, err = io.WriteString(sheetWriter, sheetHeaderXml); err != nil {
+		return nil, err
+	}
+
+	return &Writer{
+		zipWriter:   zipWriter,
+		sheetWriter: sheetWriter,
+	}, nil
+}
+
+func (writer *Writer) WriteRow(cells []string) error {
+	writer.rowCount += 1
+	rowNumber := strconv.Itoa(writer.rowCount)
+
+	if _, err := io.WriteString(writer.sheetWriter, `<row r="`+rowNumber+`">`); err != nil {
+		return err
+	}
+
+	for i, cell := range cells {
+		cellRef := columnName(i) + rowNumber
+		if _, err := io.WriteString(writer.sheetWriter, `<c r="`+cellRef+`" t="inlineStr"><is><t xml:space="preserve">`); err != nil {
+			return err
+		}
+		if err := xml.EscapeText(writer.sheetWriter, []byte(cell)); err != nil {
+			return err
+		}
+		if _, err := io.WriteString(writer.sheetWriter, `</t></is></c>`); err != nil {
+			return err
+		}
+	}
+
+	_, err := io.WriteString(writer.sheetWriter, `</row>`)
+	return err
+}
+
+func (writer *Writer) Close() error {
+	if _, err := io.WriteString(writer.sheetWriter, sheetFooterXml); err != nil {
+		return err
+	}
+	return writer.zipWriter.Close()
+}
+
+// columnName converts a zero based column index to the spreadsheet column name (0 -> A, 26 -> AA).
+func columnName(index int) string {
+	name := ""
+	for index >= 0 {
+		name = string(rune('A'+index%26)) + name
+		index = index/26 - 1
+	}
+	return name
+}
@@ -0,0 +1,42 @@
+package xlsx
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriter(t *testing.T) {
+	var buffer bytes.Buffer
+	writer, err := NewWriter(&buffer)
+	assert.Nil(t, err)
+
+	assert.Nil(t, writer.WriteRow([]string{"title", "long_link"}))
+	assert.Nil(t, writer.WriteRow([]string{"Tom & Jerry", "https://example.com/?a=1&b=2"}))
+	assert.Nil(t, writer.Close())
+
+	zipReader, err := zip.NewReader(bytes.NewReader(buffer.Bytes()), int64(buffer.Len()))
+	assert.Nil(t, err)
+
+	var sheet []byte
+	for _, file := range zipReader.File {
+		if file.Name == "xl/worksheets/sheet1.xml" {
+			fileReader, _ := file.Open()
+			sheet, _ = io.ReadAll(fileReader)
+			fileReader.Close()
+		}
+	}
+
+	assert.Contains(t, string(sheet), `<c r="B2" t="inlineStr">`)
+	assert.Contains(t, string(sheet), "Tom &amp; Jerry")
+}
+
+func TestColumnName(t *testing.T) {
+	assert.Equal(t, "A", columnName(0))
+	assert.Equal(t, "Z", columnName(25))
+	assert.Equal(t, "AA", columnName(26))
+	assert.Equal(t, "AB", columnName(27))
+}
@@ -0,0 +1,97 @@
+package controller
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ilhamfzri/pendek.in/app/logger"
+	"github.com/ilhamfzri/pendek.in/helper"
+	"github.com/ilhamfzri/pendek.in/internal/model/web"
+	"github.com/ilhamfzri/pendek.in/internal/service"
+)
+
+type AdminControllerImpl struct {
+	ReservedWordService service.ReservedWordService
+	Logger              *logger.Logger
+}
+
+func NewAdminController(reservedWordService service.ReservedWordService, logger *logger.Logger) AdminController {
+	return &AdminControllerImpl{
+		ReservedWordService: reservedWordService,
+		Logger:              logger,
+	}
+}
+
+func (controller *AdminControllerImpl) CreateReservedWord(c *gin.Context) {
+	ctx := context.Background()
+	var request web.ReservedWordCreateRequest
+
+	err := c.ShouldBindJSON(&request)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, helper.ToWebResponseFailed(err))
+		return
+	}
+
+	reservedWordResponse, errService := controller.ReservedWordService.CreateReservedWord(ctx, request)
+	if errService != nil {
+		webResponse := web.WebResponseFailed{
+			Status:  "failed",
+			Message: errService.Error(),
+		}
+		c.JSON(http.StatusBadRequest, webResponse)
+	} else {
+		webResponse := web.WebResponseSuccess{
+			Status:  "success",
+			Message: "success create reserved word",
+			Data:    reservedWordResponse,
+		}
+		c.JSON(http.StatusCreated, webResponse)
+	}
+}
+
+func (controller *AdminControllerImpl) GetAllReservedWord(c *gin.Context) {
+	ctx := context.Background()
+
+	reservedWordsResponse, errService := controller.ReservedWordService.GetAllReservedWord(ctx)
+	if errService != nil {
+		webResponse := web.WebResponseFailed{
+			Status:  "failed",
+			Message: errService.Error(),
+		}
+		c.JSON(http.StatusBadRequest, webResponse)
+	} else {
+		webResponse := web.WebResponseSuccess{
+			Status:  "success",
+			Message: "success get all reserved word",
+			Data:    reservedWordsResponse,
+		}
+		c.JSON(http.StatusOK, webResponse)
+	}
+}
+
+func (controller *AdminControllerImpl) DeleteReservedWord(c *gin.Context) {
+	ctx := context.Background()
+	var request web.ReservedWordDeleteRequest
+
+	err := c.ShouldBindUri(&request)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, helper.ToWebResponseFailed(err))
+		return
+	}
+
+	errService := controller.ReservedWordService.DeleteReservedWord(ctx, request)
+	if errService != nil {
+		webResponse := web.WebResponseFailed{
+			Status:  "failed",
+			Message: errService.Error(),
+		}
+		c.JSON(http.StatusBadRequest, webResponse)
+	} else {
+		webResponse := web.WebResponseSuccess{
+			Status:  "success",
+			Message: "success delete reserved word",
+		}
+		c.JSON(http.StatusOK, webResponse)
+	}
+}
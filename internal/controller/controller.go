@@ -13,6 +13,7 @@ type UserController interface {
 	GenerateToken(c *gin.Context)
 	ChangeProfilePicture(c *gin.Context)
 	Profile(c *gin.Context)
+	ProfileQRCode(c *gin.Context)
 	GetCurrentProfile(c *gin.Context)
 }
 
@@ -21,6 +22,7 @@ type SocialMediaLinkController interface {
 	CreateLink(c *gin.Context)
 	UpdateLink(c *gin.Context)
 	GetAllLink(c *gin.Context)
+	ReorderLink(c *gin.Context)
 	RedirectLink(c *gin.Context)
 	GetLinkAnalytic(c *gin.Context)
 	GetSummaryLinkAnalytic(c *gin.Context)
@@ -28,14 +30,48 @@ type SocialMediaLinkController interface {
 
 type CustomLinkController interface {
 	CreateLink(c *gin.Context)
+	ImportLink(c *gin.Context)
 	UpdateLink(c *gin.Context)
 	GetLink(c *gin.Context)
 	GetAllLink(c *gin.Context)
+	ExportLink(c *gin.Context)
+	DeleteLink(c *gin.Context)
+	GetAllDeletedLink(c *gin.Context)
+	RestoreLink(c *gin.Context)
+	GetLinkHistory(c *gin.Context)
+	RevertLink(c *gin.Context)
+	CloneLink(c *gin.Context)
 	GetAllThumbnail(c *gin.Context)
 	GetUserThumbnail(c *gin.Context)
 	UploadCustomThumbnail(c *gin.Context)
+	CreateUtmTemplate(c *gin.Context)
+	GetAllUtmTemplate(c *gin.Context)
+	UpdateUtmTemplate(c *gin.Context)
+	DeleteUtmTemplate(c *gin.Context)
+	GetLinkQRCode(c *gin.Context)
+	ReorderLink(c *gin.Context)
+	BatchLink(c *gin.Context)
+	CreateTag(c *gin.Context)
+	GetAllTag(c *gin.Context)
+	UpdateTag(c *gin.Context)
+	DeleteTag(c *gin.Context)
+	CreateFolder(c *gin.Context)
+	GetAllFolder(c *gin.Context)
+	UpdateFolder(c *gin.Context)
+	DeleteFolder(c *gin.Context)
+	CreateDomain(c *gin.Context)
+	GetAllDomain(c *gin.Context)
+	VerifyDomain(c *gin.Context)
+	DeleteDomain(c *gin.Context)
 	CheckShortLinkAvaibility(c *gin.Context)
 	RedirectLink(c *gin.Context)
+	UnlockLink(c *gin.Context)
 	GetLinkAnalytic(c *gin.Context)
 	GetSummaryLinkAnalytic(c *gin.Context)
 }
+
+type AdminController interface {
+	CreateReservedWord(c *gin.Context)
+	GetAllReservedWord(c *gin.Context)
+	DeleteReservedWord(c *gin.Context)
+}
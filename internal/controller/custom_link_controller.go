@@ -4,21 +4,47 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"html/template"
 	"io"
 	"net/http"
+	"path"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/go-redis/redis/v8"
 	"github.com/ilhamfzri/pendek.in/app/logger"
 	"github.com/ilhamfzri/pendek.in/helper"
+	"github.com/ilhamfzri/pendek.in/helper/uaparser"
 	"github.com/ilhamfzri/pendek.in/internal/model/web"
 	"github.com/ilhamfzri/pendek.in/internal/service"
 )
 
 // var IntervalCustomLinkAnalyticCacheTime = 30 * time.Minute
 var IntervalCustomLinkAnalyticCacheTime = 1 * time.Second
+var IntervalCustomLinkUnlockAttempt = 15 * time.Minute  // password attempts are counted within this window, an unlock clears them
+var MaxCustomLinkUnlockAttempt = 5                      // per client of a link
+var MaxCustomLinkUnlockAttemptPerLink = 50              // per link from every client, an unlock doesn't clear them
+var CookieNameCustomLinkVariant = "pendekin_variant_%s" // keeps the split variant of a visitor sticky per short link code
+var CookieMaxAgeCustomLinkVariant = 30 * 24 * time.Hour
 var ErrCustomLinkController = "[CustomLinkController] Failed To Execute"
+var ErrCustomLinkImportFormat = errors.New("import format must be csv or json")
+
+// Counts an unlock attempt on every key and starts the window of a key on its first attempt, the count and the
+// expiry are set together.
+var countUnlockAttemptScript = redis.NewScript(`
+local attempts = {}
+for i, key in ipairs(KEYS) do
+	attempts[i] = redis.call("INCR", key)
+	if attempts[i] == 1 then
+		redis.call("PEXPIRE", key, ARGV[1])
+	end
+end
+return attempts
+`)
 
 type CustomLinkControllerImpl struct {
 	Service         service.CustomLinkService
@@ -36,11 +62,679 @@ func NewCustomLinkController(service service.CustomLinkService, serviceAnalytic
 	}
 }
 
-func (controller *CustomLinkControllerImpl) CreateLink(c *gin.Context) {
+func (controller *CustomLinkControllerImpl) CreateLink(c *gin.Context) {
+	ctx := context.Background()
+	domainName := c.Request.Host
+	jwtToken := helper.ExtractTokenFromRequestHeader(c)
+	var request web.CustomLinkCreateRequest
+
+	err := c.ShouldBindJSON(&request)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, helper.ToWebResponseFailed(err))
+		return
+	}
+
+	customLinkResponse, errService := controller.Service.CreateLink(ctx, request, domainName, jwtToken)
+
+	if errService != nil {
+		webResponse := web.WebResponseFailed{
+			Status:  "failed",
+			Message: errService.Error(),
+		}
+		c.JSON(http.StatusBadRequest, webResponse)
+	} else {
+		webResponse := web.WebResponseSuccess{
+			Status:  "success",
+			Message: "success create new link",
+			Data:    customLinkResponse,
+		}
+		c.JSON(http.StatusCreated, webResponse)
+	}
+}
+
+func (controller *CustomLinkControllerImpl) ImportLink(c *gin.Context) {
+	ctx := context.Background()
+	domainName := c.Request.Host
+	jwtToken := helper.ExtractTokenFromRequestHeader(c)
+	var request web.CustomLinkImportRequest
+
+	err := c.ShouldBindQuery(&request)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, helper.ToWebResponseFailed(err))
+		return
+	}
+
+	file, fileHeader, err := c.Request.FormFile("file")
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, helper.ToWebResponseFailed(err))
+		return
+	}
+	defer file.Close()
+
+	if request.Format == "" {
+		request.Format = strings.TrimPrefix(strings.ToLower(path.Ext(fileHeader.Filename)), ".")
+	}
+
+	switch request.Format {
+	case "csv":
+		request.Links, err = helper.ParseCustomLinkImportCSV(file)
+	case "json":
+		request.Links, err = helper.ParseCustomLinkImportJSON(file)
+	default:
+		err = ErrCustomLinkImportFormat
+	}
+
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, helper.ToWebResponseFailed(err))
+		return
+	}
+
+	importResponse, errService := controller.Service.ImportLink(ctx, request, domainName, jwtToken)
+	if errService != nil {
+		webResponse := web.WebResponseFailed{
+			Status:  "failed",
+			Message: errService.Error(),
+		}
+		c.JSON(http.StatusBadRequest, webResponse)
+	} else {
+		webResponse := web.WebResponseSuccess{
+			Status:  "success",
+			Message: "success import link",
+			Data:    importResponse,
+		}
+		c.JSON(http.StatusOK, webResponse)
+	}
+}
+
+func (controller *CustomLinkControllerImpl) UpdateLink(c *gin.Context) {
+	ctx := context.Background()
+	domainName := c.Request.Host
+	jwtToken := helper.ExtractTokenFromRequestHeader(c)
+
+	var request web.CustomLinkUpdateRequest
+
+	err := c.ShouldBindUri(&request)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, helper.ToWebResponseFailed(err))
+		return
+	}
+
+	err = c.ShouldBindJSON(&request)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, helper.ToWebResponseFailed(err))
+		return
+	}
+
+	customLinkResponse, errService := controller.Service.UpdateLink(ctx, request, domainName, jwtToken)
+	if errService != nil {
+		webResponse := web.WebResponseFailed{
+			Status:  "failed",
+			Message: errService.Error(),
+		}
+		c.JSON(http.StatusBadRequest, webResponse)
+	} else {
+		webResponse := web.WebResponseSuccess{
+			Status:  "success",
+			Message: "success update link",
+			Data:    customLinkResponse,
+		}
+		c.JSON(http.StatusCreated, webResponse)
+	}
+
+}
+
+func (controller *CustomLinkControllerImpl) GetLink(c *gin.Context) {
+	ctx := context.Background()
+	domainName := c.Request.Host
+	jwtToken := helper.ExtractTokenFromRequestHeader(c)
+	var request web.CustomLinkGetRequest
+
+	err := c.ShouldBindUri(&request)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, helper.ToWebResponseFailed(err))
+		return
+	}
+
+	customLinkResponse, errService := controller.Service.GetLink(ctx, request, domainName, jwtToken)
+	if errService != nil {
+		webResponse := web.WebResponseFailed{
+			Status:  "failed",
+			Message: errService.Error(),
+		}
+		c.JSON(http.StatusBadRequest, webResponse)
+	} else {
+		webResponse := web.WebResponseSuccess{
+			Status:  "success",
+			Message: "success get link",
+			Data:    customLinkResponse,
+		}
+		c.JSON(http.StatusOK, webResponse)
+	}
+}
+
+func (controller *CustomLinkControllerImpl) GetAllLink(c *gin.Context) {
+	ctx := context.Background()
+	domainName := c.Request.Host
+	jwtToken := helper.ExtractTokenFromRequestHeader(c)
+
+	var request web.CustomLinkGetAllRequest
+
+	err := c.ShouldBindQuery(&request)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, helper.ToWebResponseFailed(err))
+		return
+	}
+
+	customLinksResponse, pagination, errService := controller.Service.GetAllLink(ctx, request, domainName, jwtToken)
+	if errService != nil {
+		webResponse := web.WebResponseFailed{
+			Status:  "failed",
+			Message: errService.Error(),
+		}
+		c.JSON(http.StatusBadRequest, webResponse)
+	} else {
+		webResponse := web.WebResponseSuccess{
+			Status:     "success",
+			Message:    "success get all custom link",
+			Data:       customLinksResponse,
+			Pagination: &pagination,
+		}
+		c.JSON(http.StatusOK, webResponse)
+	}
+
+}
+
+func (controller *CustomLinkControllerImpl) ExportLink(c *gin.Context) {
+	ctx := context.Background()
+	domainName := c.Request.Host
+	jwtToken := helper.ExtractTokenFromRequestHeader(c)
+	var request web.CustomLinkExportRequest
+
+	err := c.ShouldBindQuery(&request)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, helper.ToWebResponseFailed(err))
+		return
+	}
+
+	if request.Format == "" {
+		request.Format = "csv"
+	}
+
+	c.Header("Content-Type", helper.CustomLinkExportContentType(request.Format))
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="custom-links.%s"`, request.Format))
+	c.Status(http.StatusOK)
+
+	// It's streaming the rows into the response, the status can't be changed once the first row is written.
+	exportWriter, err := helper.NewCustomLinkExportWriter(request.Format, c.Writer, request.WithAnalytic)
+	controller.Logger.PanicIfErr(err, ErrCustomLinkController)
+
+	errService := controller.Service.ExportLink(ctx, request, domainName, jwtToken, func(row web.CustomLinkExportResponse) error {
+		if err := exportWriter.Write(row); err != nil {
+			return err
+		}
+		c.Writer.Flush()
+		return nil
+	})
+	if errService != nil {
+		controller.Logger.Error().Err(errService).Msg(ErrCustomLinkController)
+		return
+	}
+
+	err = exportWriter.Close()
+	if err != nil {
+		controller.Logger.Error().Err(err).Msg(ErrCustomLinkController)
+	}
+}
+
+func (controller *CustomLinkControllerImpl) DeleteLink(c *gin.Context) {
+	ctx := context.Background()
+	jwtToken := helper.ExtractTokenFromRequestHeader(c)
+	var request web.CustomLinkDeleteRequest
+
+	err := c.ShouldBindUri(&request)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, helper.ToWebResponseFailed(err))
+		return
+	}
+
+	errService := controller.Service.DeleteLink(ctx, request, jwtToken)
+	if errService != nil {
+		webResponse := web.WebResponseFailed{
+			Status:  "failed",
+			Message: errService.Error(),
+		}
+		c.JSON(http.StatusBadRequest, webResponse)
+	} else {
+		webResponse := web.WebResponseSuccess{
+			Status:  "success",
+			Message: "success move link to trash",
+		}
+		c.JSON(http.StatusOK, webResponse)
+	}
+}
+
+func (controller *CustomLinkControllerImpl) GetAllDeletedLink(c *gin.Context) {
+	ctx := context.Background()
+	domainName := c.Request.Host
+	jwtToken := helper.ExtractTokenFromRequestHeader(c)
+
+	customLinksResponse, errService := controller.Service.GetAllDeletedLink(ctx, domainName, jwtToken)
+	if errService != nil {
+		webResponse := web.WebResponseFailed{
+			Status:  "failed",
+			Message: errService.Error(),
+		}
+		c.JSON(http.StatusBadRequest, webResponse)
+	} else {
+		webResponse := web.WebResponseSuccess{
+			Status:  "success",
+			Message: "success get all deleted custom link",
+			Data:    customLinksResponse,
+		}
+		c.JSON(http.StatusOK, webResponse)
+	}
+}
+
+func (controller *CustomLinkControllerImpl) RestoreLink(c *gin.Context) {
+	ctx := context.Background()
+	domainName := c.Request.Host
+	jwtToken := helper.ExtractTokenFromRequestHeader(c)
+	var request web.CustomLinkRestoreRequest
+
+	err := c.ShouldBindUri(&request)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, helper.ToWebResponseFailed(err))
+		return
+	}
+
+	customLinkResponse, errService := controller.Service.RestoreLink(ctx, request, domainName, jwtToken)
+	if errService != nil {
+		webResponse := web.WebResponseFailed{
+			Status:  "failed",
+			Message: errService.Error(),
+		}
+		c.JSON(http.StatusBadRequest, webResponse)
+	} else {
+		webResponse := web.WebResponseSuccess{
+			Status:  "success",
+			Message: "success restore link",
+			Data:    customLinkResponse,
+		}
+		c.JSON(http.StatusOK, webResponse)
+	}
+}
+
+func (controller *CustomLinkControllerImpl) GetLinkHistory(c *gin.Context) {
+	ctx := context.Background()
+	jwtToken := helper.ExtractTokenFromRequestHeader(c)
+	var request web.CustomLinkHistoryRequest
+
+	err := c.ShouldBindUri(&request)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, helper.ToWebResponseFailed(err))
+		return
+	}
+
+	revisionsResponse, errService := controller.Service.GetLinkHistory(ctx, request, jwtToken)
+	if errService != nil {
+		webResponse := web.WebResponseFailed{
+			Status:  "failed",
+			Message: errService.Error(),
+		}
+		c.JSON(http.StatusBadRequest, webResponse)
+	} else {
+		webResponse := web.WebResponseSuccess{
+			Status:  "success",
+			Message: "success get link history",
+			Data:    revisionsResponse,
+		}
+		c.JSON(http.StatusOK, webResponse)
+	}
+}
+
+func (controller *CustomLinkControllerImpl) RevertLink(c *gin.Context) {
+	ctx := context.Background()
+	domainName := c.Request.Host
+	jwtToken := helper.ExtractTokenFromRequestHeader(c)
+	var request web.CustomLinkRevertRequest
+
+	err := c.ShouldBindUri(&request)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, helper.ToWebResponseFailed(err))
+		return
+	}
+
+	customLinkResponse, errService := controller.Service.RevertLink(ctx, request, domainName, jwtToken)
+	if errService != nil {
+		webResponse := web.WebResponseFailed{
+			Status:  "failed",
+			Message: errService.Error(),
+		}
+		c.JSON(http.StatusBadRequest, webResponse)
+	} else {
+		webResponse := web.WebResponseSuccess{
+			Status:  "success",
+			Message: "success revert link",
+			Data:    customLinkResponse,
+		}
+		c.JSON(http.StatusOK, webResponse)
+	}
+}
+
+func (controller *CustomLinkControllerImpl) CloneLink(c *gin.Context) {
+	ctx := context.Background()
+	domainName := c.Request.Host
+	jwtToken := helper.ExtractTokenFromRequestHeader(c)
+	var request web.CustomLinkCloneRequest
+
+	err := c.ShouldBindUri(&request)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, helper.ToWebResponseFailed(err))
+		return
+	}
+
+	// The body is optional, without one the clone keeps the title and gets a generated code.
+	err = c.ShouldBindJSON(&request)
+	if err != nil && !errors.Is(err, io.EOF) {
+		c.AbortWithStatusJSON(http.StatusBadRequest, helper.ToWebResponseFailed(err))
+		return
+	}
+
+	customLinkResponse, errService := controller.Service.CloneLink(ctx, request, domainName, jwtToken)
+	if errService != nil {
+		webResponse := web.WebResponseFailed{
+			Status:  "failed",
+			Message: errService.Error(),
+		}
+		c.JSON(http.StatusBadRequest, webResponse)
+	} else {
+		webResponse := web.WebResponseSuccess{
+			Status:  "success",
+			Message: "success clone link",
+			Data:    customLinkResponse,
+		}
+		c.JSON(http.StatusCreated, webResponse)
+	}
+}
+
+func (controller *CustomLinkControllerImpl) GetAllThumbnail(c *gin.Context) {
+	ctx := context.Background()
+	thumbnailsResponse, errService := controller.Service.GetAllThumbnail(ctx)
+
+	if errService != nil {
+		webResponse := web.WebResponseFailed{
+			Status:  "failed",
+			Message: errService.Error(),
+		}
+		c.JSON(http.StatusBadRequest, webResponse)
+	} else {
+		webResponse := web.WebResponseSuccess{
+			Status:  "success",
+			Message: "success get all default thumbnail",
+			Data:    thumbnailsResponse,
+		}
+		c.JSON(http.StatusOK, webResponse)
+	}
+}
+
+func (controller *CustomLinkControllerImpl) GetUserThumbnail(c *gin.Context) {
+	ctx := context.Background()
+	domainName := c.Request.Host
+	jwtToken := helper.ExtractTokenFromRequestHeader(c)
+
+	thumbnailsResponse, errService := controller.Service.GetUserThumbnail(ctx, domainName, jwtToken)
+	if errService != nil {
+		webResponse := web.WebResponseFailed{
+			Status:  "failed",
+			Message: errService.Error(),
+		}
+		c.JSON(http.StatusBadRequest, webResponse)
+	} else {
+		webResponse := web.WebResponseSuccess{
+			Status:  "success",
+			Message: "success get all user custom thumbnail",
+			Data:    thumbnailsResponse,
+		}
+		c.JSON(http.StatusOK, webResponse)
+	}
+
+}
+
+func (controller *CustomLinkControllerImpl) UploadCustomThumbnail(c *gin.Context) {
+	// TODO : Implement formfile validation
+	// TODO : Fix bug, cant upload webp or png
+
+	ctx := context.Background()
+	file, _, err := c.Request.FormFile("image_data")
+	domainName := c.Request.Host
+
+	controller.Logger.PanicIfErr(err, ErrCustomLinkController)
+	defer file.Close()
+
+	jwtToken := helper.ExtractTokenFromRequestHeader(c)
+
+	buf := bytes.NewBuffer(nil)
+	_, err = io.Copy(buf, file)
+	controller.Logger.PanicIfErr(err, ErrCustomLinkController)
+
+	thumbnailResponse, errService := controller.Service.UploadCustomThumbnail(ctx, buf.Bytes(), domainName, jwtToken)
+
+	if errService != nil {
+		webResponse := web.WebResponseFailed{
+			Status:  "failed",
+			Message: errService.Error(),
+		}
+		c.JSON(http.StatusBadRequest, webResponse)
+	} else {
+		webResponse := web.WebResponseSuccess{
+			Status:  "success",
+			Message: "success upload custom thumbnail",
+			Data:    thumbnailResponse,
+		}
+		c.JSON(http.StatusCreated, webResponse)
+	}
+}
+
+func (controller *CustomLinkControllerImpl) CreateUtmTemplate(c *gin.Context) {
+	ctx := context.Background()
+	jwtToken := helper.ExtractTokenFromRequestHeader(c)
+	var request web.UtmTemplateCreateRequest
+
+	err := c.ShouldBindJSON(&request)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, helper.ToWebResponseFailed(err))
+		return
+	}
+
+	utmTemplateResponse, errService := controller.Service.CreateUtmTemplate(ctx, request, jwtToken)
+	if errService != nil {
+		webResponse := web.WebResponseFailed{
+			Status:  "failed",
+			Message: errService.Error(),
+		}
+		c.JSON(http.StatusBadRequest, webResponse)
+	} else {
+		webResponse := web.WebResponseSuccess{
+			Status:  "success",
+			Message: "success create utm template",
+			Data:    utmTemplateResponse,
+		}
+		c.JSON(http.StatusCreated, webResponse)
+	}
+}
+
+func (controller *CustomLinkControllerImpl) GetAllUtmTemplate(c *gin.Context) {
+	ctx := context.Background()
+	jwtToken := helper.ExtractTokenFromRequestHeader(c)
+
+	utmTemplatesResponse, errService := controller.Service.GetAllUtmTemplate(ctx, jwtToken)
+	if errService != nil {
+		webResponse := web.WebResponseFailed{
+			Status:  "failed",
+			Message: errService.Error(),
+		}
+		c.JSON(http.StatusBadRequest, webResponse)
+	} else {
+		webResponse := web.WebResponseSuccess{
+			Status:  "success",
+			Message: "success get all utm template",
+			Data:    utmTemplatesResponse,
+		}
+		c.JSON(http.StatusOK, webResponse)
+	}
+}
+
+func (controller *CustomLinkControllerImpl) UpdateUtmTemplate(c *gin.Context) {
+	ctx := context.Background()
+	jwtToken := helper.ExtractTokenFromRequestHeader(c)
+	var request web.UtmTemplateUpdateRequest
+
+	err := c.ShouldBindUri(&request)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, helper.ToWebResponseFailed(err))
+		return
+	}
+
+	err = c.ShouldBindJSON(&request)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, helper.ToWebResponseFailed(err))
+		return
+	}
+
+	utmTemplateResponse, errService := controller.Service.UpdateUtmTemplate(ctx, request, jwtToken)
+	if errService != nil {
+		webResponse := web.WebResponseFailed{
+			Status:  "failed",
+			Message: errService.Error(),
+		}
+		c.JSON(http.StatusBadRequest, webResponse)
+	} else {
+		webResponse := web.WebResponseSuccess{
+			Status:  "success",
+			Message: "success update utm template",
+			Data:    utmTemplateResponse,
+		}
+		c.JSON(http.StatusOK, webResponse)
+	}
+}
+
+func (controller *CustomLinkControllerImpl) DeleteUtmTemplate(c *gin.Context) {
+	ctx := context.Background()
+	jwtToken := helper.ExtractTokenFromRequestHeader(c)
+	var request web.UtmTemplateDeleteRequest
+
+	err := c.ShouldBindUri(&request)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, helper.ToWebResponseFailed(err))
+		return
+	}
+
+	errService := controller.Service.DeleteUtmTemplate(ctx, request, jwtToken)
+	if errService != nil {
+		webResponse := web.WebResponseFailed{
+			Status:  "failed",
+			Message: errService.Error(),
+		}
+		c.JSON(http.StatusBadRequest, webResponse)
+	} else {
+		webResponse := web.WebResponseSuccess{
+			Status:  "success",
+			Message: "success delete utm template",
+		}
+		c.JSON(http.StatusOK, webResponse)
+	}
+}
+
+func (controller *CustomLinkControllerImpl) GetLinkQRCode(c *gin.Context) {
+	ctx := context.Background()
+	baseUrl := helper.GetBaseUrl(c)
+	jwtToken := helper.ExtractTokenFromRequestHeader(c)
+	var request web.CustomLinkQRCodeRequest
+
+	err := c.ShouldBindUri(&request)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, helper.ToWebResponseFailed(err))
+		return
+	}
+
+	err = c.ShouldBindQuery(&request)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, helper.ToWebResponseFailed(err))
+		return
+	}
+
+	qrCodeResponse, errService := controller.Service.GetLinkQRCode(ctx, request, baseUrl, jwtToken)
+	if errService != nil {
+		webResponse := web.WebResponseFailed{
+			Status:  "failed",
+			Message: errService.Error(),
+		}
+		c.JSON(http.StatusBadRequest, webResponse)
+	} else {
+		c.Data(http.StatusOK, qrCodeResponse.ContentType, qrCodeResponse.Data)
+	}
+}
+
+func (controller *CustomLinkControllerImpl) ReorderLink(c *gin.Context) {
+	ctx := context.Background()
+	jwtToken := helper.ExtractTokenFromRequestHeader(c)
+	var request web.CustomLinkReorderRequest
+
+	err := c.ShouldBindJSON(&request)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, helper.ToWebResponseFailed(err))
+		return
+	}
+
+	positionsResponse, errService := controller.Service.ReorderLink(ctx, request, jwtToken)
+	if errService != nil {
+		webResponse := web.WebResponseFailed{
+			Status:  "failed",
+			Message: errService.Error(),
+		}
+		c.JSON(http.StatusBadRequest, webResponse)
+	} else {
+		webResponse := web.WebResponseSuccess{
+			Status:  "success",
+			Message: "success reorder custom link",
+			Data:    positionsResponse,
+		}
+		c.JSON(http.StatusOK, webResponse)
+	}
+}
+
+func (controller *CustomLinkControllerImpl) BatchLink(c *gin.Context) {
+	ctx := context.Background()
+	jwtToken := helper.ExtractTokenFromRequestHeader(c)
+	var request web.CustomLinkBatchRequest
+
+	err := c.ShouldBindJSON(&request)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, helper.ToWebResponseFailed(err))
+		return
+	}
+
+	batchResponse, errService := controller.Service.BatchLink(ctx, request, jwtToken)
+	if errService != nil {
+		webResponse := web.WebResponseFailed{
+			Status:  "failed",
+			Message: errService.Error(),
+		}
+		c.JSON(http.StatusBadRequest, webResponse)
+	} else {
+		webResponse := web.WebResponseSuccess{
+			Status:  "success",
+			Message: "success batch custom link",
+			Data:    batchResponse,
+		}
+		c.JSON(http.StatusOK, webResponse)
+	}
+}
+
+func (controller *CustomLinkControllerImpl) CreateTag(c *gin.Context) {
 	ctx := context.Background()
-	domainName := c.Request.Host
 	jwtToken := helper.ExtractTokenFromRequestHeader(c)
-	var request web.CustomLinkCreateRequest
+	var request web.TagCreateRequest
 
 	err := c.ShouldBindJSON(&request)
 	if err != nil {
@@ -48,8 +742,7 @@ func (controller *CustomLinkControllerImpl) CreateLink(c *gin.Context) {
 		return
 	}
 
-	customLinkResponse, errService := controller.Service.CreateLink(ctx, request, domainName, jwtToken)
-
+	tagResponse, errService := controller.Service.CreateTag(ctx, request, jwtToken)
 	if errService != nil {
 		webResponse := web.WebResponseFailed{
 			Status:  "failed",
@@ -59,19 +752,38 @@ func (controller *CustomLinkControllerImpl) CreateLink(c *gin.Context) {
 	} else {
 		webResponse := web.WebResponseSuccess{
 			Status:  "success",
-			Message: "success create new link",
-			Data:    customLinkResponse,
+			Message: "success create tag",
+			Data:    tagResponse,
 		}
 		c.JSON(http.StatusCreated, webResponse)
 	}
 }
 
-func (controller *CustomLinkControllerImpl) UpdateLink(c *gin.Context) {
+func (controller *CustomLinkControllerImpl) GetAllTag(c *gin.Context) {
 	ctx := context.Background()
-	domainName := c.Request.Host
 	jwtToken := helper.ExtractTokenFromRequestHeader(c)
 
-	var request web.CustomLinkUpdateRequest
+	tagsResponse, errService := controller.Service.GetAllTag(ctx, jwtToken)
+	if errService != nil {
+		webResponse := web.WebResponseFailed{
+			Status:  "failed",
+			Message: errService.Error(),
+		}
+		c.JSON(http.StatusBadRequest, webResponse)
+	} else {
+		webResponse := web.WebResponseSuccess{
+			Status:  "success",
+			Message: "success get all tag",
+			Data:    tagsResponse,
+		}
+		c.JSON(http.StatusOK, webResponse)
+	}
+}
+
+func (controller *CustomLinkControllerImpl) UpdateTag(c *gin.Context) {
+	ctx := context.Background()
+	jwtToken := helper.ExtractTokenFromRequestHeader(c)
+	var request web.TagUpdateRequest
 
 	err := c.ShouldBindUri(&request)
 	if err != nil {
@@ -85,7 +797,7 @@ func (controller *CustomLinkControllerImpl) UpdateLink(c *gin.Context) {
 		return
 	}
 
-	customLinkResponse, errService := controller.Service.UpdateLink(ctx, request, domainName, jwtToken)
+	tagResponse, errService := controller.Service.UpdateTag(ctx, request, jwtToken)
 	if errService != nil {
 		webResponse := web.WebResponseFailed{
 			Status:  "failed",
@@ -95,19 +807,17 @@ func (controller *CustomLinkControllerImpl) UpdateLink(c *gin.Context) {
 	} else {
 		webResponse := web.WebResponseSuccess{
 			Status:  "success",
-			Message: "success update link",
-			Data:    customLinkResponse,
+			Message: "success update tag",
+			Data:    tagResponse,
 		}
-		c.JSON(http.StatusCreated, webResponse)
+		c.JSON(http.StatusOK, webResponse)
 	}
-
 }
 
-func (controller *CustomLinkControllerImpl) GetLink(c *gin.Context) {
+func (controller *CustomLinkControllerImpl) DeleteTag(c *gin.Context) {
 	ctx := context.Background()
-	domainName := c.Request.Host
 	jwtToken := helper.ExtractTokenFromRequestHeader(c)
-	var request web.CustomLinkGetRequest
+	var request web.TagDeleteRequest
 
 	err := c.ShouldBindUri(&request)
 	if err != nil {
@@ -115,7 +825,7 @@ func (controller *CustomLinkControllerImpl) GetLink(c *gin.Context) {
 		return
 	}
 
-	customLinkResponse, errService := controller.Service.GetLink(ctx, request, domainName, jwtToken)
+	errService := controller.Service.DeleteTag(ctx, request, jwtToken)
 	if errService != nil {
 		webResponse := web.WebResponseFailed{
 			Status:  "failed",
@@ -125,19 +835,24 @@ func (controller *CustomLinkControllerImpl) GetLink(c *gin.Context) {
 	} else {
 		webResponse := web.WebResponseSuccess{
 			Status:  "success",
-			Message: "success get link",
-			Data:    customLinkResponse,
+			Message: "success delete tag",
 		}
 		c.JSON(http.StatusOK, webResponse)
 	}
 }
 
-func (controller *CustomLinkControllerImpl) GetAllLink(c *gin.Context) {
+func (controller *CustomLinkControllerImpl) CreateFolder(c *gin.Context) {
 	ctx := context.Background()
-	domainName := c.Request.Host
 	jwtToken := helper.ExtractTokenFromRequestHeader(c)
+	var request web.FolderCreateRequest
 
-	customLinksResponse, errService := controller.Service.GetAllLink(ctx, domainName, jwtToken)
+	err := c.ShouldBindJSON(&request)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, helper.ToWebResponseFailed(err))
+		return
+	}
+
+	folderResponse, errService := controller.Service.CreateFolder(ctx, request, jwtToken)
 	if errService != nil {
 		webResponse := web.WebResponseFailed{
 			Status:  "failed",
@@ -147,18 +862,18 @@ func (controller *CustomLinkControllerImpl) GetAllLink(c *gin.Context) {
 	} else {
 		webResponse := web.WebResponseSuccess{
 			Status:  "success",
-			Message: "success get all custom link",
-			Data:    customLinksResponse,
+			Message: "success create folder",
+			Data:    folderResponse,
 		}
-		c.JSON(http.StatusOK, webResponse)
+		c.JSON(http.StatusCreated, webResponse)
 	}
-
 }
 
-func (controller *CustomLinkControllerImpl) GetAllThumbnail(c *gin.Context) {
+func (controller *CustomLinkControllerImpl) GetAllFolder(c *gin.Context) {
 	ctx := context.Background()
-	thumbnailsResponse, errService := controller.Service.GetAllThumbnail(ctx)
+	jwtToken := helper.ExtractTokenFromRequestHeader(c)
 
+	foldersResponse, errService := controller.Service.GetAllFolder(ctx, jwtToken)
 	if errService != nil {
 		webResponse := web.WebResponseFailed{
 			Status:  "failed",
@@ -168,19 +883,31 @@ func (controller *CustomLinkControllerImpl) GetAllThumbnail(c *gin.Context) {
 	} else {
 		webResponse := web.WebResponseSuccess{
 			Status:  "success",
-			Message: "success get all default thumbnail",
-			Data:    thumbnailsResponse,
+			Message: "success get all folder",
+			Data:    foldersResponse,
 		}
 		c.JSON(http.StatusOK, webResponse)
 	}
 }
 
-func (controller *CustomLinkControllerImpl) GetUserThumbnail(c *gin.Context) {
+func (controller *CustomLinkControllerImpl) UpdateFolder(c *gin.Context) {
 	ctx := context.Background()
-	domainName := c.Request.Host
 	jwtToken := helper.ExtractTokenFromRequestHeader(c)
+	var request web.FolderUpdateRequest
 
-	thumbnailsResponse, errService := controller.Service.GetUserThumbnail(ctx, domainName, jwtToken)
+	err := c.ShouldBindUri(&request)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, helper.ToWebResponseFailed(err))
+		return
+	}
+
+	err = c.ShouldBindJSON(&request)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, helper.ToWebResponseFailed(err))
+		return
+	}
+
+	folderResponse, errService := controller.Service.UpdateFolder(ctx, request, jwtToken)
 	if errService != nil {
 		webResponse := web.WebResponseFailed{
 			Status:  "failed",
@@ -190,33 +917,129 @@ func (controller *CustomLinkControllerImpl) GetUserThumbnail(c *gin.Context) {
 	} else {
 		webResponse := web.WebResponseSuccess{
 			Status:  "success",
-			Message: "success get all user custom thumbnail",
-			Data:    thumbnailsResponse,
+			Message: "success update folder",
+			Data:    folderResponse,
 		}
 		c.JSON(http.StatusOK, webResponse)
 	}
+}
+
+func (controller *CustomLinkControllerImpl) DeleteFolder(c *gin.Context) {
+	ctx := context.Background()
+	jwtToken := helper.ExtractTokenFromRequestHeader(c)
+	var request web.FolderDeleteRequest
+
+	err := c.ShouldBindUri(&request)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, helper.ToWebResponseFailed(err))
+		return
+	}
 
+	errService := controller.Service.DeleteFolder(ctx, request, jwtToken)
+	if errService != nil {
+		webResponse := web.WebResponseFailed{
+			Status:  "failed",
+			Message: errService.Error(),
+		}
+		c.JSON(http.StatusBadRequest, webResponse)
+	} else {
+		webResponse := web.WebResponseSuccess{
+			Status:  "success",
+			Message: "success delete folder",
+		}
+		c.JSON(http.StatusOK, webResponse)
+	}
 }
 
-func (controller *CustomLinkControllerImpl) UploadCustomThumbnail(c *gin.Context) {
-	// TODO : Implement formfile validation
-	// TODO : Fix bug, cant upload webp or png
+func (controller *CustomLinkControllerImpl) CreateDomain(c *gin.Context) {
+	ctx := context.Background()
+	jwtToken := helper.ExtractTokenFromRequestHeader(c)
+	var request web.CustomDomainCreateRequest
+
+	err := c.ShouldBindJSON(&request)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, helper.ToWebResponseFailed(err))
+		return
+	}
+
+	customDomainResponse, errService := controller.Service.CreateDomain(ctx, request, jwtToken)
+	if errService != nil {
+		webResponse := web.WebResponseFailed{
+			Status:  "failed",
+			Message: errService.Error(),
+		}
+		c.JSON(http.StatusBadRequest, webResponse)
+	} else {
+		webResponse := web.WebResponseSuccess{
+			Status:  "success",
+			Message: "success create domain",
+			Data:    customDomainResponse,
+		}
+		c.JSON(http.StatusCreated, webResponse)
+	}
+}
 
+func (controller *CustomLinkControllerImpl) GetAllDomain(c *gin.Context) {
 	ctx := context.Background()
-	file, _, err := c.Request.FormFile("image_data")
-	domainName := c.Request.Host
+	jwtToken := helper.ExtractTokenFromRequestHeader(c)
 
-	controller.Logger.PanicIfErr(err, ErrCustomLinkController)
-	defer file.Close()
+	customDomainsResponse, errService := controller.Service.GetAllDomain(ctx, jwtToken)
+	if errService != nil {
+		webResponse := web.WebResponseFailed{
+			Status:  "failed",
+			Message: errService.Error(),
+		}
+		c.JSON(http.StatusBadRequest, webResponse)
+	} else {
+		webResponse := web.WebResponseSuccess{
+			Status:  "success",
+			Message: "success get all domain",
+			Data:    customDomainsResponse,
+		}
+		c.JSON(http.StatusOK, webResponse)
+	}
+}
 
+func (controller *CustomLinkControllerImpl) VerifyDomain(c *gin.Context) {
+	ctx := context.Background()
 	jwtToken := helper.ExtractTokenFromRequestHeader(c)
+	var request web.CustomDomainVerifyRequest
 
-	buf := bytes.NewBuffer(nil)
-	_, err = io.Copy(buf, file)
-	controller.Logger.PanicIfErr(err, ErrCustomLinkController)
+	err := c.ShouldBindUri(&request)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, helper.ToWebResponseFailed(err))
+		return
+	}
 
-	thumbnailResponse, errService := controller.Service.UploadCustomThumbnail(ctx, buf.Bytes(), domainName, jwtToken)
+	customDomainResponse, errService := controller.Service.VerifyDomain(ctx, request, jwtToken)
+	if errService != nil {
+		webResponse := web.WebResponseFailed{
+			Status:  "failed",
+			Message: errService.Error(),
+		}
+		c.JSON(http.StatusBadRequest, webResponse)
+	} else {
+		webResponse := web.WebResponseSuccess{
+			Status:  "success",
+			Message: "success verify domain",
+			Data:    customDomainResponse,
+		}
+		c.JSON(http.StatusOK, webResponse)
+	}
+}
 
+func (controller *CustomLinkControllerImpl) DeleteDomain(c *gin.Context) {
+	ctx := context.Background()
+	jwtToken := helper.ExtractTokenFromRequestHeader(c)
+	var request web.CustomDomainDeleteRequest
+
+	err := c.ShouldBindUri(&request)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, helper.ToWebResponseFailed(err))
+		return
+	}
+
+	errService := controller.Service.DeleteDomain(ctx, request, jwtToken)
 	if errService != nil {
 		webResponse := web.WebResponseFailed{
 			Status:  "failed",
@@ -226,15 +1049,15 @@ func (controller *CustomLinkControllerImpl) UploadCustomThumbnail(c *gin.Context
 	} else {
 		webResponse := web.WebResponseSuccess{
 			Status:  "success",
-			Message: "success upload custom thumbnail",
-			Data:    thumbnailResponse,
+			Message: "success delete domain",
 		}
-		c.JSON(http.StatusCreated, webResponse)
+		c.JSON(http.StatusOK, webResponse)
 	}
 }
 
 func (controller *CustomLinkControllerImpl) CheckShortLinkAvaibility(c *gin.Context) {
 	ctx := context.Background()
+	jwtToken := helper.ExtractTokenFromRequestHeader(c)
 	var request web.CustomLinkCheckShortCodeAvaibilityRequest
 
 	err := c.ShouldBindQuery(&request)
@@ -243,22 +1066,27 @@ func (controller *CustomLinkControllerImpl) CheckShortLinkAvaibility(c *gin.Cont
 		return
 	}
 
-	errService := controller.Service.CheckShortLinkAvaibility(ctx, request)
+	avaibilityResponse, errService := controller.Service.CheckShortLinkAvaibility(ctx, request, jwtToken)
 	if errService != nil {
 		webResponse := web.WebResponseFailed{
 			Status:  "failed",
 			Message: errService.Error(),
+			Data:    avaibilityResponse,
 		}
 		c.JSON(http.StatusBadRequest, webResponse)
 	} else {
 		webResponse := web.WebResponseSuccess{
 			Status:  "success",
 			Message: "short link code can be used",
+			Data:    avaibilityResponse,
 		}
 		c.JSON(http.StatusOK, webResponse)
 	}
 
 }
+
+// RedirectLink sends the visitor to the destination, a visit carrying the qr source marker is counted as a scan
+// of the qr code of the link.
 func (controller *CustomLinkControllerImpl) RedirectLink(c *gin.Context) {
 	ctx := context.Background()
 	var request web.CustomLinkRedirectRequest
@@ -269,15 +1097,58 @@ func (controller *CustomLinkControllerImpl) RedirectLink(c *gin.Context) {
 		c.AbortWithStatusJSON(http.StatusBadRequest, helper.ToWebResponseFailed(err))
 		return
 	}
+	request.ClientIP = c.ClientIP()
+	request.UserAgent = c.Request.Header.Get("User-Agent")
+	request.AcceptLanguage = c.Request.Header.Get("Accept-Language")
+	request.VariantID = getVariantCookie(c, request.ShortLinkCode)
+	request.Host = c.Request.Host
+
+	source := ""
+	query, isScan := helper.ParseSourceQuery(c.Request.URL.Query(), service.InteractionSourceQR)
+	if isScan {
+		source = service.InteractionSourceQR
+	}
+	request.Query = query
+	request.Bot = uaparser.Parse(request.UserAgent).Bot
+
+	if shortLinkCode, isPreview := helper.ParsePreviewCode(request.ShortLinkCode); isPreview {
+		request.ShortLinkCode = shortLinkCode
+		controller.previewLink(c, request)
+		return
+	}
+
+	// It's serving the open graph preview to crawlers, links without a preview are redirected without saving an
+	// interaction. A link with a click limit always has a preview so crawlers can't get around the limit.
+	if request.Bot {
+		previewResponse, errPreview := controller.Service.GetLinkPreview(ctx, request, helper.GetBaseUrl(c))
+		if errPreview == nil {
+			c.HTML(http.StatusOK, "link_preview.html", previewResponse)
+			return
+		}
+	}
+
+	redirectResponse, errService := controller.Service.RedirectLink(ctx, request)
+	if errors.Is(errService, service.ErrCustomLinkLocked) {
+		c.HTML(http.StatusOK, "link_password.html", gin.H{
+			"ShortLinkCode": request.ShortLinkCode,
+			"Source":        source,
+			"Query":         template.URL(request.Query.Encode()),
+		})
+		return
+	}
 
-	longLink, linkID, errService := controller.Service.RedirectLink(ctx, request)
 	if errService == nil {
-		requstSaveInteraction := web.CustomLinkAnalyticInteractionRequest{
-			ClientIP:     c.ClientIP(),
-			UserAgent:    c.Request.Header.Get("User-Agent"),
-			CustomLinkID: linkID,
+		if !request.Bot {
+			requstSaveInteraction := web.CustomLinkAnalyticInteractionRequest{
+				ClientIP:     c.ClientIP(),
+				UserAgent:    c.Request.Header.Get("User-Agent"),
+				CustomLinkID: redirectResponse.LinkID,
+				VariantID:    redirectResponse.VariantID,
+				Source:       source,
+			}
+			_ = controller.AnalyticService.SaveInteraction(ctx, requstSaveInteraction)
 		}
-		_ = controller.AnalyticService.SaveInteraction(ctx, requstSaveInteraction)
+		setVariantCookie(c, request.ShortLinkCode, redirectResponse.VariantID)
 	}
 
 	if errService != nil {
@@ -285,11 +1156,120 @@ func (controller *CustomLinkControllerImpl) RedirectLink(c *gin.Context) {
 			Status:  "failed",
 			Message: errService.Error(),
 		}
-		c.JSON(http.StatusBadRequest, webResponse)
+		c.JSON(redirectErrorStatusCode(errService), webResponse)
 	} else {
-		c.Redirect(http.StatusFound, longLink)
+		renderRedirect(c, redirectResponse, redirectResponse.StatusCode)
+	}
+
+}
+
+// previewLink shows the page of the link with its destination instead of redirecting, the visit isn't counted as a click.
+// The destination of a password protected link stays hidden.
+func (controller *CustomLinkControllerImpl) previewLink(c *gin.Context, request web.CustomLinkRedirectRequest) {
+	ctx := context.Background()
+	request.Preview = true
+	request.BaseUrl = helper.GetBaseUrl(c)
+
+	redirectResponse, errService := controller.Service.RedirectLink(ctx, request)
+	isLocked := errors.Is(errService, service.ErrCustomLinkLocked)
+	if errService != nil && !isLocked {
+		webResponse := web.WebResponseFailed{
+			Status:  "failed",
+			Message: errService.Error(),
+		}
+		c.JSON(redirectErrorStatusCode(errService), webResponse)
+		return
+	}
+
+	c.HTML(http.StatusOK, "link_details.html", gin.H{
+		"ShortLinkCode": request.ShortLinkCode,
+		"Title":         redirectResponse.Title,
+		"ThumbnailUrl":  redirectResponse.ThumbnailUrl,
+		"Destination":   redirectResponse.Destination,
+		"CreatedAt":     redirectResponse.CreatedAt,
+		"Locked":        isLocked,
+	})
+}
+
+func (controller *CustomLinkControllerImpl) UnlockLink(c *gin.Context) {
+	ctx := context.Background()
+	var request web.CustomLinkRedirectRequest
+
+	err := c.ShouldBindUri(&request)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, helper.ToWebResponseFailed(err))
+		return
+	}
+	request.Password = c.PostForm("password")
+	source := ""
+	query, isScan := helper.ParseSourceQuery(c.Request.URL.Query(), service.InteractionSourceQR)
+	if isScan || c.PostForm(helper.SourceQueryKey) == service.InteractionSourceQR {
+		source = service.InteractionSourceQR
+	}
+	request.Query = query
+	request.ClientIP = c.ClientIP()
+	request.UserAgent = c.Request.Header.Get("User-Agent")
+	request.AcceptLanguage = c.Request.Header.Get("Accept-Language")
+	request.VariantID = getVariantCookie(c, request.ShortLinkCode)
+	request.Host = c.Request.Host
+	request.Bot = uaparser.Parse(request.UserAgent).Bot
+
+	// It's rate limiting password attempts per client and per link, the attempt is counted before the password is
+	// checked so concurrent attempts can't all pass the limit. The link limit stops a guess spread over many clients.
+	// Failed attempts are not counted as clicks.
+	attemptKey := helper.GenerateCacheKeyUnlockAttempt(request.Host, request.ShortLinkCode, c.ClientIP())
+	linkAttemptKey := helper.GenerateCacheKeyUnlockAttemptLink(request.Host, request.ShortLinkCode)
+	attemptCounts, err := countUnlockAttemptScript.Run(ctx, controller.Redis, []string{attemptKey, linkAttemptKey}, IntervalCustomLinkUnlockAttempt.Milliseconds()).Int64Slice()
+	controller.Logger.PanicIfErr(err, "[Custom Link Controller][Error Redis]")
+
+	if attemptCounts[0] > int64(MaxCustomLinkUnlockAttempt) || attemptCounts[1] > int64(MaxCustomLinkUnlockAttemptPerLink) {
+		c.HTML(http.StatusTooManyRequests, "link_password.html", gin.H{
+			"ShortLinkCode": request.ShortLinkCode,
+			"Source":        source,
+			"Query":         template.URL(request.Query.Encode()),
+			"Message":       "too many failed attempts, please try again later",
+		})
+		return
+	}
+
+	redirectResponse, errService := controller.Service.RedirectLink(ctx, request)
+	if errors.Is(errService, service.ErrCustomLinkLocked) || errors.Is(errService, service.ErrCustomLinkUnlockFailed) {
+		c.HTML(http.StatusUnauthorized, "link_password.html", gin.H{
+			"ShortLinkCode": request.ShortLinkCode,
+			"Source":        source,
+			"Query":         template.URL(request.Query.Encode()),
+			"Message":       errService.Error(),
+		})
+		return
+	}
+
+	if errService != nil {
+		webResponse := web.WebResponseFailed{
+			Status:  "failed",
+			Message: errService.Error(),
+		}
+		c.JSON(redirectErrorStatusCode(errService), webResponse)
+		return
+	}
+
+	// The client knows the password, its attempts start over.
+	cmdDel := controller.Redis.Del(ctx, attemptKey)
+	controller.Logger.PanicIfErr(cmdDel.Err(), "[Custom Link Controller][Error Redis]")
+
+	if !request.Bot {
+		requstSaveInteraction := web.CustomLinkAnalyticInteractionRequest{
+			ClientIP:     c.ClientIP(),
+			UserAgent:    c.Request.Header.Get("User-Agent"),
+			CustomLinkID: redirectResponse.LinkID,
+			VariantID:    redirectResponse.VariantID,
+			Source:       source,
+		}
+		_ = controller.AnalyticService.SaveInteraction(ctx, requstSaveInteraction)
 	}
+	setVariantCookie(c, request.ShortLinkCode, redirectResponse.VariantID)
 
+	// It's always 303 See Other, a 307 or 308 would send the password form to the destination.
+	renderRedirect(c, redirectResponse, http.StatusSeeOther)
 }
 
 func (controller *CustomLinkControllerImpl) GetLinkAnalytic(c *gin.Context) {
@@ -374,3 +1354,54 @@ func (controller *CustomLinkControllerImpl) GetSummaryLinkAnalytic(c *gin.Contex
 		c.JSON(http.StatusOK, webResponse)
 	}
 }
+
+// renderRedirect sends the visitor to the destination, a link with an interstitial shows the warning page instead
+// and the visitor continues from there.
+func renderRedirect(c *gin.Context, redirectResponse web.CustomLinkRedirectResponse, statusCode int) {
+	if redirectResponse.Interstitial {
+		c.HTML(http.StatusOK, "link_interstitial.html", gin.H{
+			"Title":           redirectResponse.Title,
+			"Destination":     redirectResponse.Destination,
+			"DestinationHost": helper.DestinationHost(redirectResponse.Destination),
+		})
+		return
+	}
+	c.Redirect(statusCode, redirectResponse.Destination)
+}
+
+func redirectErrorStatusCode(err error) int {
+	switch {
+	case errors.Is(err, service.ErrCustomLinkExpired):
+		return http.StatusGone
+	case errors.Is(err, service.ErrCustomLinkNotLive):
+		return http.StatusNotFound
+	default:
+		return http.StatusBadRequest
+	}
+}
+
+func getVariantCookie(c *gin.Context, shortLinkCode string) uint {
+	value, err := c.Cookie(fmt.Sprintf(CookieNameCustomLinkVariant, shortLinkCode))
+	if err != nil {
+		return 0
+	}
+
+	variantID, err := strconv.ParseUint(value, 10, 32)
+	if err != nil {
+		return 0
+	}
+	return uint(variantID)
+}
+
+func setVariantCookie(c *gin.Context, shortLinkCode string, variantID *uint) {
+	if variantID == nil {
+		return
+	}
+
+	c.SetCookie(
+		fmt.Sprintf(CookieNameCustomLinkVariant, shortLinkCode),
+		strconv.FormatUint(uint64(*variantID), 10),
+		int(CookieMaxAgeCustomLinkVariant.Seconds()),
+		"/l/"+shortLinkCode, "", false, true,
+	)
+}
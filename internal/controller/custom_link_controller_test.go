@@ -0,0 +1,303 @@
+package controller
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-redis/redis/v8"
+	"github.com/ilhamfzri/pendek.in/app/logger"
+	"github.com/ilhamfzri/pendek.in/internal/model/web"
+	"github.com/ilhamfzri/pendek.in/internal/service"
+	"github.com/ilhamfzri/pendek.in/internal/view"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	userAgentBot     = "Mozilla/5.0 (compatible; Googlebot/2.1; +http://www.google.com/bot.html)"
+	userAgentVisitor = "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/107.0.0.0 Safari/537.36"
+)
+
+// customLinkServiceStub answers the redirect of a link, the other methods of the service aren't used by the tests.
+// A link with a password is unlocked by that password only.
+type customLinkServiceStub struct {
+	service.CustomLinkService
+	PreviewResponse  web.CustomLinkPreviewResponse
+	PreviewErr       error
+	Password         string
+	RedirectResponse web.CustomLinkRedirectResponse
+	RedirectRequests []web.CustomLinkRedirectRequest
+}
+
+func (stub *customLinkServiceStub) GetLinkPreview(ctx context.Context, request web.CustomLinkRedirectRequest, domainName string) (web.CustomLinkPreviewResponse, error) {
+	return stub.PreviewResponse, stub.PreviewErr
+}
+
+func (stub *customLinkServiceStub) RedirectLink(ctx context.Context, request web.CustomLinkRedirectRequest) (web.CustomLinkRedirectResponse, error) {
+	stub.RedirectRequests = append(stub.RedirectRequests, request)
+	if stub.Password != "" && request.Password != stub.Password {
+		return web.CustomLinkRedirectResponse{}, service.ErrCustomLinkUnlockFailed
+	}
+	return stub.RedirectResponse, nil
+}
+
+// customLinkAnalyticServiceStub keeps the saved interactions.
+type customLinkAnalyticServiceStub struct {
+	service.CustomLinkAnalyticService
+	Interactions []web.CustomLinkAnalyticInteractionRequest
+}
+
+func (stub *customLinkAnalyticServiceStub) SaveInteraction(ctx context.Context, request web.CustomLinkAnalyticInteractionRequest) error {
+	stub.Interactions = append(stub.Interactions, request)
+	return nil
+}
+
+// redisMock is an in memory redis speaking just enough of the protocol for the unlock limiter, it answers the
+// attempt script itself because it can't run lua.
+type redisMock struct {
+	mu     sync.Mutex
+	Values map[string]int64
+	TTLs   map[string]time.Duration
+}
+
+func newRedisMock(t *testing.T) (*redis.Client, *redisMock) {
+	mock := &redisMock{Values: map[string]int64{}, TTLs: map[string]time.Duration{}}
+	client := redis.NewClient(&redis.Options{
+		Dialer: func(ctx context.Context, network string, addr string) (net.Conn, error) {
+			serverConn, clientConn := net.Pipe()
+			go mock.serve(serverConn)
+			return clientConn, nil
+		},
+	})
+	t.Cleanup(func() { client.Close() })
+	return client, mock
+}
+
+func (mock *redisMock) serve(conn net.Conn) {
+	defer conn.Close()
+	reader := bufio.NewReader(conn)
+	for {
+		args, err := readRedisCommand(reader)
+		if err != nil {
+			return
+		}
+		if _, err := conn.Write([]byte(mock.do(args))); err != nil {
+			return
+		}
+	}
+}
+
+func readRedisCommand(reader *bufio.Reader) ([]string, error) {
+	var count int
+	if _, err := fmt.Fscanf(reader, "*%d\r\n", &count); err != nil {
+		return nil, err
+	}
+
+	args := make([]string, count)
+	for i := range args {
+		var size int
+		if _, err := fmt.Fscanf(reader, "$%d\r\n", &size); err != nil {
+			return nil, err
+		}
+		arg := make([]byte, size+2)
+		if _, err := io.ReadFull(reader, arg); err != nil {
+			return nil, err
+		}
+		args[i] = string(arg[:size])
+	}
+	return args, nil
+}
+
+func (mock *redisMock) do(args []string) string {
+	mock.mu.Lock()
+	defer mock.mu.Unlock()
+
+	switch strings.ToUpper(args[0]) {
+	case "EVALSHA":
+		return "-NOSCRIPT No matching script.\r\n"
+	case "EVAL":
+		// EVAL script numkeys key... ttl, every key is counted like countUnlockAttemptScript does.
+		keyCount, _ := strconv.Atoi(args[2])
+		ttl, _ := strconv.Atoi(args[3+keyCount])
+		reply := fmt.Sprintf("*%d\r\n", keyCount)
+		for _, key := range args[3 : 3+keyCount] {
+			mock.Values[key]++
+			if mock.Values[key] == 1 {
+				mock.TTLs[key] = time.Duration(ttl) * time.Millisecond
+			}
+			reply += fmt.Sprintf(":%d\r\n", mock.Values[key])
+		}
+		return reply
+	case "DEL":
+		deleted := 0
+		for _, key := range args[1:] {
+			if _, ok := mock.Values[key]; ok {
+				delete(mock.Values, key)
+				delete(mock.TTLs, key)
+				deleted++
+			}
+		}
+		return fmt.Sprintf(":%d\r\n", deleted)
+	default:
+		return fmt.Sprintf("-ERR unknown command '%s'\r\n", args[0])
+	}
+}
+
+func newRedirectRouter(customLinkService service.CustomLinkService, analyticService service.CustomLinkAnalyticService, redisClient *redis.Client) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.SetHTMLTemplate(view.Templates)
+	router.SetTrustedProxies(nil)
+
+	customLinkController := NewCustomLinkController(customLinkService, analyticService, redisClient, new(logger.Logger))
+	router.GET("/l/:short_link_code", customLinkController.RedirectLink)
+	router.POST("/l/:short_link_code", customLinkController.UnlockLink)
+	return router
+}
+
+func postUnlockLink(router *gin.Engine, form url.Values, remoteAddr string) *httptest.ResponseRecorder {
+	request := httptest.NewRequest(http.MethodPost, "/l/promo1", strings.NewReader(form.Encode()))
+	request.Host = "pendek.in"
+	request.RemoteAddr = remoteAddr
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	request.Header.Set("User-Agent", userAgentVisitor)
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+	return recorder
+}
+
+func TestCustomLinkControllerRedirectLink(t *testing.T) {
+	redirectResponse := web.CustomLinkRedirectResponse{StatusCode: http.StatusFound, LinkID: 21, Destination: "https://example.com/promo"}
+
+	tests := []struct {
+		TestName             string
+		UserAgent            string
+		PreviewErr           error
+		StatusExpected       int
+		RedirectedExpected   bool
+		InteractionsExpected int
+	}{
+		{TestName: "[Bot][Open Graph Preview]", UserAgent: userAgentBot, StatusExpected: http.StatusOK},
+		{TestName: "[Bot][No Preview Falls Through]", UserAgent: userAgentBot, PreviewErr: errors.New("no preview"),
+			StatusExpected: http.StatusFound, RedirectedExpected: true},
+		{TestName: "[Visitor]", UserAgent: userAgentVisitor,
+			StatusExpected: http.StatusFound, RedirectedExpected: true, InteractionsExpected: 1},
+	}
+
+	for _, test := range tests {
+		t.Run(test.TestName, func(t *testing.T) {
+			customLinkService := &customLinkServiceStub{
+				PreviewResponse:  web.CustomLinkPreviewResponse{Title: "Promo", Url: "https://pendek.in/l/promo1"},
+				PreviewErr:       test.PreviewErr,
+				RedirectResponse: redirectResponse,
+			}
+			analyticService := &customLinkAnalyticServiceStub{}
+			router := newRedirectRouter(customLinkService, analyticService, nil)
+
+			request := httptest.NewRequest(http.MethodGet, "/l/promo1", nil)
+			request.Header.Set("User-Agent", test.UserAgent)
+			recorder := httptest.NewRecorder()
+			router.ServeHTTP(recorder, request)
+
+			assert.Equal(t, test.StatusExpected, recorder.Code)
+			assert.Len(t, analyticService.Interactions, test.InteractionsExpected)
+			if !test.RedirectedExpected {
+				assert.Contains(t, recorder.Body.String(), `<meta property="og:title" content="Promo">`)
+				assert.Empty(t, customLinkService.RedirectRequests)
+				return
+			}
+
+			assert.Equal(t, "https://example.com/promo", recorder.Header().Get("Location"))
+			assert.Len(t, customLinkService.RedirectRequests, 1)
+			assert.Equal(t, test.UserAgent == userAgentBot, customLinkService.RedirectRequests[0].Bot)
+		})
+	}
+}
+
+func TestCustomLinkControllerUnlockLink(t *testing.T) {
+	redirectResponse := web.CustomLinkRedirectResponse{StatusCode: http.StatusPermanentRedirect, LinkID: 21, Destination: "https://example.com/promo"}
+	attemptKey := "unlock-attempt:pendek.in:promo1:192.0.2.1"
+	linkAttemptKey := "unlock-attempt-link:pendek.in:promo1"
+
+	t.Run("[Too Many Attempts Of A Client]", func(t *testing.T) {
+		redisClient, redisMock := newRedisMock(t)
+		customLinkService := &customLinkServiceStub{Password: "secret", RedirectResponse: redirectResponse}
+		router := newRedirectRouter(customLinkService, &customLinkAnalyticServiceStub{}, redisClient)
+
+		for i := 0; i < MaxCustomLinkUnlockAttempt; i++ {
+			recorder := postUnlockLink(router, url.Values{"password": {"wrong"}}, "192.0.2.1:1234")
+			assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+		}
+
+		// The right password is too late, the attempt is counted before the password is checked.
+		recorder := postUnlockLink(router, url.Values{"password": {"secret"}}, "192.0.2.1:1234")
+		assert.Equal(t, http.StatusTooManyRequests, recorder.Code)
+		assert.Len(t, customLinkService.RedirectRequests, MaxCustomLinkUnlockAttempt)
+		assert.Equal(t, IntervalCustomLinkUnlockAttempt, redisMock.TTLs[attemptKey])
+		assert.Equal(t, IntervalCustomLinkUnlockAttempt, redisMock.TTLs[linkAttemptKey])
+	})
+
+	t.Run("[Forwarded For Doesn't Reset The Count]", func(t *testing.T) {
+		redisClient, _ := newRedisMock(t)
+		customLinkService := &customLinkServiceStub{Password: "secret", RedirectResponse: redirectResponse}
+		router := newRedirectRouter(customLinkService, &customLinkAnalyticServiceStub{}, redisClient)
+
+		var recorder *httptest.ResponseRecorder
+		for i := 0; i <= MaxCustomLinkUnlockAttempt; i++ {
+			request := httptest.NewRequest(http.MethodPost, "/l/promo1", strings.NewReader("password=wrong"))
+			request.Host = "pendek.in"
+			request.RemoteAddr = "192.0.2.1:1234"
+			request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			request.Header.Set("X-Forwarded-For", fmt.Sprintf("198.51.100.%d", i))
+			recorder = httptest.NewRecorder()
+			router.ServeHTTP(recorder, request)
+		}
+		assert.Equal(t, http.StatusTooManyRequests, recorder.Code)
+	})
+
+	t.Run("[Too Many Attempts Of A Link]", func(t *testing.T) {
+		redisClient, _ := newRedisMock(t)
+		customLinkService := &customLinkServiceStub{Password: "secret", RedirectResponse: redirectResponse}
+		router := newRedirectRouter(customLinkService, &customLinkAnalyticServiceStub{}, redisClient)
+
+		for i := 0; i < MaxCustomLinkUnlockAttemptPerLink; i++ {
+			recorder := postUnlockLink(router, url.Values{"password": {"wrong"}}, fmt.Sprintf("10.0.%d.%d:1234", i/256, i%256))
+			assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+		}
+
+		recorder := postUnlockLink(router, url.Values{"password": {"secret"}}, "192.0.2.1:1234")
+		assert.Equal(t, http.StatusTooManyRequests, recorder.Code)
+	})
+
+	t.Run("[Success Clears The Attempts Of The Client]", func(t *testing.T) {
+		redisClient, redisMock := newRedisMock(t)
+		customLinkService := &customLinkServiceStub{Password: "secret", RedirectResponse: redirectResponse}
+		analyticService := &customLinkAnalyticServiceStub{}
+		router := newRedirectRouter(customLinkService, analyticService, redisClient)
+
+		recorder := postUnlockLink(router, url.Values{"password": {"wrong"}}, "192.0.2.1:1234")
+		assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+
+		// It's 303 whatever the status of the link, a 308 would send the password form to the destination.
+		recorder = postUnlockLink(router, url.Values{"password": {"secret"}, "source": {"qr"}}, "192.0.2.1:1234")
+		assert.Equal(t, http.StatusSeeOther, recorder.Code)
+		assert.Equal(t, "https://example.com/promo", recorder.Header().Get("Location"))
+
+		assert.NotContains(t, redisMock.Values, attemptKey)
+		assert.Equal(t, int64(2), redisMock.Values[linkAttemptKey])
+		assert.Len(t, analyticService.Interactions, 1)
+		assert.Equal(t, service.InteractionSourceQR, analyticService.Interactions[0].Source)
+	})
+}
@@ -123,8 +123,44 @@ func (controller *SocialMediaLinkControllerImpl) GetAllLink(c *gin.Context) {
 	ctx := context.Background()
 	jwtToken := helper.ExtractTokenFromRequestHeader(c)
 	host := c.Request.Host
+	var request web.SocialMediaLinkGetAllRequest
 
-	socialMediaTypesResponse, errService := controller.Service.GetAllLink(ctx, host, jwtToken)
+	err := c.ShouldBindQuery(&request)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, helper.ToWebResponseFailed(err))
+		return
+	}
+
+	socialMediaTypesResponse, pagination, errService := controller.Service.GetAllLink(ctx, request, host, jwtToken)
+	if errService != nil {
+		webResponse := web.WebResponseFailed{
+			Status:  "failed",
+			Message: errService.Error(),
+		}
+		c.JSON(http.StatusBadRequest, webResponse)
+	} else {
+		webResponse := web.WebResponseSuccess{
+			Status:     "success",
+			Message:    "success get all social media types",
+			Data:       socialMediaTypesResponse,
+			Pagination: &pagination,
+		}
+		c.JSON(http.StatusOK, webResponse)
+	}
+}
+
+func (controller *SocialMediaLinkControllerImpl) ReorderLink(c *gin.Context) {
+	ctx := context.Background()
+	jwtToken := helper.ExtractTokenFromRequestHeader(c)
+	var request web.SocialMediaLinkReorderRequest
+
+	err := c.ShouldBindJSON(&request)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, helper.ToWebResponseFailed(err))
+		return
+	}
+
+	positionsResponse, errService := controller.Service.ReorderLink(ctx, request, jwtToken)
 	if errService != nil {
 		webResponse := web.WebResponseFailed{
 			Status:  "failed",
@@ -134,8 +170,8 @@ func (controller *SocialMediaLinkControllerImpl) GetAllLink(c *gin.Context) {
 	} else {
 		webResponse := web.WebResponseSuccess{
 			Status:  "success",
-			Message: "success get all social media types",
-			Data:    socialMediaTypesResponse,
+			Message: "success reorder social media link",
+			Data:    positionsResponse,
 		}
 		c.JSON(http.StatusOK, webResponse)
 	}
@@ -275,6 +275,45 @@ func (controller *UserControllerImpl) Profile(c *gin.Context) {
 
 }
 
+func (controller *UserControllerImpl) ProfileQRCode(c *gin.Context) {
+	ctx := context.Background()
+	baseUrl := helper.GetBaseUrl(c)
+	var request web.UserProfileQRCodeRequest
+
+	err := c.ShouldBindUri(&request)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, helper.ToWebResponseFailed(err))
+		return
+	}
+
+	err = c.ShouldBindQuery(&request)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, helper.ToWebResponseFailed(err))
+		return
+	}
+
+	userResponse := controller.Service.GetProfileData(ctx, web.UserProfileRequest{Username: request.Username})
+	if userResponse.ID == "" {
+		webResponse := web.WebResponseFailed{
+			Status:  "failed",
+			Message: "account not found",
+		}
+		c.JSON(http.StatusNotFound, webResponse)
+		return
+	}
+
+	qrCodeResponse, err := helper.RenderQRCode(helper.GetProfileUrl(baseUrl, userResponse.Username), request.QRCodeOptionRequest, nil)
+	if err != nil {
+		webResponse := web.WebResponseFailed{
+			Status:  "failed",
+			Message: err.Error(),
+		}
+		c.JSON(http.StatusBadRequest, webResponse)
+	} else {
+		c.Data(http.StatusOK, qrCodeResponse.ContentType, qrCodeResponse.Data)
+	}
+}
+
 func (controller *UserControllerImpl) GetCurrentProfile(c *gin.Context) {
 	ctx := context.Background()
 	jwtToken := helper.ExtractTokenFromRequestHeader(c)
@@ -0,0 +1,28 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ilhamfzri/pendek.in/helper"
+)
+
+var ErrAdminOnly = errors.New("only admins are allowed to access this resource")
+
+// NewAdminMiddleware only lets the configured admin user ids through, it must run after the jwt middleware.
+func NewAdminMiddleware(jwt helper.IJwt, adminUserIDs []string) gin.HandlerFunc {
+	admins := map[string]bool{}
+	for _, userID := range adminUserIDs {
+		admins[userID] = true
+	}
+
+	return func(c *gin.Context) {
+		claims := jwt.GetClaims(helper.ExtractTokenFromRequestHeader(c))
+		if claims.Id == "" || !admins[claims.Id] {
+			c.AbortWithStatusJSON(http.StatusForbidden, helper.ToWebResponseFailed(ErrAdminOnly))
+			return
+		}
+		c.Next()
+	}
+}
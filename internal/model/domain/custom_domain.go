@@ -0,0 +1,15 @@
+package domain
+
+import "time"
+
+// CustomDomain is a domain of the user that serves its links, a name can be registered by several users
+// but only one of them can verify it.
+type CustomDomain struct {
+	ID                uint   `gorm:"primarykey"`
+	UserID            string `gorm:"index"`
+	Name              string `gorm:"uniqueIndex:idx_custom_domains_verified_name,where:verified_at IS NOT NULL"`
+	VerificationToken string
+	VerifiedAt        *time.Time
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+}
@@ -1,19 +1,45 @@
 package domain
 
-import "gorm.io/gorm"
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
 
 type CustomLink struct {
 	gorm.Model
 	UserID                string `gorm:"index"`
 	Title                 string
-	ShortLinkCode         string `gorm:"unique"`
+	DomainID              *uint        `gorm:"uniqueIndex:idx_custom_links_domain_id_short_link_code,where:domain_id IS NOT NULL"`
+	Domain                CustomDomain `gorm:"foreignKey:DomainID"`
+	ShortLinkCode         string       `gorm:"uniqueIndex:idx_custom_links_short_link_code,where:domain_id IS NULL;uniqueIndex:idx_custom_links_domain_id_short_link_code,where:domain_id IS NOT NULL"` // unique per domain
+	ReleasedShortLinkCode string
+	CaseInsensitive       bool // copied from the case insensitive short code option of the owner
 	LongLink              string
 	ShowOnProfile         bool
 	Activate              bool
+	Position              int
+	RedirectStatus        int // 0 redirects with 302 Found
+	ForwardQuery          bool
+	Interstitial          bool // visitors see a warning page with the destination before leaving
+	ExpiresAt             *time.Time
+	MaxClicks             *uint
+	ClickCount            uint // clicks of the link, it sorts by clicks and is checked against MaxClicks. Only ClaimClick increments it
+	Password              string
+	ActiveFrom            *time.Time
+	ActiveUntil           *time.Time
+	Utm                   Utm `gorm:"embedded;embeddedPrefix:utm_"`
 	CustomThumbnailID     *uint
 	CustomThumbnail       CustomThumbnail `gorm:"foreignKey:CustomThumbnailID"`
 	ThumbnailID           *uint
 	Thumbnail             Thumbnail `gorm:"foreignKey:ThumbnailID"`
+	OgTitle               string
+	OgDescription         string
+	OgCustomThumbnailID   *uint
+	OgCustomThumbnail     CustomThumbnail `gorm:"foreignKey:OgCustomThumbnailID"`
+	Health                LinkHealth      `gorm:"embedded;embeddedPrefix:health_"`
+	FolderID              *uint           `gorm:"index"`
+	Tags                  []Tag           `gorm:"many2many:custom_link_tags"`
 	CustomLinkAnalytic    []CustomLinkAnalytic
 	CustomLinkInteraction []CustomLinkInteraction
 }
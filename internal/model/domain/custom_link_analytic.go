@@ -9,6 +9,7 @@ import (
 type CustomLinkAnalytic struct {
 	gorm.Model
 	ClickCount       int
+	ScanCount        int
 	ViewCount        int
 	CustomLinkID     uint
 	DeviceAnalyticID uint
@@ -9,4 +9,6 @@ type CustomLinkInteraction struct {
 	CustomLinkID uint `gorm:"index"`
 	ClientIP     string
 	UserAgent    string
+	VariantID    *uint  `gorm:"index"`
+	Source       string // empty for a click on the short link, qr for a scan of its qr code
 }
@@ -0,0 +1,19 @@
+package domain
+
+import "gorm.io/gorm"
+
+// CustomLinkRevision is a snapshot of the destination of a link after a change, the diff of a revision
+// is taken against the revision before it.
+type CustomLinkRevision struct {
+	gorm.Model
+	CustomLinkID  uint `gorm:"index;uniqueIndex:idx_custom_link_revisions_link_revision"`
+	Revision      uint `gorm:"uniqueIndex:idx_custom_link_revisions_link_revision"`
+	Action        string
+	RevertedFrom  *uint
+	UserID        string
+	Username      string
+	Title         string
+	ShortLinkCode string
+	LongLink      string
+	Utm           Utm `gorm:"embedded;embeddedPrefix:utm_"`
+}
@@ -0,0 +1,14 @@
+package domain
+
+import "gorm.io/gorm"
+
+type CustomLinkTargetingRule struct {
+	gorm.Model
+	CustomLinkID uint `gorm:"index"`
+	Position     int
+	Device       string
+	OS           string
+	Country      string
+	Language     string
+	TargetLink   string
+}
@@ -0,0 +1,12 @@
+package domain
+
+import "gorm.io/gorm"
+
+type CustomLinkVariant struct {
+	gorm.Model
+	CustomLinkID uint `gorm:"index"`
+	Position     int
+	Name         string
+	TargetLink   string
+	Weight       uint
+}
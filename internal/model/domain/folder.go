@@ -0,0 +1,12 @@
+package domain
+
+import "time"
+
+type Folder struct {
+	ID        uint   `gorm:"primarykey"`
+	UserID    string `gorm:"index"`
+	Name      string
+	ParentID  *uint `gorm:"index"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
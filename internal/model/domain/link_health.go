@@ -0,0 +1,19 @@
+package domain
+
+import "time"
+
+type LinkRedirectHop struct {
+	Url        string `json:"url"`
+	StatusCode int    `json:"status_code"`
+}
+
+type LinkHealth struct {
+	StatusCode    int // status code of the last check, 0 when the destination didn't answer
+	LatencyMs     int64
+	RedirectChain []LinkRedirectHop `gorm:"serializer:json"`
+	Error         string
+	FailureCount  int  // consecutive failed checks
+	Broken        bool `gorm:"index"` // set once the failure count reaches the threshold
+	CheckedAt     *time.Time
+	NotifiedAt    *time.Time // owner notified about the current breakage
+}
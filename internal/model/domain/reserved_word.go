@@ -0,0 +1,11 @@
+package domain
+
+import "time"
+
+type ReservedWord struct {
+	ID        uint   `gorm:"primarykey"`
+	Word      string `gorm:"unique"`
+	Category  string `gorm:"index"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
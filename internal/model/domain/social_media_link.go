@@ -13,4 +13,5 @@ type SocialMediaLink struct {
 	UserID                 string
 	LinkOrUsername         string
 	Activate               bool
+	Position               int
 }
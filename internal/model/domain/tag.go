@@ -0,0 +1,11 @@
+package domain
+
+import "time"
+
+type Tag struct {
+	ID        uint   `gorm:"primarykey"`
+	UserID    string `gorm:"uniqueIndex:idx_tags_user_id_name"`
+	Name      string `gorm:"uniqueIndex:idx_tags_user_id_name"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
@@ -0,0 +1,19 @@
+package domain
+
+import "gorm.io/gorm"
+
+type Utm struct {
+	Source   string
+	Medium   string
+	Campaign string
+	Term     string
+	Content  string
+}
+
+type UtmTemplate struct {
+	gorm.Model
+	UserID    string `gorm:"index"`
+	Name      string
+	IsDefault bool
+	Utm       Utm `gorm:"embedded;embeddedPrefix:utm_"`
+}
@@ -0,0 +1,10 @@
+package web
+
+type ReservedWordCreateRequest struct {
+	Word     string `json:"word" binding:"required,max=50,alphanum"`
+	Category string `json:"category" binding:"required,oneof=route brand offensive"`
+}
+
+type ReservedWordDeleteRequest struct {
+	WordID uint `uri:"word_id" binding:"required"`
+}
@@ -0,0 +1,7 @@
+package web
+
+type ReservedWordResponse struct {
+	ID       uint   `json:"id"`
+	Word     string `json:"word"`
+	Category string `json:"category"`
+}
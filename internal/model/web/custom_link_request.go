@@ -1,42 +1,253 @@
 package web
 
-import "time"
+import (
+	"net/url"
+	"time"
+)
 
 type CustomLinkCreateRequest struct {
-	Title           string `json:"title" binding:"required,min=1,max=20"`
-	ShortLinkCode   string `json:"short_link_code" binding:"required,min=5,max=20,alphanum"`
-	LongLink        string `json:"long_link" binding:"required,url"`
-	UserThumbnailID *uint  `json:"user_thumbnail_id"`
-	ThumbnailID     *uint  `json:"thumbnail_id"`
+	Title             string                           `json:"title" binding:"required,min=1,max=20"`
+	ShortLinkCode     string                           `json:"short_link_code" binding:"omitempty,min=5,max=20,alphanum"`
+	LongLink          string                           `json:"long_link" binding:"required,url"`
+	UserThumbnailID   *uint                            `json:"user_thumbnail_id"`
+	ThumbnailID       *uint                            `json:"thumbnail_id"`
+	ExpiresAt         *time.Time                       `json:"expires_at" binding:"omitempty,gt"`
+	MaxClicks         *uint                            `json:"max_clicks" binding:"omitempty,min=1"`
+	Password          string                           `json:"password" binding:"omitempty,min=4,max=50"`
+	ActiveFrom        *time.Time                       `json:"active_from"`
+	ActiveUntil       *time.Time                       `json:"active_until" binding:"omitempty,gt"`
+	UtmTemplateID     *uint                            `json:"utm_template_id"`
+	UtmSource         string                           `json:"utm_source" binding:"omitempty,max=100"`
+	UtmMedium         string                           `json:"utm_medium" binding:"omitempty,max=100"`
+	UtmCampaign       string                           `json:"utm_campaign" binding:"omitempty,max=100"`
+	UtmTerm           string                           `json:"utm_term" binding:"omitempty,max=100"`
+	UtmContent        string                           `json:"utm_content" binding:"omitempty,max=100"`
+	TargetingRules    []CustomLinkTargetingRuleRequest `json:"targeting_rules" binding:"omitempty,max=20,dive"`
+	Variants          []CustomLinkVariantRequest       `json:"variants" binding:"omitempty,max=10,dive"`
+	FolderID          *uint                            `json:"folder_id"`
+	TagIDs            []uint                           `json:"tag_ids" binding:"omitempty,max=20"`
+	OgTitle           string                           `json:"og_title" binding:"omitempty,max=100"`
+	OgDescription     string                           `json:"og_description" binding:"omitempty,max=300"`
+	OgUserThumbnailID *uint                            `json:"og_user_thumbnail_id"`
+	RedirectStatus    int                              `json:"redirect_status" binding:"omitempty,oneof=301 302 307 308"`
+	ForwardQuery      bool                             `json:"forward_query"`
+	Interstitial      bool                             `json:"interstitial"`
+	DomainID          *uint                            `json:"domain_id"`
+}
+
+type CustomLinkVariantRequest struct {
+	Name       string `json:"name" binding:"required,min=1,max=30"`
+	TargetLink string `json:"target_link" binding:"required,url"`
+	Weight     uint   `json:"weight" binding:"required,min=1,max=1000"`
+}
+
+type CustomLinkTargetingRuleRequest struct {
+	Device     string `json:"device" binding:"omitempty,oneof=mobile tablet desktop"`
+	OS         string `json:"os" binding:"omitempty,oneof=ios android windows macos linux chromeos"`
+	Country    string `json:"country" binding:"omitempty,iso3166_1_alpha2"`
+	Language   string `json:"language" binding:"omitempty,min=2,max=20"`
+	TargetLink string `json:"target_link" binding:"required,url"`
+}
+
+type CustomLinkImportRequest struct {
+	Format string `form:"format" binding:"omitempty,oneof=csv json"`
+	DryRun bool   `form:"dry_run"`
+	Atomic bool   `form:"atomic"`
+	Links  []CustomLinkCreateRequest
+}
+
+type CustomLinkExportRequest struct {
+	Format       string `form:"format" binding:"omitempty,oneof=csv json xlsx"`
+	WithAnalytic bool   `form:"with_analytic"`
 }
 
 type CustomLinkUpdateRequest struct {
-	CustomLinkID    uint   `uri:"link_id" binding:"required"`
-	Title           string `json:"title" binding:"omitempty,min=1,max=20"`
-	ShortLinkCode   string `json:"short_link_code" binding:"omitempty,min=5,max=20,alphanum"`
-	LongLink        string `json:"long_link" binding:"omitempty,url"`
-	UserThumbnailID *uint  `json:"user_thumbnail_id"`
-	ThumbnailID     *uint  `json:"thumbnail_id"`
-	ShowOnProfile   *bool  `json:"show_on_profile"`
-	Activate        *bool  `json:"activate"`
+	CustomLinkID      uint                              `uri:"link_id" binding:"required"`
+	Title             string                            `json:"title" binding:"omitempty,min=1,max=20"`
+	ShortLinkCode     string                            `json:"short_link_code" binding:"omitempty,min=5,max=20,alphanum"`
+	LongLink          string                            `json:"long_link" binding:"omitempty,url"`
+	UserThumbnailID   *uint                             `json:"user_thumbnail_id"`
+	ThumbnailID       *uint                             `json:"thumbnail_id"`
+	ShowOnProfile     *bool                             `json:"show_on_profile"`
+	Activate          *bool                             `json:"activate"`
+	ExpiresAt         *time.Time                        `json:"expires_at"`                                      // zero time removes the expiry date
+	MaxClicks         *uint                             `json:"max_clicks"`                                      // 0 removes the click limit
+	Password          *string                           `json:"password" binding:"omitempty,max=50"`             // empty string removes the password
+	ActiveFrom        *time.Time                        `json:"active_from"`                                     // zero time removes the start of the window
+	ActiveUntil       *time.Time                        `json:"active_until"`                                    // zero time removes the end of the window
+	UtmSource         *string                           `json:"utm_source" binding:"omitempty,max=100"`          // empty string removes the parameter
+	UtmMedium         *string                           `json:"utm_medium" binding:"omitempty,max=100"`          // empty string removes the parameter
+	UtmCampaign       *string                           `json:"utm_campaign" binding:"omitempty,max=100"`        // empty string removes the parameter
+	UtmTerm           *string                           `json:"utm_term" binding:"omitempty,max=100"`            // empty string removes the parameter
+	UtmContent        *string                           `json:"utm_content" binding:"omitempty,max=100"`         // empty string removes the parameter
+	TargetingRules    *[]CustomLinkTargetingRuleRequest `json:"targeting_rules" binding:"omitempty,max=20,dive"` // replaces the rules, empty list removes them
+	Variants          *[]CustomLinkVariantRequest       `json:"variants" binding:"omitempty,max=10,dive"`        // replaces the variants, empty list removes them
+	FolderID          *uint                             `json:"folder_id"`                                       // 0 moves the link to the root
+	TagIDs            *[]uint                           `json:"tag_ids" binding:"omitempty,max=20"`              // replaces the tags, empty list removes them
+	OgTitle           *string                           `json:"og_title" binding:"omitempty,max=100"`            // empty string removes the title
+	OgDescription     *string                           `json:"og_description" binding:"omitempty,max=300"`      // empty string removes the description
+	OgUserThumbnailID *uint                             `json:"og_user_thumbnail_id"`                            // 0 removes the image
+	RedirectStatus    *int                              `json:"redirect_status" binding:"omitempty,oneof=301 302 307 308"`
+	ForwardQuery      *bool                             `json:"forward_query"`
+	Interstitial      *bool                             `json:"interstitial"`
+	DomainID          *uint                             `json:"domain_id"` // 0 moves the link to the shared domain
+}
+
+type CustomLinkGetAllRequest struct {
+	TagID             uint   `form:"tag_id"`
+	FolderID          uint   `form:"folder_id"`
+	IncludeSubfolders bool   `form:"include_subfolders"`
+	Search            string `form:"search" binding:"omitempty,max=100"`
+	Sort              string `form:"sort" binding:"omitempty,oneof=created updated title clicks position"`
+	Order             string `form:"order" binding:"omitempty,oneof=asc desc"`
+	Limit             int    `form:"limit" binding:"omitempty,min=1,max=100"`
+	Cursor            string `form:"cursor"`
+	Broken            bool   `form:"broken"`
+}
+
+type CustomLinkReorderRequest struct {
+	LinkIDs []uint `json:"link_ids" binding:"required,min=1,max=1000,unique"`
+}
+
+type CustomLinkBatchRequest struct {
+	LinkIDs  []uint  `json:"link_ids" binding:"required,min=1,max=1000,unique"`
+	Action   string  `json:"action" binding:"required,oneof=activate deactivate show hide retag move delete"`
+	TagIDs   *[]uint `json:"tag_ids" binding:"omitempty,max=20"` // required to retag, replaces the tags and an empty list removes them
+	FolderID *uint   `json:"folder_id"`                          // required to move, 0 moves the links to the root
 }
 
 type CustomLinkGetRequest struct {
 	LinkID uint `uri:"link_id" binding:"required"`
 }
 
+type CustomLinkDeleteRequest struct {
+	LinkID uint `uri:"link_id" binding:"required"`
+}
+
+type CustomLinkRestoreRequest struct {
+	LinkID uint `uri:"link_id" binding:"required"`
+}
+
+type CustomLinkHistoryRequest struct {
+	LinkID uint `uri:"link_id" binding:"required"`
+}
+
+type CustomLinkRevertRequest struct {
+	LinkID   uint `uri:"link_id" binding:"required"`
+	Revision uint `uri:"revision" binding:"required"`
+}
+
+type CustomLinkCloneRequest struct {
+	LinkID        uint   `uri:"link_id" binding:"required"`
+	Title         string `json:"title" binding:"omitempty,min=1,max=20"`                    // the title of the cloned link is kept when empty
+	ShortLinkCode string `json:"short_link_code" binding:"omitempty,min=5,max=20,alphanum"` // a code is generated when empty
+}
+
 type CustomLinkRedirectRequest struct {
-	ShortLinkCode string `uri:"short_link_code" binding:"required"`
+	ShortLinkCode  string `uri:"short_link_code" binding:"required"`
+	Password       string
+	ClientIP       string
+	UserAgent      string
+	AcceptLanguage string
+	VariantID      uint       // variant assigned on a previous visit, 0 when there is none
+	Query          url.Values // query string of the visit, it's forwarded when the link allows it
+	Host           string     // host of the visit, it picks the domain of the link
+	BaseUrl        string     // base url of the visit, it builds the thumbnail url of a preview
+	Preview        bool       // the visit only looks at the link, the data of the preview page is filled in
+	Bot            bool       // the visit comes from a crawler, it only claims a click of a link with a click limit
 }
 
 type CustomLinkCheckShortCodeAvaibilityRequest struct {
-	Code string `form:"code" binding:"required,min=5,max=20"`
+	Code     string `form:"code" binding:"required,min=5,max=20"`
+	DomainID uint   `form:"domain_id"`               // 0 is the shared domain
+	Title    string `form:"title" binding:"max=100"` // suggestions are built from it when the code is taken
+}
+
+type CustomDomainCreateRequest struct {
+	Name string `json:"name" binding:"required,fqdn,max=253"`
+}
+
+type CustomDomainVerifyRequest struct {
+	DomainID uint `uri:"domain_id" binding:"required"`
+}
+
+type CustomDomainDeleteRequest struct {
+	DomainID uint `uri:"domain_id" binding:"required"`
+}
+
+type TagCreateRequest struct {
+	Name string `json:"name" binding:"required,min=1,max=30"`
+}
+
+type TagUpdateRequest struct {
+	TagID uint   `uri:"tag_id" binding:"required"`
+	Name  string `json:"name" binding:"omitempty,min=1,max=30"`
+}
+
+type TagDeleteRequest struct {
+	TagID uint `uri:"tag_id" binding:"required"`
+}
+
+type FolderCreateRequest struct {
+	Name     string `json:"name" binding:"required,min=1,max=50"`
+	ParentID *uint  `json:"parent_id"`
+}
+
+type FolderUpdateRequest struct {
+	FolderID uint   `uri:"folder_id" binding:"required"`
+	Name     string `json:"name" binding:"omitempty,min=1,max=50"`
+	ParentID *uint  `json:"parent_id"` // 0 moves the folder to the root
+}
+
+type FolderDeleteRequest struct {
+	FolderID uint `uri:"folder_id" binding:"required"`
+}
+
+type UtmTemplateCreateRequest struct {
+	Name        string `json:"name" binding:"required,min=1,max=50"`
+	IsDefault   bool   `json:"is_default"`
+	UtmSource   string `json:"utm_source" binding:"omitempty,max=100"`
+	UtmMedium   string `json:"utm_medium" binding:"omitempty,max=100"`
+	UtmCampaign string `json:"utm_campaign" binding:"omitempty,max=100"`
+	UtmTerm     string `json:"utm_term" binding:"omitempty,max=100"`
+	UtmContent  string `json:"utm_content" binding:"omitempty,max=100"`
+}
+
+type UtmTemplateUpdateRequest struct {
+	TemplateID  uint    `uri:"template_id" binding:"required"`
+	Name        string  `json:"name" binding:"omitempty,min=1,max=50"`
+	IsDefault   *bool   `json:"is_default"`
+	UtmSource   *string `json:"utm_source" binding:"omitempty,max=100"`
+	UtmMedium   *string `json:"utm_medium" binding:"omitempty,max=100"`
+	UtmCampaign *string `json:"utm_campaign" binding:"omitempty,max=100"`
+	UtmTerm     *string `json:"utm_term" binding:"omitempty,max=100"`
+	UtmContent  *string `json:"utm_content" binding:"omitempty,max=100"`
+}
+
+type UtmTemplateDeleteRequest struct {
+	TemplateID uint `uri:"template_id" binding:"required"`
 }
 
 type CustomLinkAnalyticInteractionRequest struct {
 	ClientIP     string
 	UserAgent    string
 	CustomLinkID uint
+	VariantID    *uint
+	Source       string
+}
+
+type QRCodeOptionRequest struct {
+	Format     string `form:"format" binding:"omitempty,oneof=png svg"`
+	Size       int    `form:"size" binding:"omitempty,min=64,max=2048"`
+	Level      string `form:"level" binding:"omitempty,oneof=l m q h"`
+	Foreground string `form:"fg" binding:"omitempty,max=7"`
+	Background string `form:"bg" binding:"omitempty,max=7"`
+}
+
+type CustomLinkQRCodeRequest struct {
+	LinkID uint `uri:"link_id" binding:"required"`
+	Logo   bool `form:"logo"`
+	QRCodeOptionRequest
 }
 
 type CustomLinkAnalyticGetRequest struct {
@@ -9,35 +9,253 @@ type ThumbnailResponse struct {
 }
 
 type CustomLinkResponse struct {
-	ID                uint   `json:"id"`
-	Title             string `json:"title"`
-	ShortLinkCode     string `json:"short_link_code"`
-	LongLink          string `json:"long_link"`
-	RedirectLink      string `json:"redirect_link"`
-	ShowOnProfile     bool   `json:"show_on_profile"`
-	Activate          bool   `json:"activate"`
-	ThumbnailID       uint   `json:"thumbnail_id,omitempty"`
-	CustomThumbnailID uint   `json:"custom_thumbnail_id,omitempty"`
-	ThumbnailUrl      string `json:"thumbnail_url,omitempty"`
+	ID                     uint                              `json:"id"`
+	Title                  string                            `json:"title"`
+	ShortLinkCode          string                            `json:"short_link_code"`
+	LongLink               string                            `json:"long_link"`
+	RedirectLink           string                            `json:"redirect_link"`
+	ShowOnProfile          bool                              `json:"show_on_profile"`
+	Activate               bool                              `json:"activate"`
+	Position               int                               `json:"position"`
+	RedirectStatus         int                               `json:"redirect_status"`
+	ForwardQuery           bool                              `json:"forward_query"`
+	Interstitial           bool                              `json:"interstitial"`
+	DomainID               *uint                             `json:"domain_id,omitempty"`
+	ThumbnailID            uint                              `json:"thumbnail_id,omitempty"`
+	CustomThumbnailID      uint                              `json:"custom_thumbnail_id,omitempty"`
+	ThumbnailUrl           string                            `json:"thumbnail_url,omitempty"`
+	ExpiresAt              *time.Time                        `json:"expires_at,omitempty"`
+	MaxClicks              *uint                             `json:"max_clicks,omitempty"`
+	Expired                bool                              `json:"expired"`
+	PasswordProtected      bool                              `json:"password_protected"`
+	ActiveFrom             *time.Time                        `json:"active_from,omitempty"`
+	ActiveUntil            *time.Time                        `json:"active_until,omitempty"`
+	UtmSource              string                            `json:"utm_source,omitempty"`
+	UtmMedium              string                            `json:"utm_medium,omitempty"`
+	UtmCampaign            string                            `json:"utm_campaign,omitempty"`
+	UtmTerm                string                            `json:"utm_term,omitempty"`
+	UtmContent             string                            `json:"utm_content,omitempty"`
+	TargetingRules         []CustomLinkTargetingRuleResponse `json:"targeting_rules,omitempty"`
+	Variants               []CustomLinkVariantResponse       `json:"variants,omitempty"`
+	FolderID               *uint                             `json:"folder_id,omitempty"`
+	Tags                   []TagResponse                     `json:"tags,omitempty"`
+	OgTitle                string                            `json:"og_title,omitempty"`
+	OgDescription          string                            `json:"og_description,omitempty"`
+	OgCustomThumbnailID    uint                              `json:"og_custom_thumbnail_id,omitempty"`
+	DeletedAt              *time.Time                        `json:"deleted_at,omitempty"`
+	ShortLinkCodeReleaseAt *time.Time                        `json:"short_link_code_release_at,omitempty"`
+	Broken                 bool                              `json:"broken"`
+	Health                 *CustomLinkHealthResponse         `json:"health,omitempty"`
+}
+
+type CustomLinkHealthResponse struct {
+	StatusCode    int                             `json:"status_code"`
+	LatencyMs     int64                           `json:"latency_ms"`
+	RedirectChain []CustomLinkRedirectHopResponse `json:"redirect_chain,omitempty"`
+	Error         string                          `json:"error,omitempty"`
+	FailureCount  int                             `json:"failure_count"`
+	CheckedAt     *time.Time                      `json:"checked_at"`
+}
+
+type CustomLinkRedirectHopResponse struct {
+	Url        string `json:"url"`
+	StatusCode int    `json:"status_code"`
+}
+
+type CustomDomainResponse struct {
+	ID                 uint                       `json:"id"`
+	Name               string                     `json:"name"`
+	Verified           bool                       `json:"verified"`
+	VerifiedAt         *time.Time                 `json:"verified_at,omitempty"`
+	VerificationRecord CustomDomainRecordResponse `json:"verification_record"`
+}
+
+type CustomDomainRecordResponse struct {
+	Type  string `json:"type"`
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type TagResponse struct {
+	ID   uint   `json:"id"`
+	Name string `json:"name"`
+}
+
+type FolderResponse struct {
+	ID       uint             `json:"id"`
+	Name     string           `json:"name"`
+	ParentID *uint            `json:"parent_id,omitempty"`
+	Children []FolderResponse `json:"children,omitempty"`
+}
+
+type CustomLinkVariantResponse struct {
+	ID         uint   `json:"id"`
+	Name       string `json:"name"`
+	TargetLink string `json:"target_link"`
+	Weight     uint   `json:"weight"`
+}
+
+type CustomLinkRedirectResponse struct {
+	Destination  string
+	StatusCode   int
+	LinkID       uint
+	VariantID    *uint
+	Title        string
+	ThumbnailUrl string // only filled in for a preview
+	CreatedAt    time.Time
+	Interstitial bool // the visitor is warned on a page before leaving to the destination
+}
+
+// CustomLinkPreviewResponse is the open graph data served to crawlers instead of the redirect.
+type CustomLinkPreviewResponse struct {
+	Title       string
+	Description string
+	ImageUrl    string
+	Url         string
+}
+
+type CustomLinkTargetingRuleResponse struct {
+	Device     string `json:"device,omitempty"`
+	OS         string `json:"os,omitempty"`
+	Country    string `json:"country,omitempty"`
+	Language   string `json:"language,omitempty"`
+	TargetLink string `json:"target_link"`
+}
+
+type CustomLinkRevisionResponse struct {
+	Revision      uint                               `json:"revision"`
+	Action        string                             `json:"action"`
+	RevertedFrom  *uint                              `json:"reverted_from,omitempty"`
+	ChangedBy     string                             `json:"changed_by"`
+	ChangedAt     time.Time                          `json:"changed_at"`
+	Title         string                             `json:"title"`
+	ShortLinkCode string                             `json:"short_link_code"`
+	LongLink      string                             `json:"long_link"`
+	UtmSource     string                             `json:"utm_source,omitempty"`
+	UtmMedium     string                             `json:"utm_medium,omitempty"`
+	UtmCampaign   string                             `json:"utm_campaign,omitempty"`
+	UtmTerm       string                             `json:"utm_term,omitempty"`
+	UtmContent    string                             `json:"utm_content,omitempty"`
+	Changes       []CustomLinkRevisionChangeResponse `json:"changes"`
+}
+
+type CustomLinkRevisionChangeResponse struct {
+	Field string `json:"field"`
+	From  string `json:"from"`
+	To    string `json:"to"`
+}
+
+type UtmTemplateResponse struct {
+	ID          uint   `json:"id"`
+	Name        string `json:"name"`
+	IsDefault   bool   `json:"is_default"`
+	UtmSource   string `json:"utm_source,omitempty"`
+	UtmMedium   string `json:"utm_medium,omitempty"`
+	UtmCampaign string `json:"utm_campaign,omitempty"`
+	UtmTerm     string `json:"utm_term,omitempty"`
+	UtmContent  string `json:"utm_content,omitempty"`
+}
+
+type CustomLinkImportRowResponse struct {
+	Row     int                 `json:"row"`
+	Status  string              `json:"status"`
+	Message string              `json:"message,omitempty"`
+	Link    *CustomLinkResponse `json:"link,omitempty"`
+}
+
+type CustomLinkImportResponse struct {
+	Total     int                           `json:"total"`
+	Succeeded int                           `json:"succeeded"`
+	Failed    int                           `json:"failed"`
+	DryRun    bool                          `json:"dry_run"`
+	Atomic    bool                          `json:"atomic"`
+	Results   []CustomLinkImportRowResponse `json:"results"`
+}
+
+type CustomLinkExportResponse struct {
+	ID                uint       `json:"id"`
+	Title             string     `json:"title"`
+	ShortLinkCode     string     `json:"short_link_code"`
+	LongLink          string     `json:"long_link"`
+	RedirectLink      string     `json:"redirect_link"`
+	ThumbnailUrl      string     `json:"thumbnail_url"`
+	ShowOnProfile     bool       `json:"show_on_profile"`
+	Activate          bool       `json:"activate"`
+	Expired           bool       `json:"expired"`
+	PasswordProtected bool       `json:"password_protected"`
+	TotalClickCount   *int       `json:"total_click_count,omitempty"`
+	CreatedAt         time.Time  `json:"created_at"`
+	UpdatedAt         time.Time  `json:"updated_at"`
+	ExpiresAt         *time.Time `json:"expires_at"`
 }
 
 type CustomLinkAnalyticResponse struct {
-	LinkID         uint                   `json:"link_id"`
-	ClickCount     int                    `json:"click_count"`
-	ViewCount      int                    `json:"view_count"`
-	DeviceAnalytic DeviceAnalyticResponse `json:"device_analytic"`
-	Datetime       string                 `json:"datetime"`
-	LastUpdated    time.Time              `json:"last_updated"`
+	LinkID         uint                                `json:"link_id"`
+	ClickCount     int                                 `json:"click_count"`
+	ScanCount      int                                 `json:"scan_count"`
+	ViewCount      int                                 `json:"view_count"`
+	DeviceAnalytic DeviceAnalyticResponse              `json:"device_analytic"`
+	Variants       []CustomLinkVariantAnalyticResponse `json:"variants,omitempty"`
+	Datetime       string                              `json:"datetime"`
+	LastUpdated    time.Time                           `json:"last_updated"`
+}
+
+type CustomLinkVariantAnalyticResponse struct {
+	VariantID  uint   `json:"variant_id"`
+	Name       string `json:"name"`
+	Weight     uint   `json:"weight"`
+	Active     bool   `json:"active"`
+	ClickCount int    `json:"click_count"`
 }
 
 type TotalCustomLinkAnalyticResponse struct {
-	LinkID          int `json:"link_id"`
-	TotalClickCount int `json:"total_click_count"`
-	TotalViewCount  int `json:"total_view_count"`
+	LinkID          int                                 `json:"link_id"`
+	TotalClickCount int                                 `json:"total_click_count"`
+	TotalScanCount  int                                 `json:"total_scan_count"`
+	TotalViewCount  int                                 `json:"total_view_count"`
+	Variants        []CustomLinkVariantAnalyticResponse `json:"variants,omitempty"`
+}
+
+type CustomLinkShortCodeAvaibilityResponse struct {
+	Code        string   `json:"code"`
+	Available   bool     `json:"available"`
+	Suggestions []string `json:"suggestions,omitempty"` // available alternatives, the closest first
+}
+
+type QRCodeResponse struct {
+	ContentType string
+	Data        []byte
+}
+
+type CustomLinkPositionResponse struct {
+	LinkID   uint `json:"link_id"`
+	Position int  `json:"position"`
+}
+
+type CustomLinkBatchResponse struct {
+	Action    string                          `json:"action"`
+	Succeeded int                             `json:"succeeded"`
+	Failed    int                             `json:"failed"`
+	Results   []CustomLinkBatchResultResponse `json:"results"`
+}
+
+type CustomLinkBatchResultResponse struct {
+	LinkID  uint   `json:"link_id"`
+	Status  string `json:"status"`
+	Message string `json:"message,omitempty"`
+}
+
+type TagCustomLinkAnalyticResponse struct {
+	TagID           uint   `json:"tag_id"`
+	Name            string `json:"name"`
+	LinkCount       int    `json:"link_count"`
+	TotalClickCount int    `json:"total_click_count"`
+	TotalViewCount  int    `json:"total_view_count"`
 }
 
 type CustomLinkAnalyticSummaryResponse struct {
 	CustomLink     []TotalCustomLinkAnalyticResponse `json:"link"`
+	Tag            []TagCustomLinkAnalyticResponse   `json:"tag"`
 	DeviceAnalytic DeviceAnalyticResponse            `json:"device_analytic"`
 	LastUpdated    time.Time                         `json:"last_updated"`
 }
@@ -13,6 +13,16 @@ type SocialMediaLinkUpdateRequest struct {
 	Activate          *bool  `json:"activate"`
 }
 
+type SocialMediaLinkGetAllRequest struct {
+	Search string `form:"search" binding:"omitempty,max=100"`
+	Limit  int    `form:"limit" binding:"omitempty,min=1,max=100"`
+	Cursor string `form:"cursor"`
+}
+
+type SocialMediaLinkReorderRequest struct {
+	TypeIDs []uint `json:"type_ids" binding:"required,min=1,unique"`
+}
+
 type SocialMediaLinkRedirectRequest struct {
 	Username        string `uri:"username" binding:"required"`
 	SocialMediaName string `uri:"social-media" binding:"required"`
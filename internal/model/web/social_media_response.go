@@ -14,9 +14,15 @@ type SocialMediaLinkResponse struct {
 	SocialMediaName string `json:"social_media"`
 	LinkOrUsername  string `json:"link_or_username"`
 	Activate        bool   `json:"activate"`
+	Position        int    `json:"position"`
 	RedirectLink    string `json:"redirect_link,omitempty"`
 }
 
+type SocialMediaLinkPositionResponse struct {
+	TypeID   uint `json:"type_id"`
+	Position int  `json:"position"`
+}
+
 type SocialMediaAnalyticResponse struct {
 	SocialMediaLinkID uint                   `json:"social_media_link_id"`
 	SocialMediaName   string                 `json:"social_media_name"`
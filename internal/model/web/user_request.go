@@ -22,10 +22,16 @@ type UserChangePasswordRequest struct {
 }
 
 type UserUpdateRequest struct {
-	FullName string `validate:"max=16" json:"full_name,omitempty"`
-	Bio      string `validate:"max=255" json:"bio,omitempty"`
+	FullName                 string `validate:"max=16" json:"full_name,omitempty"`
+	Bio                      string `validate:"max=255" json:"bio,omitempty"`
+	CaseInsensitiveShortCode *bool  `json:"case_insensitive_short_code,omitempty"` // /l/Promo and /l/promo open the same link
 }
 
 type UserProfileRequest struct {
 	Username string `uri:"username" binding:"required,alphanum"`
 }
+
+type UserProfileQRCodeRequest struct {
+	Username string `uri:"username" binding:"required,alphanum"`
+	QRCodeOptionRequest
+}
@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/ilhamfzri/pendek.in/app/logger"
+	"github.com/ilhamfzri/pendek.in/internal/model/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type CustomDomainRepositoryImpl struct {
+	Logger *logger.Logger
+}
+
+func NewCustomDomainRepository(logger *logger.Logger) CustomDomainRepository {
+	return &CustomDomainRepositoryImpl{
+		Logger: logger,
+	}
+}
+
+func (repository *CustomDomainRepositoryImpl) Create(ctx context.Context, tx *gorm.DB, customDomain domain.CustomDomain) (domain.CustomDomain, error) {
+	result := tx.WithContext(ctx).Create(&customDomain)
+	return customDomain, result.Error
+}
+
+func (repository *CustomDomainRepositoryImpl) UpdateVerifiedAt(ctx context.Context, tx *gorm.DB, customDomain domain.CustomDomain) (domain.CustomDomain, error) {
+	result := tx.WithContext(ctx).Model(&customDomain).Clauses(clause.Returning{}).Update("verified_at", customDomain.VerifiedAt)
+	return customDomain, result.Error
+}
+
+func (repository *CustomDomainRepositoryImpl) Delete(ctx context.Context, tx *gorm.DB, customDomain domain.CustomDomain) error {
+	result := tx.WithContext(ctx).Delete(&customDomain)
+	return result.Error
+}
+
+func (repository *CustomDomainRepositoryImpl) FindByIdAndUserID(ctx context.Context, tx *gorm.DB, id uint, userID string) (domain.CustomDomain, error) {
+	var customDomain domain.CustomDomain
+	result := tx.WithContext(ctx).Where("id = ? AND user_id = ?", id, userID).First(&customDomain)
+	return customDomain, result.Error
+}
+
+func (repository *CustomDomainRepositoryImpl) FindByNameAndUserID(ctx context.Context, tx *gorm.DB, name string, userID string) (domain.CustomDomain, error) {
+	var customDomain domain.CustomDomain
+	result := tx.WithContext(ctx).Where("name = ? AND user_id = ?", name, userID).First(&customDomain)
+	return customDomain, result.Error
+}
+
+func (repository *CustomDomainRepositoryImpl) FindVerifiedByName(ctx context.Context, tx *gorm.DB, name string) (domain.CustomDomain, error) {
+	var customDomain domain.CustomDomain
+	result := tx.WithContext(ctx).Where("name = ? AND verified_at IS NOT NULL", name).First(&customDomain)
+	return customDomain, result.Error
+}
+
+func (repository *CustomDomainRepositoryImpl) FetchAllByUserID(ctx context.Context, tx *gorm.DB, userID string) ([]domain.CustomDomain, error) {
+	var customDomains []domain.CustomDomain
+	result := tx.WithContext(ctx).Where("user_id = ?", userID).Order("name").Find(&customDomains)
+	return customDomains, result.Error
+}
+
+// CountLinksByID counts the links of the domain including the deleted ones, their short link codes are kept for it.
+func (repository *CustomDomainRepositoryImpl) CountLinksByID(ctx context.Context, tx *gorm.DB, id uint) (int64, error) {
+	var count int64
+	result := tx.WithContext(ctx).Model(&domain.CustomLink{}).Unscoped().Where("domain_id = ?", id).Count(&count)
+	return count, result.Error
+}
@@ -29,6 +29,23 @@ func (repository *CustomLinkAnalyticRepositoryImpl) Update(ctx context.Context,
 	return customLinkAnalytic, result.Error
 }
 
+func (repository *CustomLinkAnalyticRepositoryImpl) SumClickCountByLinkIDs(ctx context.Context, tx *gorm.DB, customLinkIDs []uint) (map[uint]int, error) {
+	var rows []struct {
+		CustomLinkID uint
+		Total        int
+	}
+	result := tx.WithContext(ctx).Model(&domain.CustomLinkAnalytic{}).
+		Select("custom_link_id, SUM(click_count) AS total").
+		Where("custom_link_id IN ?", customLinkIDs).
+		Group("custom_link_id").Scan(&rows)
+
+	totals := map[uint]int{}
+	for _, row := range rows {
+		totals[row.CustomLinkID] = row.Total
+	}
+	return totals, result.Error
+}
+
 func (repository *CustomLinkAnalyticRepositoryImpl) FindByLinkIDAndDate(ctx context.Context, tx *gorm.DB, customLinkID uint, date time.Time) (domain.CustomLinkAnalytic, error) {
 	var customLinkAnalytic domain.CustomLinkAnalytic
 	result := tx.WithContext(ctx).Preload("DeviceAnalytic").Where("custom_link_id = ? AND date = ?", customLinkID, date).First(&customLinkAnalytic)
@@ -32,3 +32,21 @@ func (repository *CustomLinkInteractionRepositoryImpl) FindByLinkIdAndDate(ctx c
 		Where("created_at BETWEEN ? AND ?", dateFirstRange, dateEndRange).Find(&customLinkInteractions)
 	return customLinkInteractions, result.Error
 }
+
+func (repository *CustomLinkInteractionRepositoryImpl) CountByLinkIDGroupByVariant(ctx context.Context, tx *gorm.DB, linkId uint, startTime time.Time, endTime time.Time) (map[uint]int, error) {
+	var rows []struct {
+		VariantID  uint
+		ClickCount int
+	}
+	result := tx.WithContext(ctx).Model(&domain.CustomLinkInteraction{}).
+		Select("variant_id, COUNT(*) AS click_count").
+		Where("custom_link_id = ? AND variant_id IS NOT NULL", linkId).
+		Where("created_at >= ? AND created_at < ?", startTime, endTime).
+		Group("variant_id").Scan(&rows)
+
+	clickCounts := make(map[uint]int)
+	for _, row := range rows {
+		clickCounts[row.VariantID] = row.ClickCount
+	}
+	return clickCounts, result.Error
+}
@@ -2,6 +2,9 @@ package repository
 
 import (
 	"context"
+	"fmt"
+	"strings"
+	"time"
 
 	"github.com/ilhamfzri/pendek.in/app/logger"
 	"github.com/ilhamfzri/pendek.in/internal/model/domain"
@@ -19,26 +22,111 @@ func NewCustomLinkRepository(logger *logger.Logger) CustomLinkRepository {
 	}
 }
 
+// Create puts the link after the last link of the user unless it has a position.
 func (repository *CustomLinkRepositoryImpl) Create(ctx context.Context, tx *gorm.DB, link domain.CustomLink) (domain.CustomLink, error) {
+	if link.Position == 0 {
+		result := tx.WithContext(ctx).Model(&domain.CustomLink{}).Unscoped().
+			Where("user_id = ?", link.UserID).Select("COALESCE(MAX(position), 0) + 1").Scan(&link.Position)
+		if result.Error != nil {
+			return link, result.Error
+		}
+	}
+
 	result := tx.WithContext(ctx).Create(&link)
 	return link, result.Error
 }
 
+// UpdatePositions numbers the links from 1 in the given order, the updated_at of the links is left untouched.
+func (repository *CustomLinkRepositoryImpl) UpdatePositions(ctx context.Context, tx *gorm.DB, userID string, linkIDs []uint) error {
+	for i, linkID := range linkIDs {
+		result := tx.WithContext(ctx).Model(&domain.CustomLink{}).
+			Where("id = ? AND user_id = ?", linkID, userID).UpdateColumn("position", i+1)
+		if result.Error != nil {
+			return result.Error
+		}
+	}
+	return nil
+}
+
 func (repository *CustomLinkRepositoryImpl) Update(ctx context.Context, tx *gorm.DB, link domain.CustomLink) (domain.CustomLink, error) {
 	result := tx.WithContext(ctx).Model(&link).Clauses(clause.Returning{}).
 		Select("*").
 		Updates(
 			map[string]interface{}{
-				"title":           link.Title,
-				"short_link_code": link.ShortLinkCode,
-				"long_link":       link.LongLink,
-				"show_on_profile": link.ShowOnProfile,
-				"activate":        link.Activate,
+				"title":                  link.Title,
+				"short_link_code":        link.ShortLinkCode,
+				"long_link":              link.LongLink,
+				"show_on_profile":        link.ShowOnProfile,
+				"activate":               link.Activate,
+				"redirect_status":        link.RedirectStatus,
+				"forward_query":          link.ForwardQuery,
+				"interstitial":           link.Interstitial,
+				"expires_at":             link.ExpiresAt,
+				"max_clicks":             link.MaxClicks,
+				"password":               link.Password,
+				"active_from":            link.ActiveFrom,
+				"active_until":           link.ActiveUntil,
+				"utm_source":             link.Utm.Source,
+				"utm_medium":             link.Utm.Medium,
+				"utm_campaign":           link.Utm.Campaign,
+				"utm_term":               link.Utm.Term,
+				"utm_content":            link.Utm.Content,
+				"folder_id":              link.FolderID,
+				"domain_id":              link.DomainID,
+				"og_title":               link.OgTitle,
+				"og_description":         link.OgDescription,
+				"og_custom_thumbnail_id": link.OgCustomThumbnailID,
+			},
+		)
+	return link, result.Error
+}
+
+func (repository *CustomLinkRepositoryImpl) Delete(ctx context.Context, tx *gorm.DB, link domain.CustomLink) error {
+	result := tx.WithContext(ctx).Delete(&link)
+	return result.Error
+}
+
+func (repository *CustomLinkRepositoryImpl) Restore(ctx context.Context, tx *gorm.DB, link domain.CustomLink) (domain.CustomLink, error) {
+	result := tx.WithContext(ctx).Unscoped().Model(&link).Clauses(clause.Returning{}).
+		Select("deleted_at", "short_link_code", "released_short_link_code").
+		Updates(
+			map[string]interface{}{
+				"deleted_at":               nil,
+				"short_link_code":          link.ShortLinkCode,
+				"released_short_link_code": link.ReleasedShortLinkCode,
 			},
 		)
 	return link, result.Error
 }
 
+// ReleaseShortLinkCode moves the short link code of a deleted link into released_short_link_code
+// and replaces it with a placeholder that can never pass request validation, so the code can be registered again.
+func (repository *CustomLinkRepositoryImpl) ReleaseShortLinkCode(ctx context.Context, tx *gorm.DB, link domain.CustomLink) (domain.CustomLink, error) {
+	result := tx.WithContext(ctx).Unscoped().Model(&link).Clauses(clause.Returning{}).
+		Select("short_link_code", "released_short_link_code").
+		Updates(
+			map[string]interface{}{
+				"short_link_code":          fmt.Sprintf("~%d", link.ID),
+				"released_short_link_code": link.ShortLinkCode,
+			},
+		)
+	return link, result.Error
+}
+
+// ReleaseShortLinkCodesDeletedBefore releases the short link codes of every link deleted at or before deletedBefore
+// the way ReleaseShortLinkCode does, codes released already are skipped. It returns the number of released codes.
+func (repository *CustomLinkRepositoryImpl) ReleaseShortLinkCodesDeletedBefore(ctx context.Context, tx *gorm.DB, deletedBefore time.Time) (int64, error) {
+	result := tx.WithContext(ctx).Unscoped().Model(&domain.CustomLink{}).
+		Where("deleted_at <= ? AND short_link_code NOT LIKE ?", deletedBefore, "~%").
+		UpdateColumns(
+			map[string]interface{}{
+				"released_short_link_code": gorm.Expr("short_link_code"),
+				"short_link_code":          gorm.Expr("'~' || id"),
+			},
+		)
+	return result.RowsAffected, result.Error
+}
+
 func (repository *CustomLinkRepositoryImpl) UpdateThumbnailIDFK(ctx context.Context, tx *gorm.DB, linkID uint, thumbnailID *uint) (domain.CustomLink, error) {
 	customLink := domain.CustomLink{}
 	result := tx.WithContext(ctx).Model(&customLink).Clauses(clause.Returning{}).
@@ -63,20 +151,223 @@ func (repository *CustomLinkRepositoryImpl) UpdateCustomThumbnailIDFK(ctx contex
 	return customLink, result.Error
 }
 
-func (repository *CustomLinkRepositoryImpl) FindByShortLinkCode(ctx context.Context, tx *gorm.DB, shortLinkCode string) (domain.CustomLink, error) {
+// FindByShortLinkCode finds the link of the short link code on the domain, a nil domain is the shared domain.
+// Case insensitive links match the code in any case, a case sensitive link with the exact code wins.
+func (repository *CustomLinkRepositoryImpl) FindByShortLinkCode(ctx context.Context, tx *gorm.DB, domainID *uint, shortLinkCode string) (domain.CustomLink, error) {
 	var link domain.CustomLink
-	result := tx.WithContext(ctx).Where("short_link_code = ?", shortLinkCode).First(&link)
+	result := whereDomainID(tx.WithContext(ctx), domainID).
+		Where("short_link_code = ? OR (case_insensitive AND LOWER(short_link_code) = LOWER(?))", shortLinkCode, shortLinkCode).
+		Order("case_insensitive").
+		First(&link)
 	return link, result.Error
 }
 
+// FetchAllByShortLinkCodeUnscoped fetches every link holding the short link code on the domain including the deleted ones,
+// codes differing only in case are included when either side is case insensitive.
+func (repository *CustomLinkRepositoryImpl) FetchAllByShortLinkCodeUnscoped(ctx context.Context, tx *gorm.DB, domainID *uint, shortLinkCode string, caseInsensitive bool) ([]domain.CustomLink, error) {
+	var links []domain.CustomLink
+	result := whereDomainID(tx.WithContext(ctx).Unscoped(), domainID).
+		Where("short_link_code = ? OR ((case_insensitive OR ?) AND LOWER(short_link_code) = LOWER(?))", shortLinkCode, caseInsensitive, shortLinkCode).
+		Find(&links)
+	return links, result.Error
+}
+
+// CountCaseConflictsByUserID counts the links of the user whose short link code differs only in case
+// from another link on the same domain.
+func (repository *CustomLinkRepositoryImpl) CountCaseConflictsByUserID(ctx context.Context, tx *gorm.DB, userID string) (int64, error) {
+	var count int64
+	result := tx.WithContext(ctx).Table("custom_links AS a").
+		Joins("JOIN custom_links AS b ON b.id <> a.id AND COALESCE(b.domain_id, 0) = COALESCE(a.domain_id, 0) AND LOWER(b.short_link_code) = LOWER(a.short_link_code)").
+		Where("a.user_id = ?", userID).Count(&count)
+	return count, result.Error
+}
+
+// UpdateCaseInsensitiveByUserID applies the case insensitive short code option of the user to all of their links, deleted ones included.
+func (repository *CustomLinkRepositoryImpl) UpdateCaseInsensitiveByUserID(ctx context.Context, tx *gorm.DB, userID string, caseInsensitive bool) error {
+	result := tx.WithContext(ctx).Unscoped().Model(&domain.CustomLink{}).
+		Where("user_id = ?", userID).UpdateColumn("case_insensitive", caseInsensitive)
+	return result.Error
+}
+
+// LockShortLinkCode locks the short link code on the domain until the transaction ends, every case of the code
+// shares the lock. Writers take it before checking the code so two transactions can't both see the code as free.
+func (repository *CustomLinkRepositoryImpl) LockShortLinkCode(ctx context.Context, tx *gorm.DB, domainID *uint, shortLinkCode string) error {
+	var lockDomainID uint
+	if domainID != nil {
+		lockDomainID = *domainID
+	}
+
+	result := tx.WithContext(ctx).Exec("SELECT pg_advisory_xact_lock(CAST(? AS integer), hashtext(LOWER(?)))", lockDomainID, shortLinkCode)
+	return result.Error
+}
+
+// LockShortLinkCodesByUserID takes the lock of LockShortLinkCode for every link of the user, deleted ones included.
+// The locks are taken in a fixed order so two of these calls can't deadlock.
+func (repository *CustomLinkRepositoryImpl) LockShortLinkCodesByUserID(ctx context.Context, tx *gorm.DB, userID string) error {
+	result := tx.WithContext(ctx).Exec("SELECT pg_advisory_xact_lock(lock_key.domain_id, lock_key.code) FROM ("+
+		"SELECT DISTINCT CAST(COALESCE(domain_id, 0) AS integer) AS domain_id, hashtext(LOWER(short_link_code)) AS code "+
+		"FROM custom_links WHERE user_id = ? ORDER BY 1, 2) AS lock_key", userID)
+	return result.Error
+}
+
+func whereDomainID(query *gorm.DB, domainID *uint) *gorm.DB {
+	if domainID == nil {
+		return query.Where("domain_id IS NULL")
+	}
+	return query.Where("domain_id = ?", *domainID)
+}
+
 func (repository *CustomLinkRepositoryImpl) FindByIdAndUserID(ctx context.Context, tx *gorm.DB, id int, userID string) (domain.CustomLink, error) {
 	var link domain.CustomLink
-	result := tx.WithContext(ctx).Preload("CustomThumbnail").Preload("Thumbnail").Where("id = ? AND user_id = ?", id, userID).First(&link)
+	result := tx.WithContext(ctx).Preload("CustomThumbnail").Preload("Thumbnail").Preload("Tags").Preload("Domain").Where("id = ? AND user_id = ?", id, userID).First(&link)
+	return link, result.Error
+}
+
+// FindByIdAndUserIDForUpdate locks the row of the link until the end of the transaction, changes of the same link
+// wait for each other.
+func (repository *CustomLinkRepositoryImpl) FindByIdAndUserIDForUpdate(ctx context.Context, tx *gorm.DB, id int, userID string) (domain.CustomLink, error) {
+	var link domain.CustomLink
+	result := tx.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).
+		Preload("CustomThumbnail").Preload("Thumbnail").Preload("Tags").Preload("Domain").Where("id = ? AND user_id = ?", id, userID).First(&link)
+	return link, result.Error
+}
+
+func (repository *CustomLinkRepositoryImpl) FindByIdAndUserIDUnscoped(ctx context.Context, tx *gorm.DB, id int, userID string) (domain.CustomLink, error) {
+	var link domain.CustomLink
+	result := tx.WithContext(ctx).Unscoped().Preload("CustomThumbnail").Preload("Thumbnail").Preload("Tags").Preload("Domain").Where("id = ? AND user_id = ?", id, userID).First(&link)
+	return link, result.Error
+}
+
+func (repository *CustomLinkRepositoryImpl) FindDeletedByIdAndUserID(ctx context.Context, tx *gorm.DB, id int, userID string) (domain.CustomLink, error) {
+	var link domain.CustomLink
+	result := tx.WithContext(ctx).Unscoped().Preload("CustomThumbnail").Preload("Thumbnail").Preload("Tags").Preload("Domain").
+		Where("id = ? AND user_id = ? AND deleted_at IS NOT NULL", id, userID).First(&link)
 	return link, result.Error
 }
 
 func (repository *CustomLinkRepositoryImpl) FetchAllByUserID(ctx context.Context, tx *gorm.DB, userID string) ([]domain.CustomLink, error) {
 	var links []domain.CustomLink
-	result := tx.WithContext(ctx).Preload("CustomThumbnail").Preload("Thumbnail").Where("user_id = ?", userID).Order("id ASC").Find(&links)
+	result := tx.WithContext(ctx).Preload("CustomThumbnail").Preload("Thumbnail").Preload("Tags").Preload("Domain").Where("user_id = ?", userID).Order("position ASC, id ASC").Find(&links)
 	return links, result.Error
 }
+
+// FetchAllByUserIDInBatches walks every link of the user in id order, only one batch is kept in memory at a time.
+func (repository *CustomLinkRepositoryImpl) FetchAllByUserIDInBatches(ctx context.Context, tx *gorm.DB, userID string, batchSize int, fn func(links []domain.CustomLink) error) error {
+	var links []domain.CustomLink
+	result := tx.WithContext(ctx).Preload("CustomThumbnail").Preload("Thumbnail").Preload("Tags").Preload("Domain").Where("user_id = ?", userID).
+		FindInBatches(&links, batchSize, func(batchTx *gorm.DB, batch int) error {
+			return fn(links)
+		})
+	return result.Error
+}
+
+func (repository *CustomLinkRepositoryImpl) FetchAllDeletedByUserID(ctx context.Context, tx *gorm.DB, userID string) ([]domain.CustomLink, error) {
+	var links []domain.CustomLink
+	result := tx.WithContext(ctx).Unscoped().Preload("CustomThumbnail").Preload("Thumbnail").Preload("Tags").Preload("Domain").
+		Where("user_id = ? AND deleted_at IS NOT NULL", userID).Order("deleted_at DESC").Find(&links)
+	return links, result.Error
+}
+
+// FetchAllActiveInBatches walks every activated link of all users in id order for the health check.
+func (repository *CustomLinkRepositoryImpl) FetchAllActiveInBatches(ctx context.Context, tx *gorm.DB, batchSize int, fn func(links []domain.CustomLink) error) error {
+	var links []domain.CustomLink
+	result := tx.WithContext(ctx).Where("activate").
+		FindInBatches(&links, batchSize, func(batchTx *gorm.DB, batch int) error {
+			return fn(links)
+		})
+	return result.Error
+}
+
+// UpdateHealth stores the result of a health check without touching updated_at, a check isn't an edit of the link.
+func (repository *CustomLinkRepositoryImpl) UpdateHealth(ctx context.Context, tx *gorm.DB, linkID uint, health domain.LinkHealth) error {
+	result := tx.WithContext(ctx).Model(&domain.CustomLink{}).Where("id = ?", linkID).
+		Select("health_status_code", "health_latency_ms", "health_redirect_chain", "health_error", "health_failure_count", "health_broken", "health_checked_at", "health_notified_at").
+		UpdateColumns(domain.CustomLink{Health: health})
+	return result.Error
+}
+
+// ClaimClick counts a click of the link, false when the link has already reached its click limit. The check and the
+// increment are one statement so concurrent visits can't go over the limit.
+func (repository *CustomLinkRepositoryImpl) ClaimClick(ctx context.Context, tx *gorm.DB, linkID uint) (bool, error) {
+	result := tx.WithContext(ctx).Model(&domain.CustomLink{}).
+		Where("id = ? AND (max_clicks IS NULL OR click_count < max_clicks)", linkID).
+		UpdateColumn("click_count", gorm.Expr("click_count + 1"))
+	return result.RowsAffected == 1, result.Error
+}
+
+func (repository *CustomLinkRepositoryImpl) FetchPageByUserIDAndFilter(ctx context.Context, tx *gorm.DB, userID string, filter CustomLinkFilter, page CustomLinkPage) ([]domain.CustomLink, error) {
+	var links []domain.CustomLink
+	query := tx.WithContext(ctx).Preload("CustomThumbnail").Preload("Thumbnail").Preload("Tags").Preload("Domain").Where("user_id = ?", userID)
+
+	if filter.TagID != 0 {
+		query = query.Where("id IN (?)", tx.Table("custom_link_tags").Select("custom_link_id").Where("tag_id = ?", filter.TagID))
+	}
+
+	if len(filter.FolderIDs) > 0 {
+		query = query.Where("folder_id IN ?", filter.FolderIDs)
+	}
+
+	if filter.Search != "" {
+		pattern := "%" + escapeLike(filter.Search) + "%"
+		query = query.Where("(title ILIKE ? OR short_link_code ILIKE ? OR long_link ILIKE ?)", pattern, pattern, pattern)
+	}
+
+	if filter.Broken {
+		query = query.Where("health_broken")
+	}
+
+	sortColumn := customLinkSortColumns[page.Sort]
+	if sortColumn == "" {
+		sortColumn = customLinkSortColumns[CustomLinkSortCreated]
+	}
+
+	direction, operator := "ASC", ">"
+	if page.Desc {
+		direction, operator = "DESC", "<"
+	}
+
+	if page.CursorValue != nil {
+		query = query.Where(
+			fmt.Sprintf("(%[1]s %[2]s ? OR (%[1]s = ? AND custom_links.id %[2]s ?))", sortColumn, operator),
+			page.CursorValue, page.CursorValue, page.CursorID,
+		)
+	}
+
+	// It's fetching one more row than the limit so the caller knows there is a next page.
+	result := query.Order(sortColumn + " " + direction).Order("custom_links.id " + direction).Limit(page.Limit + 1).Find(&links)
+	return links, result.Error
+}
+
+var customLinkSortColumns = map[string]string{
+	CustomLinkSortCreated:  "custom_links.created_at",
+	CustomLinkSortUpdated:  "custom_links.updated_at",
+	CustomLinkSortTitle:    "custom_links.title",
+	CustomLinkSortPosition: "custom_links.position",
+	CustomLinkSortClicks:   "custom_links.click_count",
+}
+
+// escapeLike makes the wildcards of a search term match literally.
+func escapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(value)
+}
+
+func (repository *CustomLinkRepositoryImpl) FetchAllByIDsAndUserID(ctx context.Context, tx *gorm.DB, linkIDs []uint, userID string) ([]domain.CustomLink, error) {
+	var links []domain.CustomLink
+	result := tx.WithContext(ctx).Where("id IN ? AND user_id = ?", linkIDs, userID).Order("id ASC").Find(&links)
+	return links, result.Error
+}
+
+// UpdateColumnsByIDsAndUserID sets the same values on every listed link of the user in one statement.
+func (repository *CustomLinkRepositoryImpl) UpdateColumnsByIDsAndUserID(ctx context.Context, tx *gorm.DB, linkIDs []uint, userID string, values map[string]interface{}) error {
+	result := tx.WithContext(ctx).Model(&domain.CustomLink{}).Where("id IN ? AND user_id = ?", linkIDs, userID).Updates(values)
+	return result.Error
+}
+
+func (repository *CustomLinkRepositoryImpl) DeleteByIDsAndUserID(ctx context.Context, tx *gorm.DB, linkIDs []uint, userID string) error {
+	result := tx.WithContext(ctx).Where("id IN ? AND user_id = ?", linkIDs, userID).Delete(&domain.CustomLink{})
+	return result.Error
+}
+
+func (repository *CustomLinkRepositoryImpl) ReplaceTags(ctx context.Context, tx *gorm.DB, link domain.CustomLink, tags []domain.Tag) error {
+	return tx.WithContext(ctx).Model(&link).Association("Tags").Replace(tags)
+}
@@ -0,0 +1,210 @@
+package repository
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func newRepositoryMock(t *testing.T) (*gorm.DB, sqlmock.Sqlmock) {
+	sqlDB, sqlMock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { sqlDB.Close() })
+
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return db, sqlMock
+}
+
+func TestCustomLinkRepositoryFetchPageByUserIDAndFilter(t *testing.T) {
+	repository := NewCustomLinkRepository(nil)
+	cursorTime := time.Date(2022, 11, 1, 10, 0, 0, 123456000, time.UTC)
+
+	tests := []struct {
+		TestName      string
+		Page          CustomLinkPage
+		QueryExpected string
+	}{
+		{TestName: "[Created][Asc]",
+			Page:          CustomLinkPage{Sort: CustomLinkSortCreated, Limit: 2, CursorValue: cursorTime, CursorID: 3},
+			QueryExpected: `WHERE user_id = $1 AND ((custom_links.created_at > $2 OR (custom_links.created_at = $3 AND custom_links.id > $4))) AND "custom_links"."deleted_at" IS NULL ORDER BY custom_links.created_at ASC,custom_links.id ASC LIMIT 3`,
+		},
+		{TestName: "[Updated][Desc]",
+			Page:          CustomLinkPage{Sort: CustomLinkSortUpdated, Desc: true, Limit: 2, CursorValue: cursorTime, CursorID: 3},
+			QueryExpected: `WHERE user_id = $1 AND ((custom_links.updated_at < $2 OR (custom_links.updated_at = $3 AND custom_links.id < $4))) AND "custom_links"."deleted_at" IS NULL ORDER BY custom_links.updated_at DESC,custom_links.id DESC LIMIT 3`,
+		},
+		{TestName: "[Title][Asc]",
+			Page:          CustomLinkPage{Sort: CustomLinkSortTitle, Limit: 2, CursorValue: "beta", CursorID: 3},
+			QueryExpected: `WHERE user_id = $1 AND ((custom_links.title > $2 OR (custom_links.title = $3 AND custom_links.id > $4))) AND "custom_links"."deleted_at" IS NULL ORDER BY custom_links.title ASC,custom_links.id ASC LIMIT 3`,
+		},
+		{TestName: "[Clicks][Desc]",
+			Page:          CustomLinkPage{Sort: CustomLinkSortClicks, Desc: true, Limit: 2, CursorValue: 5, CursorID: 3},
+			QueryExpected: `WHERE user_id = $1 AND ((custom_links.click_count < $2 OR (custom_links.click_count = $3 AND custom_links.id < $4))) AND "custom_links"."deleted_at" IS NULL ORDER BY custom_links.click_count DESC,custom_links.id DESC LIMIT 3`,
+		},
+		{TestName: "[Position][Asc]",
+			Page:          CustomLinkPage{Sort: CustomLinkSortPosition, Limit: 2, CursorValue: 2, CursorID: 3},
+			QueryExpected: `WHERE user_id = $1 AND ((custom_links.position > $2 OR (custom_links.position = $3 AND custom_links.id > $4))) AND "custom_links"."deleted_at" IS NULL ORDER BY custom_links.position ASC,custom_links.id ASC LIMIT 3`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.TestName, func(t *testing.T) {
+			db, sqlMock := newRepositoryMock(t)
+
+			// The sort value of the cursor is compared twice, the id only breaks its ties.
+			sqlMock.ExpectQuery(regexp.QuoteMeta(test.QueryExpected)).
+				WithArgs("123456", test.Page.CursorValue, test.Page.CursorValue, test.Page.CursorID).
+				WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+			links, err := repository.FetchPageByUserIDAndFilter(context.Background(), db, "123456", CustomLinkFilter{}, test.Page)
+			assert.Nil(t, err)
+			assert.Empty(t, links)
+			assert.Nil(t, sqlMock.ExpectationsWereMet())
+		})
+	}
+
+	t.Run("[First Page]", func(t *testing.T) {
+		db, sqlMock := newRepositoryMock(t)
+
+		sqlMock.ExpectQuery(regexp.QuoteMeta(`WHERE user_id = $1 AND "custom_links"."deleted_at" IS NULL ORDER BY custom_links.created_at ASC,custom_links.id ASC LIMIT 3`)).
+			WithArgs("123456").
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		_, err := repository.FetchPageByUserIDAndFilter(context.Background(), db, "123456", CustomLinkFilter{}, CustomLinkPage{Limit: 2})
+		assert.Nil(t, err)
+		assert.Nil(t, sqlMock.ExpectationsWereMet())
+	})
+}
+
+func TestCustomLinkRepositoryShortLinkCode(t *testing.T) {
+	repository := NewCustomLinkRepository(nil)
+	domainID := uint(4)
+
+	t.Run("[Lock Then Fetch Mixed Case Links]", func(t *testing.T) {
+		db, sqlMock := newRepositoryMock(t)
+
+		// A case sensitive "Promo" asks for every case when the other link is case insensitive.
+		sqlMock.ExpectBegin()
+		sqlMock.ExpectExec(regexp.QuoteMeta(`SELECT pg_advisory_xact_lock(CAST($1 AS integer), hashtext(LOWER($2)))`)).
+			WithArgs(domainID, "Promo").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		sqlMock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "custom_links" WHERE domain_id = $1 AND (short_link_code = $2 OR ((case_insensitive OR $3) AND LOWER(short_link_code) = LOWER($4)))`)).
+			WithArgs(domainID, "Promo", false, "Promo").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "short_link_code", "case_insensitive"}).
+				AddRow(1, "promo", true).
+				AddRow(2, "Promo", false))
+		sqlMock.ExpectCommit()
+
+		tx := db.Begin()
+		assert.Nil(t, repository.LockShortLinkCode(context.Background(), tx, &domainID, "Promo"))
+		links, err := repository.FetchAllByShortLinkCodeUnscoped(context.Background(), tx, &domainID, "Promo", false)
+		assert.Nil(t, err)
+		assert.Nil(t, tx.Commit().Error)
+
+		assert.Len(t, links, 2)
+		assert.True(t, links[0].CaseInsensitive)
+		assert.False(t, links[1].CaseInsensitive)
+		assert.Nil(t, sqlMock.ExpectationsWereMet())
+	})
+
+	t.Run("[Lock Shared Domain]", func(t *testing.T) {
+		db, sqlMock := newRepositoryMock(t)
+
+		sqlMock.ExpectExec(regexp.QuoteMeta(`SELECT pg_advisory_xact_lock(CAST($1 AS integer), hashtext(LOWER($2)))`)).
+			WithArgs(uint(0), "promo").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		sqlMock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "custom_links" WHERE domain_id IS NULL AND (short_link_code = $1 OR ((case_insensitive OR $2) AND LOWER(short_link_code) = LOWER($3)))`)).
+			WithArgs("promo", true, "promo").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "short_link_code", "case_insensitive"}).
+				AddRow(2, "Promo", false))
+
+		assert.Nil(t, repository.LockShortLinkCode(context.Background(), db, nil, "promo"))
+		links, err := repository.FetchAllByShortLinkCodeUnscoped(context.Background(), db, nil, "promo", true)
+		assert.Nil(t, err)
+		assert.Len(t, links, 1)
+		assert.Nil(t, sqlMock.ExpectationsWereMet())
+	})
+
+	t.Run("[Lock Codes Of User]", func(t *testing.T) {
+		db, sqlMock := newRepositoryMock(t)
+
+		sqlMock.ExpectExec(regexp.QuoteMeta(`SELECT pg_advisory_xact_lock(lock_key.domain_id, lock_key.code) FROM (SELECT DISTINCT CAST(COALESCE(domain_id, 0) AS integer) AS domain_id, hashtext(LOWER(short_link_code)) AS code FROM custom_links WHERE user_id = $1 ORDER BY 1, 2) AS lock_key`)).
+			WithArgs("123456").
+			WillReturnResult(sqlmock.NewResult(0, 3))
+
+		assert.Nil(t, repository.LockShortLinkCodesByUserID(context.Background(), db, "123456"))
+		assert.Nil(t, sqlMock.ExpectationsWereMet())
+	})
+}
+
+func TestCustomLinkRepositoryReleaseShortLinkCodesDeletedBefore(t *testing.T) {
+	repository := NewCustomLinkRepository(nil)
+	db, sqlMock := newRepositoryMock(t)
+	deletedBefore := time.Date(2022, 11, 1, 10, 0, 0, 0, time.UTC)
+
+	// The released code keeps the old value of short_link_code, postgres evaluates every assignment on the old row.
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectExec(regexp.QuoteMeta(`UPDATE "custom_links" SET "released_short_link_code"=short_link_code,"short_link_code"='~' || id WHERE deleted_at <= $1 AND short_link_code NOT LIKE $2`)).
+		WithArgs(deletedBefore, "~%").
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	sqlMock.ExpectCommit()
+
+	released, err := repository.ReleaseShortLinkCodesDeletedBefore(context.Background(), db, deletedBefore)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(2), released)
+	assert.Nil(t, sqlMock.ExpectationsWereMet())
+}
+
+func TestCustomLinkRepositoryClaimClick(t *testing.T) {
+	repository := NewCustomLinkRepository(nil)
+	query := `UPDATE "custom_links" SET "click_count"=click_count + 1 WHERE (id = $1 AND (max_clicks IS NULL OR click_count < max_clicks)) AND "custom_links"."deleted_at" IS NULL`
+
+	tests := []struct {
+		TestName     string
+		RowsAffected int64
+		Expected     bool
+	}{
+		{TestName: "[Claimed]", RowsAffected: 1, Expected: true},
+		{TestName: "[Limit Reached]", RowsAffected: 0, Expected: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.TestName, func(t *testing.T) {
+			db, sqlMock := newRepositoryMock(t)
+
+			sqlMock.ExpectBegin()
+			sqlMock.ExpectExec(regexp.QuoteMeta(query)).
+				WithArgs(uint(21)).
+				WillReturnResult(sqlmock.NewResult(0, test.RowsAffected))
+			sqlMock.ExpectCommit()
+
+			claimed, err := repository.ClaimClick(context.Background(), db, 21)
+			assert.Nil(t, err)
+			assert.Equal(t, test.Expected, claimed)
+			assert.Nil(t, sqlMock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestCustomLinkRepositoryFindByIdAndUserIDForUpdate(t *testing.T) {
+	repository := NewCustomLinkRepository(nil)
+	db, sqlMock := newRepositoryMock(t)
+
+	sqlMock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "custom_links" WHERE (id = $1 AND user_id = $2) AND "custom_links"."deleted_at" IS NULL ORDER BY "custom_links"."id" LIMIT 1 FOR UPDATE`)).
+		WithArgs(21, "123456").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	_, err := repository.FindByIdAndUserIDForUpdate(context.Background(), db, 21, "123456")
+	assert.Equal(t, gorm.ErrRecordNotFound, err)
+	assert.Nil(t, sqlMock.ExpectationsWereMet())
+}
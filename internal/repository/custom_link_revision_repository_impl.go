@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/ilhamfzri/pendek.in/app/logger"
+	"github.com/ilhamfzri/pendek.in/internal/model/domain"
+	"gorm.io/gorm"
+)
+
+type CustomLinkRevisionRepositoryImpl struct {
+	Logger *logger.Logger
+}
+
+func NewCustomLinkRevisionRepository(logger *logger.Logger) CustomLinkRevisionRepository {
+	return &CustomLinkRevisionRepositoryImpl{
+		Logger: logger,
+	}
+}
+
+// Create numbers the revision after the last revision of the link, the caller holds the lock of the link row
+// so concurrent changes of the link can't take the same number.
+func (repository *CustomLinkRevisionRepositoryImpl) Create(ctx context.Context, tx *gorm.DB, revision domain.CustomLinkRevision) (domain.CustomLinkRevision, error) {
+	result := tx.WithContext(ctx).Model(&domain.CustomLinkRevision{}).
+		Where("custom_link_id = ?", revision.CustomLinkID).Select("COALESCE(MAX(revision), 0) + 1").Scan(&revision.Revision)
+	if result.Error != nil {
+		return revision, result.Error
+	}
+
+	result = tx.WithContext(ctx).Create(&revision)
+	return revision, result.Error
+}
+
+func (repository *CustomLinkRevisionRepositoryImpl) FetchAllByLinkID(ctx context.Context, tx *gorm.DB, linkID uint) ([]domain.CustomLinkRevision, error) {
+	var revisions []domain.CustomLinkRevision
+	result := tx.WithContext(ctx).Where("custom_link_id = ?", linkID).Order("revision").Find(&revisions)
+	return revisions, result.Error
+}
+
+func (repository *CustomLinkRevisionRepositoryImpl) FindByLinkIDAndRevision(ctx context.Context, tx *gorm.DB, linkID uint, revision uint) (domain.CustomLinkRevision, error) {
+	var linkRevision domain.CustomLinkRevision
+	result := tx.WithContext(ctx).Where("custom_link_id = ? AND revision = ?", linkID, revision).First(&linkRevision)
+	return linkRevision, result.Error
+}
+
+func (repository *CustomLinkRevisionRepositoryImpl) FindLatestByLinkID(ctx context.Context, tx *gorm.DB, linkID uint) (domain.CustomLinkRevision, error) {
+	var linkRevision domain.CustomLinkRevision
+	result := tx.WithContext(ctx).Where("custom_link_id = ?", linkID).Order("revision DESC").First(&linkRevision)
+	return linkRevision, result.Error
+}
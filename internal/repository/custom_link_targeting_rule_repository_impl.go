@@ -0,0 +1,40 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/ilhamfzri/pendek.in/app/logger"
+	"github.com/ilhamfzri/pendek.in/internal/model/domain"
+	"gorm.io/gorm"
+)
+
+type CustomLinkTargetingRuleRepositoryImpl struct {
+	Logger *logger.Logger
+}
+
+func NewCustomLinkTargetingRuleRepository(logger *logger.Logger) CustomLinkTargetingRuleRepository {
+	return &CustomLinkTargetingRuleRepositoryImpl{
+		Logger: logger,
+	}
+}
+
+func (repository *CustomLinkTargetingRuleRepositoryImpl) FetchAllByLinkID(ctx context.Context, tx *gorm.DB, linkID uint) ([]domain.CustomLinkTargetingRule, error) {
+	var rules []domain.CustomLinkTargetingRule
+	result := tx.WithContext(ctx).Where("custom_link_id = ?", linkID).Order("position").Find(&rules)
+	return rules, result.Error
+}
+
+func (repository *CustomLinkTargetingRuleRepositoryImpl) ReplaceByLinkID(ctx context.Context, tx *gorm.DB, linkID uint, rules []domain.CustomLinkTargetingRule) ([]domain.CustomLinkTargetingRule, error) {
+	result := tx.WithContext(ctx).Unscoped().Where("custom_link_id = ?", linkID).Delete(&domain.CustomLinkTargetingRule{})
+	if result.Error != nil || len(rules) == 0 {
+		return nil, result.Error
+	}
+
+	for i := range rules {
+		rules[i].CustomLinkID = linkID
+		rules[i].Position = i
+	}
+
+	result = tx.WithContext(ctx).Create(&rules)
+	return rules, result.Error
+}
@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/ilhamfzri/pendek.in/app/logger"
+	"github.com/ilhamfzri/pendek.in/internal/model/domain"
+	"gorm.io/gorm"
+)
+
+type CustomLinkVariantRepositoryImpl struct {
+	Logger *logger.Logger
+}
+
+func NewCustomLinkVariantRepository(logger *logger.Logger) CustomLinkVariantRepository {
+	return &CustomLinkVariantRepositoryImpl{
+		Logger: logger,
+	}
+}
+
+func (repository *CustomLinkVariantRepositoryImpl) FetchAllByLinkID(ctx context.Context, tx *gorm.DB, linkID uint) ([]domain.CustomLinkVariant, error) {
+	var variants []domain.CustomLinkVariant
+	result := tx.WithContext(ctx).Where("custom_link_id = ?", linkID).Order("position").Find(&variants)
+	return variants, result.Error
+}
+
+func (repository *CustomLinkVariantRepositoryImpl) FetchAllByLinkIDUnscoped(ctx context.Context, tx *gorm.DB, linkID uint) ([]domain.CustomLinkVariant, error) {
+	var variants []domain.CustomLinkVariant
+	result := tx.WithContext(ctx).Unscoped().Where("custom_link_id = ?", linkID).Order("id").Find(&variants)
+	return variants, result.Error
+}
+
+// ReplaceByLinkID soft deletes the current variants, so the clicks recorded for them stay attributable.
+func (repository *CustomLinkVariantRepositoryImpl) ReplaceByLinkID(ctx context.Context, tx *gorm.DB, linkID uint, variants []domain.CustomLinkVariant) ([]domain.CustomLinkVariant, error) {
+	result := tx.WithContext(ctx).Where("custom_link_id = ?", linkID).Delete(&domain.CustomLinkVariant{})
+	if result.Error != nil || len(variants) == 0 {
+		return nil, result.Error
+	}
+
+	for i := range variants {
+		variants[i].CustomLinkID = linkID
+		variants[i].Position = i
+	}
+
+	result = tx.WithContext(ctx).Create(&variants)
+	return variants, result.Error
+}
@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/ilhamfzri/pendek.in/app/logger"
+	"github.com/ilhamfzri/pendek.in/internal/model/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type FolderRepositoryImpl struct {
+	Logger *logger.Logger
+}
+
+func NewFolderRepository(logger *logger.Logger) FolderRepository {
+	return &FolderRepositoryImpl{
+		Logger: logger,
+	}
+}
+
+func (repository *FolderRepositoryImpl) Create(ctx context.Context, tx *gorm.DB, folder domain.Folder) (domain.Folder, error) {
+	result := tx.WithContext(ctx).Create(&folder)
+	return folder, result.Error
+}
+
+func (repository *FolderRepositoryImpl) Update(ctx context.Context, tx *gorm.DB, folder domain.Folder) (domain.Folder, error) {
+	result := tx.WithContext(ctx).Model(&folder).Clauses(clause.Returning{}).
+		Updates(
+			map[string]interface{}{
+				"name":      folder.Name,
+				"parent_id": folder.ParentID,
+			},
+		)
+	return folder, result.Error
+}
+
+// Delete moves the subfolders and the links of the folder, including the ones in trash, up to its parent before deleting it.
+func (repository *FolderRepositoryImpl) Delete(ctx context.Context, tx *gorm.DB, folder domain.Folder) error {
+	result := tx.WithContext(ctx).Model(&domain.Folder{}).Where("parent_id = ?", folder.ID).Update("parent_id", folder.ParentID)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	result = tx.WithContext(ctx).Unscoped().Model(&domain.CustomLink{}).Where("folder_id = ?", folder.ID).Update("folder_id", folder.ParentID)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	result = tx.WithContext(ctx).Delete(&folder)
+	return result.Error
+}
+
+func (repository *FolderRepositoryImpl) FindByIdAndUserID(ctx context.Context, tx *gorm.DB, id uint, userID string) (domain.Folder, error) {
+	var folder domain.Folder
+	result := tx.WithContext(ctx).Where("id = ? AND user_id = ?", id, userID).First(&folder)
+	return folder, result.Error
+}
+
+func (repository *FolderRepositoryImpl) FetchAllByUserID(ctx context.Context, tx *gorm.DB, userID string) ([]domain.Folder, error) {
+	var folders []domain.Folder
+	result := tx.WithContext(ctx).Where("user_id = ?", userID).Order("name").Find(&folders)
+	return folders, result.Error
+}
@@ -81,6 +81,29 @@ func (_m *SocialMediaLinkRepository) FindByUserID(ctx context.Context, tx *gorm.
 	return r0, r1
 }
 
+// FindPageByUserID provides a mock function with given fields: ctx, tx, userId, search, afterTypeId, limit
+func (_m *SocialMediaLinkRepository) FindPageByUserID(ctx context.Context, tx *gorm.DB, userId string, search string, afterTypeId uint, limit int) ([]domain.SocialMediaLink, error) {
+	ret := _m.Called(ctx, tx, userId, search, afterTypeId, limit)
+
+	var r0 []domain.SocialMediaLink
+	if rf, ok := ret.Get(0).(func(context.Context, *gorm.DB, string, string, uint, int) []domain.SocialMediaLink); ok {
+		r0 = rf(ctx, tx, userId, search, afterTypeId, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.SocialMediaLink)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *gorm.DB, string, string, uint, int) error); ok {
+		r1 = rf(ctx, tx, userId, search, afterTypeId, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Update provides a mock function with given fields: ctx, tx, socialMediaLink
 func (_m *SocialMediaLinkRepository) Update(ctx context.Context, tx *gorm.DB, socialMediaLink domain.SocialMediaLink) (domain.SocialMediaLink, error) {
 	ret := _m.Called(ctx, tx, socialMediaLink)
@@ -102,6 +125,20 @@ func (_m *SocialMediaLinkRepository) Update(ctx context.Context, tx *gorm.DB, so
 	return r0, r1
 }
 
+// UpdatePositions provides a mock function with given fields: ctx, tx, userId, linkIds
+func (_m *SocialMediaLinkRepository) UpdatePositions(ctx context.Context, tx *gorm.DB, userId string, linkIds []uint) error {
+	ret := _m.Called(ctx, tx, userId, linkIds)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *gorm.DB, string, []uint) error); ok {
+		r0 = rf(ctx, tx, userId, linkIds)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 type mockConstructorTestingTNewSocialMediaLinkRepository interface {
 	mock.TestingT
 	Cleanup(func())
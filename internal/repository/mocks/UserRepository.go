@@ -58,6 +58,27 @@ func (_m *UserRepository) FindByEmail(ctx context.Context, tx *gorm.DB, email st
 	return r0, r1
 }
 
+// FindByID provides a mock function with given fields: ctx, tx, userId
+func (_m *UserRepository) FindByID(ctx context.Context, tx *gorm.DB, userId string) (domain.User, error) {
+	ret := _m.Called(ctx, tx, userId)
+
+	var r0 domain.User
+	if rf, ok := ret.Get(0).(func(context.Context, *gorm.DB, string) domain.User); ok {
+		r0 = rf(ctx, tx, userId)
+	} else {
+		r0 = ret.Get(0).(domain.User)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *gorm.DB, string) error); ok {
+		r1 = rf(ctx, tx, userId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // FindByUsername provides a mock function with given fields: ctx, tx, username
 func (_m *UserRepository) FindByUsername(ctx context.Context, tx *gorm.DB, username string) (domain.User, error) {
 	ret := _m.Called(ctx, tx, username)
@@ -100,6 +121,20 @@ func (_m *UserRepository) Update(ctx context.Context, tx *gorm.DB, user domain.U
 	return r0, r1
 }
 
+// UpdateCaseInsensitiveShortCode provides a mock function with given fields: ctx, tx, userId, caseInsensitive
+func (_m *UserRepository) UpdateCaseInsensitiveShortCode(ctx context.Context, tx *gorm.DB, userId string, caseInsensitive bool) error {
+	ret := _m.Called(ctx, tx, userId, caseInsensitive)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *gorm.DB, string, bool) error); ok {
+		r0 = rf(ctx, tx, userId, caseInsensitive)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpdatePassword provides a mock function with given fields: ctx, tx, userId, newPassword
 func (_m *UserRepository) UpdatePassword(ctx context.Context, tx *gorm.DB, userId string, newPassword string) error {
 	ret := _m.Called(ctx, tx, userId, newPassword)
@@ -14,6 +14,8 @@ type UserRepository interface {
 	FindByEmail(ctx context.Context, tx *gorm.DB, email string) (domain.User, error)
 	Update(ctx context.Context, tx *gorm.DB, user domain.User) (domain.User, error)
 	UpdatePassword(ctx context.Context, tx *gorm.DB, userId string, newPassword string) error
+	UpdateCaseInsensitiveShortCode(ctx context.Context, tx *gorm.DB, userId string, caseInsensitive bool) error
+	FindByID(ctx context.Context, tx *gorm.DB, userId string) (domain.User, error)
 }
 
 type SocialMediaTypeRepository interface {
@@ -27,7 +29,9 @@ type SocialMediaLinkRepository interface {
 	Create(ctx context.Context, tx *gorm.DB, socialMediaLink domain.SocialMediaLink) (domain.SocialMediaLink, error)
 	Update(ctx context.Context, tx *gorm.DB, socialMediaLink domain.SocialMediaLink) (domain.SocialMediaLink, error)
 	FindByUserID(ctx context.Context, tx *gorm.DB, userId string) ([]domain.SocialMediaLink, error)
+	FindPageByUserID(ctx context.Context, tx *gorm.DB, userId string, search string, afterTypeId uint, limit int) ([]domain.SocialMediaLink, error)
 	FindByTypeAndUserID(ctx context.Context, tx *gorm.DB, typeId uint, userId string) (domain.SocialMediaLink, error)
+	UpdatePositions(ctx context.Context, tx *gorm.DB, userId string, linkIds []uint) error
 }
 
 type SocialMediaInteractionRepository interface {
@@ -53,6 +57,34 @@ type ThumbnailRepository interface {
 	FetchAll(ctx context.Context, tx *gorm.DB) ([]domain.Thumbnail, error)
 }
 
+type CustomLinkVariantRepository interface {
+	FetchAllByLinkID(ctx context.Context, tx *gorm.DB, linkID uint) ([]domain.CustomLinkVariant, error)
+	FetchAllByLinkIDUnscoped(ctx context.Context, tx *gorm.DB, linkID uint) ([]domain.CustomLinkVariant, error)
+	ReplaceByLinkID(ctx context.Context, tx *gorm.DB, linkID uint, variants []domain.CustomLinkVariant) ([]domain.CustomLinkVariant, error)
+}
+
+type CustomLinkRevisionRepository interface {
+	Create(ctx context.Context, tx *gorm.DB, revision domain.CustomLinkRevision) (domain.CustomLinkRevision, error)
+	FetchAllByLinkID(ctx context.Context, tx *gorm.DB, linkID uint) ([]domain.CustomLinkRevision, error)
+	FindByLinkIDAndRevision(ctx context.Context, tx *gorm.DB, linkID uint, revision uint) (domain.CustomLinkRevision, error)
+	FindLatestByLinkID(ctx context.Context, tx *gorm.DB, linkID uint) (domain.CustomLinkRevision, error)
+}
+
+type CustomLinkTargetingRuleRepository interface {
+	FetchAllByLinkID(ctx context.Context, tx *gorm.DB, linkID uint) ([]domain.CustomLinkTargetingRule, error)
+	ReplaceByLinkID(ctx context.Context, tx *gorm.DB, linkID uint, rules []domain.CustomLinkTargetingRule) ([]domain.CustomLinkTargetingRule, error)
+}
+
+type UtmTemplateRepository interface {
+	Create(ctx context.Context, tx *gorm.DB, template domain.UtmTemplate) (domain.UtmTemplate, error)
+	Update(ctx context.Context, tx *gorm.DB, template domain.UtmTemplate) (domain.UtmTemplate, error)
+	Delete(ctx context.Context, tx *gorm.DB, template domain.UtmTemplate) error
+	FindByIdAndUserID(ctx context.Context, tx *gorm.DB, id uint, userID string) (domain.UtmTemplate, error)
+	FindDefaultByUserID(ctx context.Context, tx *gorm.DB, userID string) (domain.UtmTemplate, error)
+	FetchAllByUserID(ctx context.Context, tx *gorm.DB, userID string) ([]domain.UtmTemplate, error)
+	UnsetDefaultByUserID(ctx context.Context, tx *gorm.DB, userID string) error
+}
+
 type CustomThumbnailRepository interface {
 	Create(ctx context.Context, tx *gorm.DB, thumbnail domain.CustomThumbnail) (domain.CustomThumbnail, error)
 	FetchAllByUserID(ctx context.Context, tx *gorm.DB, userID string) ([]domain.CustomThumbnail, error)
@@ -62,20 +94,109 @@ type CustomThumbnailRepository interface {
 type CustomLinkRepository interface {
 	Create(ctx context.Context, tx *gorm.DB, link domain.CustomLink) (domain.CustomLink, error)
 	Update(ctx context.Context, tx *gorm.DB, link domain.CustomLink) (domain.CustomLink, error)
-	FindByShortLinkCode(ctx context.Context, tx *gorm.DB, shortLinkCode string) (domain.CustomLink, error)
+	Delete(ctx context.Context, tx *gorm.DB, link domain.CustomLink) error
+	Restore(ctx context.Context, tx *gorm.DB, link domain.CustomLink) (domain.CustomLink, error)
+	ReleaseShortLinkCode(ctx context.Context, tx *gorm.DB, link domain.CustomLink) (domain.CustomLink, error)
+	ReleaseShortLinkCodesDeletedBefore(ctx context.Context, tx *gorm.DB, deletedBefore time.Time) (int64, error)
+	FindByShortLinkCode(ctx context.Context, tx *gorm.DB, domainID *uint, shortLinkCode string) (domain.CustomLink, error)
+	FetchAllByShortLinkCodeUnscoped(ctx context.Context, tx *gorm.DB, domainID *uint, shortLinkCode string, caseInsensitive bool) ([]domain.CustomLink, error)
+	CountCaseConflictsByUserID(ctx context.Context, tx *gorm.DB, userID string) (int64, error)
+	UpdateCaseInsensitiveByUserID(ctx context.Context, tx *gorm.DB, userID string, caseInsensitive bool) error
+	LockShortLinkCode(ctx context.Context, tx *gorm.DB, domainID *uint, shortLinkCode string) error
+	LockShortLinkCodesByUserID(ctx context.Context, tx *gorm.DB, userID string) error
 	FindByIdAndUserID(ctx context.Context, tx *gorm.DB, id int, userId string) (domain.CustomLink, error)
+	FindByIdAndUserIDForUpdate(ctx context.Context, tx *gorm.DB, id int, userId string) (domain.CustomLink, error)
+	FindByIdAndUserIDUnscoped(ctx context.Context, tx *gorm.DB, id int, userId string) (domain.CustomLink, error)
+	FindDeletedByIdAndUserID(ctx context.Context, tx *gorm.DB, id int, userId string) (domain.CustomLink, error)
 	FetchAllByUserID(ctx context.Context, tx *gorm.DB, userID string) ([]domain.CustomLink, error)
+	FetchAllByUserIDInBatches(ctx context.Context, tx *gorm.DB, userID string, batchSize int, fn func(links []domain.CustomLink) error) error
+	FetchAllDeletedByUserID(ctx context.Context, tx *gorm.DB, userID string) ([]domain.CustomLink, error)
+	FetchAllActiveInBatches(ctx context.Context, tx *gorm.DB, batchSize int, fn func(links []domain.CustomLink) error) error
+	UpdateHealth(ctx context.Context, tx *gorm.DB, linkID uint, health domain.LinkHealth) error
+	ClaimClick(ctx context.Context, tx *gorm.DB, linkID uint) (bool, error)
 	UpdateThumbnailIDFK(ctx context.Context, tx *gorm.DB, linkID uint, thumbnailID *uint) (domain.CustomLink, error)
 	UpdateCustomThumbnailIDFK(ctx context.Context, tx *gorm.DB, linkID uint, customThumbnailID *uint) (domain.CustomLink, error)
+	FetchPageByUserIDAndFilter(ctx context.Context, tx *gorm.DB, userID string, filter CustomLinkFilter, page CustomLinkPage) ([]domain.CustomLink, error)
+	ReplaceTags(ctx context.Context, tx *gorm.DB, link domain.CustomLink, tags []domain.Tag) error
+	UpdatePositions(ctx context.Context, tx *gorm.DB, userID string, linkIDs []uint) error
+	FetchAllByIDsAndUserID(ctx context.Context, tx *gorm.DB, linkIDs []uint, userID string) ([]domain.CustomLink, error)
+	UpdateColumnsByIDsAndUserID(ctx context.Context, tx *gorm.DB, linkIDs []uint, userID string, values map[string]interface{}) error
+	DeleteByIDsAndUserID(ctx context.Context, tx *gorm.DB, linkIDs []uint, userID string) error
+}
+
+// CustomLinkFilter narrows down the links of a user, zero values don't filter.
+type CustomLinkFilter struct {
+	TagID     uint
+	FolderIDs []uint
+	Search    string // substring of the title, short link code or long link
+	Broken    bool   // only links flagged by the health check
+}
+
+const (
+	CustomLinkSortCreated  = "created"
+	CustomLinkSortUpdated  = "updated"
+	CustomLinkSortTitle    = "title"
+	CustomLinkSortClicks   = "clicks"
+	CustomLinkSortPosition = "position"
+)
+
+// CustomLinkPage is a keyset page, the rows come after the cursor row in the sort order and ties are broken by id.
+type CustomLinkPage struct {
+	Sort        string
+	Desc        bool
+	Limit       int
+	CursorValue interface{} // sort value of the cursor row, nil for the first page
+	CursorID    uint
+}
+
+type CustomDomainRepository interface {
+	Create(ctx context.Context, tx *gorm.DB, customDomain domain.CustomDomain) (domain.CustomDomain, error)
+	UpdateVerifiedAt(ctx context.Context, tx *gorm.DB, customDomain domain.CustomDomain) (domain.CustomDomain, error)
+	Delete(ctx context.Context, tx *gorm.DB, customDomain domain.CustomDomain) error
+	FindByIdAndUserID(ctx context.Context, tx *gorm.DB, id uint, userID string) (domain.CustomDomain, error)
+	FindByNameAndUserID(ctx context.Context, tx *gorm.DB, name string, userID string) (domain.CustomDomain, error)
+	FindVerifiedByName(ctx context.Context, tx *gorm.DB, name string) (domain.CustomDomain, error)
+	FetchAllByUserID(ctx context.Context, tx *gorm.DB, userID string) ([]domain.CustomDomain, error)
+	CountLinksByID(ctx context.Context, tx *gorm.DB, id uint) (int64, error)
+}
+
+type ReservedWordRepository interface {
+	Create(ctx context.Context, tx *gorm.DB, word domain.ReservedWord) (domain.ReservedWord, error)
+	Delete(ctx context.Context, tx *gorm.DB, word domain.ReservedWord) error
+	FindByID(ctx context.Context, tx *gorm.DB, id uint) (domain.ReservedWord, error)
+	FindByWord(ctx context.Context, tx *gorm.DB, word string) (domain.ReservedWord, error)
+	FindMatch(ctx context.Context, tx *gorm.DB, name string, substringCategories []string) (domain.ReservedWord, error)
+	FetchAll(ctx context.Context, tx *gorm.DB) ([]domain.ReservedWord, error)
+	Count(ctx context.Context, tx *gorm.DB) (int64, error)
+}
+
+type TagRepository interface {
+	Create(ctx context.Context, tx *gorm.DB, tag domain.Tag) (domain.Tag, error)
+	Update(ctx context.Context, tx *gorm.DB, tag domain.Tag) (domain.Tag, error)
+	Delete(ctx context.Context, tx *gorm.DB, tag domain.Tag) error
+	FindByIdAndUserID(ctx context.Context, tx *gorm.DB, id uint, userID string) (domain.Tag, error)
+	FindByNameAndUserID(ctx context.Context, tx *gorm.DB, name string, userID string) (domain.Tag, error)
+	FindByIDsAndUserID(ctx context.Context, tx *gorm.DB, ids []uint, userID string) ([]domain.Tag, error)
+	FetchAllByUserID(ctx context.Context, tx *gorm.DB, userID string) ([]domain.Tag, error)
+}
+
+type FolderRepository interface {
+	Create(ctx context.Context, tx *gorm.DB, folder domain.Folder) (domain.Folder, error)
+	Update(ctx context.Context, tx *gorm.DB, folder domain.Folder) (domain.Folder, error)
+	Delete(ctx context.Context, tx *gorm.DB, folder domain.Folder) error
+	FindByIdAndUserID(ctx context.Context, tx *gorm.DB, id uint, userID string) (domain.Folder, error)
+	FetchAllByUserID(ctx context.Context, tx *gorm.DB, userID string) ([]domain.Folder, error)
 }
 
 type CustomLinkInteractionRepository interface {
 	Create(ctx context.Context, tx *gorm.DB, linkInteraction domain.CustomLinkInteraction) error
 	FindByLinkIdAndDate(ctx context.Context, tx *gorm.DB, linkId int, date time.Time) ([]domain.CustomLinkInteraction, error)
+	CountByLinkIDGroupByVariant(ctx context.Context, tx *gorm.DB, linkId uint, startTime time.Time, endTime time.Time) (map[uint]int, error)
 }
 
 type CustomLinkAnalyticRepository interface {
 	Create(ctx context.Context, tx *gorm.DB, customLinkAnalytic domain.CustomLinkAnalytic) (domain.CustomLinkAnalytic, error)
 	Update(ctx context.Context, tx *gorm.DB, customLinkAnalytic domain.CustomLinkAnalytic) (domain.CustomLinkAnalytic, error)
 	FindByLinkIDAndDate(ctx context.Context, tx *gorm.DB, customLinkID uint, date time.Time) (domain.CustomLinkAnalytic, error)
+	SumClickCountByLinkIDs(ctx context.Context, tx *gorm.DB, customLinkIDs []uint) (map[uint]int, error)
 }
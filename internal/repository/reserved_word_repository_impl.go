@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/ilhamfzri/pendek.in/app/logger"
+	"github.com/ilhamfzri/pendek.in/internal/model/domain"
+	"gorm.io/gorm"
+)
+
+type ReservedWordRepositoryImpl struct {
+	Logger *logger.Logger
+}
+
+func NewReservedWordRepository(logger *logger.Logger) ReservedWordRepository {
+	return &ReservedWordRepositoryImpl{
+		Logger: logger,
+	}
+}
+
+func (repository *ReservedWordRepositoryImpl) Create(ctx context.Context, tx *gorm.DB, word domain.ReservedWord) (domain.ReservedWord, error) {
+	result := tx.WithContext(ctx).Create(&word)
+	return word, result.Error
+}
+
+func (repository *ReservedWordRepositoryImpl) Delete(ctx context.Context, tx *gorm.DB, word domain.ReservedWord) error {
+	result := tx.WithContext(ctx).Delete(&word)
+	return result.Error
+}
+
+func (repository *ReservedWordRepositoryImpl) FindByID(ctx context.Context, tx *gorm.DB, id uint) (domain.ReservedWord, error) {
+	var word domain.ReservedWord
+	result := tx.WithContext(ctx).Where("id = ?", id).First(&word)
+	return word, result.Error
+}
+
+func (repository *ReservedWordRepositoryImpl) FindByWord(ctx context.Context, tx *gorm.DB, word string) (domain.ReservedWord, error) {
+	var reservedWord domain.ReservedWord
+	result := tx.WithContext(ctx).Where("word = ?", word).First(&reservedWord)
+	return reservedWord, result.Error
+}
+
+// FindMatch finds the reserved word the name hits, words of the substring categories match anywhere in the name.
+func (repository *ReservedWordRepositoryImpl) FindMatch(ctx context.Context, tx *gorm.DB, name string, substringCategories []string) (domain.ReservedWord, error) {
+	var word domain.ReservedWord
+	result := tx.WithContext(ctx).
+		Where("word = ? OR (category IN ? AND strpos(?, word) > 0)", name, substringCategories, name).
+		Order("id").First(&word)
+	return word, result.Error
+}
+
+func (repository *ReservedWordRepositoryImpl) FetchAll(ctx context.Context, tx *gorm.DB) ([]domain.ReservedWord, error) {
+	var words []domain.ReservedWord
+	result := tx.WithContext(ctx).Order("category").Order("word").Find(&words)
+	return words, result.Error
+}
+
+func (repository *ReservedWordRepositoryImpl) Count(ctx context.Context, tx *gorm.DB) (int64, error) {
+	var count int64
+	result := tx.WithContext(ctx).Model(&domain.ReservedWord{}).Count(&count)
+	return count, result.Error
+}
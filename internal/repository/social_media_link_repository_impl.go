@@ -18,7 +18,16 @@ func NewSocialMediaLinkRepository(log *logger.Logger) SocialMediaLinkRepository
 	}
 }
 
+// Create puts the link after the last link of the user unless it has a position.
 func (repository *SocialMediaLinkRepositoryImpl) Create(ctx context.Context, tx *gorm.DB, socialMediaLink domain.SocialMediaLink) (domain.SocialMediaLink, error) {
+	if socialMediaLink.Position == 0 {
+		result := tx.WithContext(ctx).Model(&domain.SocialMediaLink{}).Unscoped().
+			Where("user_id = ?", socialMediaLink.UserID).Select("COALESCE(MAX(position), 0) + 1").Scan(&socialMediaLink.Position)
+		if result.Error != nil {
+			return socialMediaLink, result.Error
+		}
+	}
+
 	result := tx.WithContext(ctx).Create(&socialMediaLink)
 	return socialMediaLink, result.Error
 }
@@ -36,7 +45,32 @@ func (repository *SocialMediaLinkRepositoryImpl) Update(ctx context.Context, tx
 
 func (repository *SocialMediaLinkRepositoryImpl) FindByUserID(ctx context.Context, tx *gorm.DB, userId string) ([]domain.SocialMediaLink, error) {
 	var socialMediaLinks []domain.SocialMediaLink
-	result := tx.WithContext(ctx).Preload("SocialMediaType").Order("type_id ASC").Find(&socialMediaLinks, "social_media_links.user_id = ?", userId)
+	result := tx.WithContext(ctx).Preload("SocialMediaType").Order("position ASC, type_id ASC").Find(&socialMediaLinks, "social_media_links.user_id = ?", userId)
+	return socialMediaLinks, result.Error
+}
+
+// UpdatePositions numbers the links from 1 in the given order.
+func (repository *SocialMediaLinkRepositoryImpl) UpdatePositions(ctx context.Context, tx *gorm.DB, userId string, linkIds []uint) error {
+	for i, linkId := range linkIds {
+		result := tx.WithContext(ctx).Model(&domain.SocialMediaLink{}).
+			Where("id = ? AND user_id = ?", linkId, userId).UpdateColumn("position", i+1)
+		if result.Error != nil {
+			return result.Error
+		}
+	}
+	return nil
+}
+
+func (repository *SocialMediaLinkRepositoryImpl) FindPageByUserID(ctx context.Context, tx *gorm.DB, userId string, search string, afterTypeId uint, limit int) ([]domain.SocialMediaLink, error) {
+	var socialMediaLinks []domain.SocialMediaLink
+	query := tx.WithContext(ctx).Preload("SocialMediaType").Where("social_media_links.user_id = ? AND social_media_links.type_id > ?", userId, afterTypeId)
+
+	if search != "" {
+		query = query.Where("social_media_links.link_or_username ILIKE ?", "%"+escapeLike(search)+"%")
+	}
+
+	// It's fetching one more row than the limit so the caller knows there is a next page.
+	result := query.Order("type_id ASC").Limit(limit + 1).Find(&socialMediaLinks)
 	return socialMediaLinks, result.Error
 }
 
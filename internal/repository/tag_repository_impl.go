@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/ilhamfzri/pendek.in/app/logger"
+	"github.com/ilhamfzri/pendek.in/internal/model/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type TagRepositoryImpl struct {
+	Logger *logger.Logger
+}
+
+func NewTagRepository(logger *logger.Logger) TagRepository {
+	return &TagRepositoryImpl{
+		Logger: logger,
+	}
+}
+
+func (repository *TagRepositoryImpl) Create(ctx context.Context, tx *gorm.DB, tag domain.Tag) (domain.Tag, error) {
+	result := tx.WithContext(ctx).Create(&tag)
+	return tag, result.Error
+}
+
+func (repository *TagRepositoryImpl) Update(ctx context.Context, tx *gorm.DB, tag domain.Tag) (domain.Tag, error) {
+	result := tx.WithContext(ctx).Model(&tag).Clauses(clause.Returning{}).Update("name", tag.Name)
+	return tag, result.Error
+}
+
+// Delete removes the tag from every link before deleting it.
+func (repository *TagRepositoryImpl) Delete(ctx context.Context, tx *gorm.DB, tag domain.Tag) error {
+	result := tx.WithContext(ctx).Exec("DELETE FROM custom_link_tags WHERE tag_id = ?", tag.ID)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	result = tx.WithContext(ctx).Delete(&tag)
+	return result.Error
+}
+
+func (repository *TagRepositoryImpl) FindByIdAndUserID(ctx context.Context, tx *gorm.DB, id uint, userID string) (domain.Tag, error) {
+	var tag domain.Tag
+	result := tx.WithContext(ctx).Where("id = ? AND user_id = ?", id, userID).First(&tag)
+	return tag, result.Error
+}
+
+func (repository *TagRepositoryImpl) FindByNameAndUserID(ctx context.Context, tx *gorm.DB, name string, userID string) (domain.Tag, error) {
+	var tag domain.Tag
+	result := tx.WithContext(ctx).Where("name = ? AND user_id = ?", name, userID).First(&tag)
+	return tag, result.Error
+}
+
+func (repository *TagRepositoryImpl) FindByIDsAndUserID(ctx context.Context, tx *gorm.DB, ids []uint, userID string) ([]domain.Tag, error) {
+	var tags []domain.Tag
+	result := tx.WithContext(ctx).Where("id IN ? AND user_id = ?", ids, userID).Order("name").Find(&tags)
+	return tags, result.Error
+}
+
+func (repository *TagRepositoryImpl) FetchAllByUserID(ctx context.Context, tx *gorm.DB, userID string) ([]domain.Tag, error) {
+	var tags []domain.Tag
+	result := tx.WithContext(ctx).Where("user_id = ?", userID).Order("name").Find(&tags)
+	return tags, result.Error
+}
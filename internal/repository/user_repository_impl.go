@@ -29,6 +29,12 @@ func (repository *UserRepositoryImpl) FindByUsername(ctx context.Context, tx *go
 	return user, result.Error
 }
 
+func (repository *UserRepositoryImpl) FindByID(ctx context.Context, tx *gorm.DB, userId string) (domain.User, error) {
+	var user domain.User
+	result := tx.WithContext(ctx).Where("id = ?", userId).First(&user)
+	return user, result.Error
+}
+
 func (repository *UserRepositoryImpl) FindByEmail(ctx context.Context, tx *gorm.DB, email string) (domain.User, error) {
 	var user domain.User
 	result := tx.WithContext(ctx).Where("email = ?", email).First(&user)
@@ -53,3 +59,8 @@ func (repository *UserRepositoryImpl) UpdatePassword(ctx context.Context, tx *go
 	result := tx.WithContext(ctx).Model(&domain.User{}).Where("id = ?", userId).Update("password", newPassword)
 	return result.Error
 }
+
+func (repository *UserRepositoryImpl) UpdateCaseInsensitiveShortCode(ctx context.Context, tx *gorm.DB, userId string, caseInsensitive bool) error {
+	result := tx.WithContext(ctx).Model(&domain.User{}).Where("id = ?", userId).Update("case_insensitive_short_code", caseInsensitive)
+	return result.Error
+}
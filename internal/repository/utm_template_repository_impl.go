@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/ilhamfzri/pendek.in/app/logger"
+	"github.com/ilhamfzri/pendek.in/internal/model/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type UtmTemplateRepositoryImpl struct {
+	Logger *logger.Logger
+}
+
+func NewUtmTemplateRepository(logger *logger.Logger) UtmTemplateRepository {
+	return &UtmTemplateRepositoryImpl{
+		Logger: logger,
+	}
+}
+
+func (repository *UtmTemplateRepositoryImpl) Create(ctx context.Context, tx *gorm.DB, template domain.UtmTemplate) (domain.UtmTemplate, error) {
+	result := tx.WithContext(ctx).Create(&template)
+	return template, result.Error
+}
+
+func (repository *UtmTemplateRepositoryImpl) Update(ctx context.Context, tx *gorm.DB, template domain.UtmTemplate) (domain.UtmTemplate, error) {
+	result := tx.WithContext(ctx).Model(&template).Clauses(clause.Returning{}).
+		Updates(
+			map[string]interface{}{
+				"name":         template.Name,
+				"is_default":   template.IsDefault,
+				"utm_source":   template.Utm.Source,
+				"utm_medium":   template.Utm.Medium,
+				"utm_campaign": template.Utm.Campaign,
+				"utm_term":     template.Utm.Term,
+				"utm_content":  template.Utm.Content,
+			},
+		)
+	return template, result.Error
+}
+
+func (repository *UtmTemplateRepositoryImpl) Delete(ctx context.Context, tx *gorm.DB, template domain.UtmTemplate) error {
+	result := tx.WithContext(ctx).Delete(&template)
+	return result.Error
+}
+
+func (repository *UtmTemplateRepositoryImpl) FindByIdAndUserID(ctx context.Context, tx *gorm.DB, id uint, userID string) (domain.UtmTemplate, error) {
+	var template domain.UtmTemplate
+	result := tx.WithContext(ctx).Where("id = ? AND user_id = ?", id, userID).First(&template)
+	return template, result.Error
+}
+
+func (repository *UtmTemplateRepositoryImpl) FindDefaultByUserID(ctx context.Context, tx *gorm.DB, userID string) (domain.UtmTemplate, error) {
+	var template domain.UtmTemplate
+	result := tx.WithContext(ctx).Where("user_id = ? AND is_default = ?", userID, true).First(&template)
+	return template, result.Error
+}
+
+func (repository *UtmTemplateRepositoryImpl) FetchAllByUserID(ctx context.Context, tx *gorm.DB, userID string) ([]domain.UtmTemplate, error) {
+	var templates []domain.UtmTemplate
+	result := tx.WithContext(ctx).Where("user_id = ?", userID).Order("id").Find(&templates)
+	return templates, result.Error
+}
+
+func (repository *UtmTemplateRepositoryImpl) UnsetDefaultByUserID(ctx context.Context, tx *gorm.DB, userID string) error {
+	result := tx.WithContext(ctx).Model(&domain.UtmTemplate{}).
+		Where("user_id = ? AND is_default = ?", userID, true).
+		Update("is_default", false)
+	return result.Error
+}
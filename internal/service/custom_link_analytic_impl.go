@@ -17,6 +17,7 @@ type CustomLinkAnalyticServiceImpl struct {
 	CustomLinkRepository            repository.CustomLinkRepository
 	CustomLinkAnalyticRepository    repository.CustomLinkAnalyticRepository
 	CustomLinkInteractionRepository repository.CustomLinkInteractionRepository
+	CustomLinkVariantRepository     repository.CustomLinkVariantRepository
 	DeviceAnalyticRepository        repository.DeviceAnalyticRepository
 	DB                              *gorm.DB
 	Logger                          *logger.Logger
@@ -29,12 +30,14 @@ var (
 	ErrCustomLinkAnalyticService             = "[Custom Link Analytic Service] Failed to execute"
 	ErrCustomLinkAnalyticInvalidEndDate      = errors.New("end date value atleast today, not in the future")
 	ErrCustomLinkAnalyticInvalidStartDate    = errors.New("start date format invaled, start date up to last 30 days")
+	InteractionSourceQR                      = "qr" // source of an interaction that came from scanning the qr code of a link
 )
 
 func NewCustomLinkAnalyticService(
 	customLinkRepo repository.CustomLinkRepository,
 	customLinkAnalyticRepo repository.CustomLinkAnalyticRepository,
 	customLinkInteractionRepo repository.CustomLinkInteractionRepository,
+	customLinkVariantRepo repository.CustomLinkVariantRepository,
 	deviceAnalyticRepo repository.DeviceAnalyticRepository,
 	db *gorm.DB,
 	logger *logger.Logger,
@@ -44,6 +47,7 @@ func NewCustomLinkAnalyticService(
 		CustomLinkRepository:            customLinkRepo,
 		CustomLinkAnalyticRepository:    customLinkAnalyticRepo,
 		CustomLinkInteractionRepository: customLinkInteractionRepo,
+		CustomLinkVariantRepository:     customLinkVariantRepo,
 		DeviceAnalyticRepository:        deviceAnalyticRepo,
 		DB:                              db,
 		Logger:                          logger,
@@ -61,6 +65,8 @@ func (service *CustomLinkAnalyticServiceImpl) SaveInteraction(ctx context.Contex
 		ClientIP:     request.ClientIP,
 		UserAgent:    request.UserAgent,
 		CustomLinkID: request.CustomLinkID,
+		VariantID:    request.VariantID,
+		Source:       request.Source,
 	}
 
 	repoErr := service.CustomLinkInteractionRepository.Create(ctx, tx, customLinkInteractionDomain)
@@ -86,12 +92,16 @@ func (service *CustomLinkAnalyticServiceImpl) GetLinkAnalytic(ctx context.Contex
 		return []web.CustomLinkAnalyticResponse{}, ErrCustomLinkAnalyticInvalidStartDate
 	}
 
-	customLink, errRepo := service.CustomLinkRepository.FindByIdAndUserID(ctx, tx, request.LinkID, claims.Id)
+	// It's including deleted links, so their analytics stay available for reporting.
+	customLink, errRepo := service.CustomLinkRepository.FindByIdAndUserIDUnscoped(ctx, tx, request.LinkID, claims.Id)
 	if errRepo != nil && errors.Is(errRepo, gorm.ErrRecordNotFound) {
 		return []web.CustomLinkAnalyticResponse{}, ErrCustomLinkNotRegistered
 	}
 	service.Logger.PanicIfErr(errRepo, ErrCustomLinkAnalyticService)
 
+	variants, errRepo := service.CustomLinkVariantRepository.FetchAllByLinkIDUnscoped(ctx, tx, customLink.ID)
+	service.Logger.PanicIfErr(errRepo, ErrCustomLinkAnalyticService)
+
 	var costumLinkAnalyticResponses []web.CustomLinkAnalyticResponse
 
 	for requestDate := startDate; !requestDate.After(endDate); requestDate = requestDate.AddDate(0, 0, 1) {
@@ -113,12 +123,14 @@ func (service *CustomLinkAnalyticServiceImpl) GetLinkAnalytic(ctx context.Contex
 
 			deviceAnalytic := helper.CustomLinkInteractionsToDeviceAnalytic(&customLinkInteractions)
 			clickCount := len(customLinkInteractions)
+			scanCount := helper.CountCustomLinkInteractionsBySource(&customLinkInteractions, InteractionSourceQR)
 
 			deviceAnalytic, errDeviceAnalyticRepo := service.DeviceAnalyticRepository.Create(ctx, tx, deviceAnalytic)
 			service.Logger.PanicIfErr(errDeviceAnalyticRepo, ErrCustomLinkAnalyticService)
 
 			customLinkAnalytic = domain.CustomLinkAnalytic{
 				ClickCount:       clickCount,
+				ScanCount:        scanCount,
 				CustomLinkID:     customLink.ID,
 				DeviceAnalyticID: deviceAnalytic.ID,
 				Date:             requestDate,
@@ -129,6 +141,7 @@ func (service *CustomLinkAnalyticServiceImpl) GetLinkAnalytic(ctx context.Contex
 
 			customLinkAnalytic.DeviceAnalytic = deviceAnalytic
 			customLinkAnalyticResponse := helper.CustomLinkAnalyticDomainToResponse(&customLinkAnalytic)
+			customLinkAnalyticResponse.Variants = service.variantAnalytic(ctx, tx, customLink.ID, variants, requestDate, requestDate.AddDate(0, 0, 1))
 			costumLinkAnalyticResponses = append(costumLinkAnalyticResponses, customLinkAnalyticResponse)
 
 			continue
@@ -149,12 +162,14 @@ func (service *CustomLinkAnalyticServiceImpl) GetLinkAnalytic(ctx context.Contex
 
 				deviceAnalytic := helper.CustomLinkInteractionsToDeviceAnalytic(&customLinkInteractions)
 				clickCount := len(customLinkInteractions)
+				scanCount := helper.CountCustomLinkInteractionsBySource(&customLinkInteractions, InteractionSourceQR)
 
 				deviceAnalytic.ID = customLinkAnalytic.DeviceAnalyticID
 				deviceAnalytic, errDeviceAnalyticRepo := service.DeviceAnalyticRepository.Update(ctx, tx, deviceAnalytic)
 				service.Logger.PanicIfErr(errDeviceAnalyticRepo, ErrCustomLinkAnalyticService)
 
 				customLinkAnalytic.ClickCount = clickCount
+				customLinkAnalytic.ScanCount = scanCount
 				customLinkAnalytic, errAnalyticRepo = service.CustomLinkAnalyticRepository.Update(ctx, tx, customLinkAnalytic)
 				service.Logger.PanicIfErr(errAnalyticRepo, ErrCustomLinkAnalyticService)
 
@@ -162,6 +177,7 @@ func (service *CustomLinkAnalyticServiceImpl) GetLinkAnalytic(ctx context.Contex
 			}
 
 			customLinkAnalyticResponse := helper.CustomLinkAnalyticDomainToResponse(&customLinkAnalytic)
+			customLinkAnalyticResponse.Variants = service.variantAnalytic(ctx, tx, customLink.ID, variants, requestDate, requestDate.AddDate(0, 0, 1))
 			costumLinkAnalyticResponses = append(costumLinkAnalyticResponses, customLinkAnalyticResponse)
 
 			continue
@@ -213,12 +229,14 @@ func (service *CustomLinkAnalyticServiceImpl) GetSummaryLinkAnalytic(ctx context
 
 				deviceAnalytic := helper.CustomLinkInteractionsToDeviceAnalytic(&customLinkInteractions)
 				clickCount := len(customLinkInteractions)
+				scanCount := helper.CountCustomLinkInteractionsBySource(&customLinkInteractions, InteractionSourceQR)
 
 				deviceAnalytic, errDeviceAnalyticRepo := service.DeviceAnalyticRepository.Create(ctx, tx, deviceAnalytic)
 				service.Logger.PanicIfErr(errDeviceAnalyticRepo, ErrCustomLinkAnalyticService)
 
 				customLinkAnalytic = domain.CustomLinkAnalytic{
 					ClickCount:       clickCount,
+					ScanCount:        scanCount,
 					CustomLinkID:     customLink.ID,
 					DeviceAnalyticID: deviceAnalytic.ID,
 					Date:             requestDate,
@@ -229,6 +247,7 @@ func (service *CustomLinkAnalyticServiceImpl) GetSummaryLinkAnalytic(ctx context
 				service.Logger.PanicIfErr(errAnalyticRepo, ErrCustomLinkAnalyticService)
 
 				totalCustomLinkResponse.TotalClickCount += customLinkAnalytic.ClickCount
+				totalCustomLinkResponse.TotalScanCount += customLinkAnalytic.ScanCount
 				totalCustomLinkResponse.TotalViewCount += customLinkAnalytic.ViewCount
 
 				deviceAnalyticTotal.Desktop += customLinkAnalytic.DeviceAnalytic.Desktop
@@ -254,12 +273,14 @@ func (service *CustomLinkAnalyticServiceImpl) GetSummaryLinkAnalytic(ctx context
 
 					deviceAnalytic := helper.CustomLinkInteractionsToDeviceAnalytic(&customLinkInteractions)
 					clickCount := len(customLinkInteractions)
+					scanCount := helper.CountCustomLinkInteractionsBySource(&customLinkInteractions, InteractionSourceQR)
 
 					deviceAnalytic.ID = customLinkAnalytic.DeviceAnalyticID
 					deviceAnalytic, errDeviceAnalyticRepo := service.DeviceAnalyticRepository.Update(ctx, tx, deviceAnalytic)
 					service.Logger.PanicIfErr(errDeviceAnalyticRepo, ErrCustomLinkAnalyticService)
 
 					customLinkAnalytic.ClickCount = clickCount
+					customLinkAnalytic.ScanCount = scanCount
 					customLinkAnalytic, errAnalyticRepo = service.CustomLinkAnalyticRepository.Update(ctx, tx, customLinkAnalytic)
 					service.Logger.PanicIfErr(errAnalyticRepo, ErrCustomLinkAnalyticService)
 
@@ -267,6 +288,7 @@ func (service *CustomLinkAnalyticServiceImpl) GetSummaryLinkAnalytic(ctx context
 				}
 
 				totalCustomLinkResponse.TotalClickCount += customLinkAnalytic.ClickCount
+				totalCustomLinkResponse.TotalScanCount += customLinkAnalytic.ScanCount
 				totalCustomLinkResponse.TotalViewCount += customLinkAnalytic.ViewCount
 
 				deviceAnalyticTotal.Desktop += customLinkAnalytic.DeviceAnalytic.Desktop
@@ -278,14 +300,41 @@ func (service *CustomLinkAnalyticServiceImpl) GetSummaryLinkAnalytic(ctx context
 			}
 		}
 
+		variants, errRepo := service.CustomLinkVariantRepository.FetchAllByLinkIDUnscoped(ctx, tx, customLink.ID)
+		service.Logger.PanicIfErr(errRepo, ErrCustomLinkAnalyticService)
+		totalCustomLinkResponse.Variants = service.variantAnalytic(ctx, tx, customLink.ID, variants, startDate, endDate.AddDate(0, 0, 1))
+
 		totalCustomLinkResponses = append(totalCustomLinkResponses, totalCustomLinkResponse)
 	}
 
 	deviceAnalyticResponse := helper.DeviceAnalyticDomainToResponse(&deviceAnalyticTotal)
 	customLinkSummaryResponse := web.CustomLinkAnalyticSummaryResponse{
 		CustomLink:     totalCustomLinkResponses,
+		Tag:            helper.TagAnalytic(customLinks, totalCustomLinkResponses),
 		DeviceAnalytic: deviceAnalyticResponse,
 		LastUpdated:    time.Now(),
 	}
 	return customLinkSummaryResponse, nil
 }
+
+// variantAnalytic counts the clicks of every split variant the link ever had, including the replaced ones, within the time range.
+func (service *CustomLinkAnalyticServiceImpl) variantAnalytic(ctx context.Context, tx *gorm.DB, linkID uint, variants []domain.CustomLinkVariant, startTime time.Time, endTime time.Time) []web.CustomLinkVariantAnalyticResponse {
+	if len(variants) == 0 {
+		return nil
+	}
+
+	clickCounts, errRepo := service.CustomLinkInteractionRepository.CountByLinkIDGroupByVariant(ctx, tx, linkID, startTime, endTime)
+	service.Logger.PanicIfErr(errRepo, ErrCustomLinkAnalyticService)
+
+	var variantAnalyticResponses []web.CustomLinkVariantAnalyticResponse
+	for _, variant := range variants {
+		variantAnalyticResponses = append(variantAnalyticResponses, web.CustomLinkVariantAnalyticResponse{
+			VariantID:  variant.ID,
+			Name:       variant.Name,
+			Weight:     variant.Weight,
+			Active:     !variant.DeletedAt.Valid,
+			ClickCount: clickCounts[variant.ID],
+		})
+	}
+	return variantAnalyticResponses
+}
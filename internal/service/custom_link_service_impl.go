@@ -9,10 +9,15 @@ import (
 	"image/jpeg"
 	"os"
 	"path"
+	"strconv"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/ilhamfzri/pendek.in/app/logger"
 	"github.com/ilhamfzri/pendek.in/helper"
+	"github.com/ilhamfzri/pendek.in/helper/domainverify"
+	"github.com/ilhamfzri/pendek.in/helper/geoip"
+	"github.com/ilhamfzri/pendek.in/helper/urlsafety"
 	"github.com/ilhamfzri/pendek.in/internal/model/domain"
 	"github.com/ilhamfzri/pendek.in/internal/model/web"
 	"github.com/ilhamfzri/pendek.in/internal/repository"
@@ -20,34 +25,111 @@ import (
 	"gorm.io/gorm"
 )
 
+// CustomLinkServiceDependencies groups the repositories and helpers of the custom link service,
+// a dependency left out stays nil.
+type CustomLinkServiceDependencies struct {
+	CustomLinkRepository              repository.CustomLinkRepository
+	CustomLinkAnalyticRepository      repository.CustomLinkAnalyticRepository
+	CustomThumbnailRepository         repository.CustomThumbnailRepository
+	ThumbnailRepository               repository.ThumbnailRepository
+	UtmTemplateRepository             repository.UtmTemplateRepository
+	CustomLinkTargetingRuleRepository repository.CustomLinkTargetingRuleRepository
+	CustomLinkVariantRepository       repository.CustomLinkVariantRepository
+	TagRepository                     repository.TagRepository
+	FolderRepository                  repository.FolderRepository
+	CustomLinkRevisionRepository      repository.CustomLinkRevisionRepository
+	CustomDomainRepository            repository.CustomDomainRepository
+	ReservedWordRepository            repository.ReservedWordRepository
+	UserRepository                    repository.UserRepository
+	ShortCodeGenerator                *helper.ShortCodeGenerator
+	GeoIP                             *geoip.Database
+	URLChecker                        *urlsafety.Checker
+	DomainVerifier                    *domainverify.Verifier
+}
+
 type CustomLinkServiceImpl struct {
-	CustomLinkRepository      repository.CustomLinkRepository
-	CustomThumbnailRepository repository.CustomThumbnailRepository
-	ThumbnailRepository       repository.ThumbnailRepository
-	DB                        *gorm.DB
-	Logger                    *logger.Logger
-	Jwt                       helper.IJwt
+	CustomLinkServiceDependencies
+	DB     *gorm.DB
+	Logger *logger.Logger
+	Jwt    helper.IJwt
 }
 
 var (
-	ErrCustomLinkService       = "[Custom Link Service] Failed To Execute "
-	ErrTwoTumbnailNotNull      = errors.New("set only one value either use thumbnail_id or user_thumbnail_id")
-	ErrThumbnailIDNotFound     = errors.New("thumbnail_id invalid, make sure thumbnail_id is available")
-	ErrUserThumbnailIDNotFound = errors.New("user_thumbnail_id invalid, make sure user_thumbnail_id is available")
-	ErrShortLinkCodeRegistered = errors.New("short_link_code is registered")
-	ErrCustomLinkNotRegistered = errors.New("link is not registered")
-	ErrCustomLinkInvalid       = errors.New("link is invalid")
+	RetentionDurationDeletedCustomLink = 30 * 24 * time.Hour // short link code of a deleted link is released after this duration
+	MaxCustomLinkImportRow             = 1000
+	BatchSizeCustomLinkExport          = 500
+	DefaultCustomLinkPageLimit         = 20
+	MaxShortCodeSuggestion             = 5
+)
+
+const (
+	RevisionActionInitial = "initial" // the link as it was before its history was kept
+	RevisionActionCreate  = "create"
+	RevisionActionUpdate  = "update"
+	RevisionActionRevert  = "revert"
+)
+
+const (
+	BatchActionActivate   = "activate"
+	BatchActionDeactivate = "deactivate"
+	BatchActionShow       = "show" // shows the links on the profile
+	BatchActionHide       = "hide"
+	BatchActionRetag      = "retag"
+	BatchActionMove       = "move"
+	BatchActionDelete     = "delete"
+)
+
+var (
+	ErrCustomLinkService          = "[Custom Link Service] Failed To Execute "
+	ErrTwoTumbnailNotNull         = errors.New("set only one value either use thumbnail_id or user_thumbnail_id")
+	ErrThumbnailIDNotFound        = errors.New("thumbnail_id invalid, make sure thumbnail_id is available")
+	ErrUserThumbnailIDNotFound    = errors.New("user_thumbnail_id invalid, make sure user_thumbnail_id is available")
+	ErrShortLinkCodeRegistered    = errors.New("short_link_code is registered")
+	ErrShortLinkCodeReserved      = errors.New("short_link_code is reserved")
+	ErrCustomLinkNotRegistered    = errors.New("link is not registered")
+	ErrCustomLinkInvalid          = errors.New("link is invalid")
+	ErrCustomLinkNotDeleted       = errors.New("link is not in trash")
+	ErrShortLinkCodeReclaimed     = errors.New("short_link_code of this link has been released and registered by another link")
+	ErrShortLinkCodeGenerate      = errors.New("failed to generate an available short_link_code, please try again")
+	ErrCustomLinkExpired          = errors.New("link is expired")
+	ErrCustomLinkExpiresAt        = errors.New("expires_at must be in the future")
+	ErrCustomLinkPassword         = errors.New("password must be at least 4 characters")
+	ErrCustomLinkLocked           = errors.New("link is password protected")
+	ErrCustomLinkUnlockFailed     = errors.New("link password incorrect")
+	ErrCustomLinkActiveWindow     = errors.New("active_until must be after active_from")
+	ErrCustomLinkNotLive          = errors.New("link is not active at this time")
+	ErrCustomLinkImportEmpty      = errors.New("import file doesn't contain any link")
+	ErrUtmTemplateIDNotFound      = errors.New("utm_template_id invalid, make sure utm_template_id is available")
+	ErrUtmTemplateNotRegistered   = errors.New("utm template is not registered")
+	ErrTargetingRuleCondition     = errors.New("targeting rule must have at least one of device, os, country or language")
+	ErrCustomLinkVariantCount     = errors.New("variants must contain at least two destinations")
+	ErrTagIDNotFound              = errors.New("tag_ids invalid, make sure every tag_id is available")
+	ErrTagNameRegistered          = errors.New("tag name is registered")
+	ErrTagNotRegistered           = errors.New("tag is not registered")
+	ErrFolderIDNotFound           = errors.New("folder_id invalid, make sure folder_id is available")
+	ErrFolderNotRegistered        = errors.New("folder is not registered")
+	ErrFolderParentIDNotFound     = errors.New("parent_id invalid, make sure parent_id is available")
+	ErrFolderParentInvalid        = errors.New("parent_id invalid, a folder can't be moved into itself or its subfolders")
+	ErrCustomDomainRegistered     = errors.New("domain is registered")
+	ErrCustomDomainNotRegistered  = errors.New("domain is not registered")
+	ErrCustomDomainIDNotFound     = errors.New("domain_id invalid, make sure domain_id is a verified domain")
+	ErrCustomDomainInUse          = errors.New("domain still has links, delete them from the trash first")
+	ErrCustomLinkReorderInvalid   = errors.New("link_ids invalid, make sure every link_id is registered")
+	ErrCustomLinkBatchTagIDs      = errors.New("tag_ids is required to retag links")
+	ErrCustomLinkBatchFolderID    = errors.New("folder_id is required to move links")
+	ErrQRCodeLogoNotFound         = errors.New("link doesn't have a custom thumbnail to use as qr code logo")
+	ErrOgUserThumbnailIDNotFound  = errors.New("og_user_thumbnail_id invalid, make sure og_user_thumbnail_id is available")
+	ErrCustomLinkPreviewNotSet    = errors.New("link doesn't have an open graph preview")
+	ErrCustomLinkRevisionNotFound = errors.New("revision is not registered")
+	ErrCustomLinkImportTooLarge   = fmt.Errorf("import file contains more than %d links", MaxCustomLinkImportRow)
 )
 
-func NewCustomLinkService(clr repository.CustomLinkRepository, ctr repository.CustomThumbnailRepository, tr repository.ThumbnailRepository,
-	db *gorm.DB, logger *logger.Logger, jwt helper.IJwt) CustomLinkService {
+func NewCustomLinkService(dependencies CustomLinkServiceDependencies, db *gorm.DB, logger *logger.Logger, jwt helper.IJwt) CustomLinkService {
 	return &CustomLinkServiceImpl{
-		CustomLinkRepository:      clr,
-		CustomThumbnailRepository: ctr,
-		ThumbnailRepository:       tr,
-		DB:                        db,
-		Logger:                    logger,
-		Jwt:                       jwt,
+		CustomLinkServiceDependencies: dependencies,
+		DB:                            db,
+		Logger:                        logger,
+		Jwt:                           jwt,
 	}
 }
 
@@ -59,10 +141,131 @@ func (service *CustomLinkServiceImpl) CreateLink(ctx context.Context, request we
 	tx := service.DB.Begin()
 	defer helper.CommitOrRollback(tx)
 
+	return service.createLink(ctx, tx, request, domainName, claims.Id, claims.Username)
+}
+
+func (service *CustomLinkServiceImpl) ImportLink(ctx context.Context, request web.CustomLinkImportRequest, domainName string, jwtToken string) (web.CustomLinkImportResponse, error) {
+	// It's getting the claims from the token.
+	claims := service.Jwt.GetClaims(jwtToken)
+
+	if len(request.Links) == 0 {
+		return web.CustomLinkImportResponse{}, ErrCustomLinkImportEmpty
+	}
+
+	if len(request.Links) > MaxCustomLinkImportRow {
+		return web.CustomLinkImportResponse{}, ErrCustomLinkImportTooLarge
+	}
+
+	// It's a transaction.
+	tx := service.DB.Begin()
+	defer helper.CommitOrRollback(tx)
+
+	// Dry run and failed all-or-nothing imports are rolled back to this savepoint.
+	errTx := tx.SavePoint("import").Error
+	service.Logger.PanicIfErr(errTx, ErrCustomLinkService)
+
+	importResponse := web.CustomLinkImportResponse{
+		Total:  len(request.Links),
+		DryRun: request.DryRun,
+		Atomic: request.Atomic,
+	}
+
+	for i, linkRequest := range request.Links {
+		rowResponse := web.CustomLinkImportRowResponse{Row: i + 1}
+
+		errValidate := helper.ValidateRequest(linkRequest)
+		if errValidate != nil {
+			rowResponse.Status = "failed"
+			rowResponse.Message = errValidate.Error()
+			importResponse.Failed += 1
+			importResponse.Results = append(importResponse.Results, rowResponse)
+			continue
+		}
+
+		errTx = tx.SavePoint("import_row").Error
+		service.Logger.PanicIfErr(errTx, ErrCustomLinkService)
+
+		customLinkResponse, errCreate := service.createLink(ctx, tx, linkRequest, domainName, claims.Id, claims.Username)
+		if errCreate != nil {
+			errTx = tx.RollbackTo("import_row").Error
+			service.Logger.PanicIfErr(errTx, ErrCustomLinkService)
+		}
+
+		// It's releasing the savepoint of the row, a kept savepoint would nest one more subtransaction per row.
+		errTx = tx.Exec("RELEASE SAVEPOINT import_row").Error
+		service.Logger.PanicIfErr(errTx, ErrCustomLinkService)
+
+		if errCreate != nil {
+			rowResponse.Status = "failed"
+			rowResponse.Message = errCreate.Error()
+			importResponse.Failed += 1
+			importResponse.Results = append(importResponse.Results, rowResponse)
+			continue
+		}
+
+		rowResponse.Status = "created"
+		if request.DryRun {
+			rowResponse.Status = "valid"
+		}
+		rowResponse.Link = &customLinkResponse
+		importResponse.Succeeded += 1
+		importResponse.Results = append(importResponse.Results, rowResponse)
+	}
+
+	if request.DryRun || (request.Atomic && importResponse.Failed > 0) {
+		errTx = tx.RollbackTo("import").Error
+		service.Logger.PanicIfErr(errTx, ErrCustomLinkService)
+	}
+
+	// It's marking the rows as rolled back, so the report doesn't claim links that were never saved.
+	if !request.DryRun && request.Atomic && importResponse.Failed > 0 {
+		for i := range importResponse.Results {
+			if importResponse.Results[i].Status == "created" {
+				importResponse.Results[i].Status = "rolled_back"
+				importResponse.Results[i].Link = nil
+			}
+		}
+		importResponse.Succeeded = 0
+	}
+
+	return importResponse, nil
+}
+
+// createLink creates a custom link inside the given transaction, it's shared by CreateLink and ImportLink.
+func (service *CustomLinkServiceImpl) createLink(ctx context.Context, tx *gorm.DB, request web.CustomLinkCreateRequest, domainName string, userID string, username string) (web.CustomLinkResponse, error) {
 	if request.UserThumbnailID != nil && request.ThumbnailID != nil {
 		return web.CustomLinkResponse{}, ErrTwoTumbnailNotNull
 	}
 
+	if !helper.IsValidWindow(request.ActiveFrom, request.ActiveUntil) {
+		return web.CustomLinkResponse{}, ErrCustomLinkActiveWindow
+	}
+
+	if err := validateTargetingRules(request.TargetingRules); err != nil {
+		return web.CustomLinkResponse{}, err
+	}
+
+	if len(request.Variants) == 1 {
+		return web.CustomLinkResponse{}, ErrCustomLinkVariantCount
+	}
+
+	if err := service.checkDestinations(ctx, tx, request.LongLink, request.TargetingRules, request.Variants, domainName); err != nil {
+		return web.CustomLinkResponse{}, err
+	}
+
+	if request.FolderID != nil && *request.FolderID != 0 {
+		if _, errFolder := service.findFolder(ctx, tx, *request.FolderID, userID); errFolder != nil {
+			return web.CustomLinkResponse{}, ErrFolderIDNotFound
+		}
+	} else {
+		request.FolderID = nil
+	}
+
+	tags, errTags := service.findTags(ctx, tx, request.TagIDs, userID)
+	if errTags != nil {
+		return web.CustomLinkResponse{}, errTags
+	}
+
 	var thumbnailUrl string
 
 	if request.ThumbnailID != nil {
@@ -81,7 +284,7 @@ func (service *CustomLinkServiceImpl) CreateLink(ctx context.Context, request we
 
 	if request.UserThumbnailID != nil {
 		userThumbnailID := *request.UserThumbnailID
-		customThumbnail, errRepo := service.CustomThumbnailRepository.FindByThumbnailIDAndUserID(ctx, tx, int(userThumbnailID), claims.Id)
+		customThumbnail, errRepo := service.CustomThumbnailRepository.FindByThumbnailIDAndUserID(ctx, tx, int(userThumbnailID), userID)
 		if errRepo != nil {
 			if errors.Is(errRepo, gorm.ErrRecordNotFound) {
 				return web.CustomLinkResponse{}, ErrUserThumbnailIDNotFound
@@ -93,22 +296,72 @@ func (service *CustomLinkServiceImpl) CreateLink(ctx context.Context, request we
 
 	}
 
-	customLink, errRepo := service.CustomLinkRepository.FindByShortLinkCode(ctx, tx, request.ShortLinkCode)
-	if errRepo != nil && !errors.Is(errRepo, gorm.ErrRecordNotFound) {
-		service.Logger.PanicIfErr(errRepo, ErrCustomLinkService)
+	utm := domain.Utm{
+		Source:   request.UtmSource,
+		Medium:   request.UtmMedium,
+		Campaign: request.UtmCampaign,
+		Term:     request.UtmTerm,
+		Content:  request.UtmContent,
+	}
+
+	if request.UtmTemplateID != nil {
+		utmTemplate, errRepo := service.UtmTemplateRepository.FindByIdAndUserID(ctx, tx, *request.UtmTemplateID, userID)
+		if errRepo != nil {
+			if errors.Is(errRepo, gorm.ErrRecordNotFound) {
+				return web.CustomLinkResponse{}, ErrUtmTemplateIDNotFound
+			} else {
+				service.Logger.PanicIfErr(errRepo, ErrCustomLinkService)
+			}
+		}
+		utm = mergeUtm(utm, utmTemplate.Utm)
+	}
+
+	var customDomain domain.CustomDomain
+	if request.DomainID != nil && *request.DomainID != 0 {
+		var errDomain error
+		customDomain, errDomain = service.findVerifiedDomain(ctx, tx, *request.DomainID, userID)
+		if errDomain != nil {
+			return web.CustomLinkResponse{}, errDomain
+		}
+	} else {
+		request.DomainID = nil
 	}
 
-	if customLink.ID != 0 {
+	caseInsensitive := service.isCaseInsensitive(ctx, tx, userID)
+	if request.ShortLinkCode == "" {
+		shortLinkCode, errGenerate := service.generateShortLinkCode(ctx, tx, request.DomainID, caseInsensitive)
+		if errGenerate != nil {
+			return web.CustomLinkResponse{}, errGenerate
+		}
+		request.ShortLinkCode = shortLinkCode
+	} else if service.isShortLinkCodeReserved(ctx, tx, request.ShortLinkCode) {
+		return web.CustomLinkResponse{}, ErrShortLinkCodeReserved
+	} else if service.isShortLinkCodeTaken(ctx, tx, request.DomainID, request.ShortLinkCode, caseInsensitive, 0) {
 		return web.CustomLinkResponse{}, ErrShortLinkCodeRegistered
 	}
 
-	customLink = domain.CustomLink{
-		UserID:        claims.Id,
-		Title:         request.Title,
-		ShortLinkCode: request.ShortLinkCode,
-		LongLink:      request.LongLink,
-		ShowOnProfile: true,
-		Activate:      true,
+	customLink := domain.CustomLink{
+		UserID:          userID,
+		Title:           request.Title,
+		ShortLinkCode:   request.ShortLinkCode,
+		LongLink:        request.LongLink,
+		ShowOnProfile:   true,
+		CaseInsensitive: caseInsensitive,
+		Activate:        true,
+		ExpiresAt:       request.ExpiresAt,
+		MaxClicks:       request.MaxClicks,
+		ActiveFrom:      request.ActiveFrom,
+		ActiveUntil:     request.ActiveUntil,
+		Utm:             utm,
+		FolderID:        request.FolderID,
+		Tags:            tags,
+		DomainID:        request.DomainID,
+	}
+
+	if request.Password != "" {
+		hashPassword, err := helper.HashPassword(request.Password)
+		service.Logger.PanicIfErr(err, ErrCustomLinkService)
+		customLink.Password = hashPassword
 	}
 
 	if request.UserThumbnailID != nil {
@@ -119,12 +372,40 @@ func (service *CustomLinkServiceImpl) CreateLink(ctx context.Context, request we
 		customLink.ThumbnailID = request.ThumbnailID
 	}
 
-	customLink, errRepo = service.CustomLinkRepository.Create(ctx, tx, customLink)
+	customLink.RedirectStatus = request.RedirectStatus
+	customLink.ForwardQuery = request.ForwardQuery
+	customLink.Interstitial = request.Interstitial
+	customLink.OgTitle = request.OgTitle
+	customLink.OgDescription = request.OgDescription
+	if request.OgUserThumbnailID != nil {
+		if errOg := service.checkOgThumbnail(ctx, tx, *request.OgUserThumbnailID, userID); errOg != nil {
+			return web.CustomLinkResponse{}, errOg
+		}
+		customLink.OgCustomThumbnailID = request.OgUserThumbnailID
+	}
+
+	customLink, errRepo := service.CustomLinkRepository.Create(ctx, tx, customLink)
+	service.Logger.PanicIfErr(errRepo, ErrCustomLinkService)
+	customLink.Domain = customDomain
+
+	revision := helper.CustomLinkSnapshot(&customLink)
+	revision.Action = RevisionActionCreate
+	revision.UserID = userID
+	revision.Username = username
+	_, errRepo = service.CustomLinkRevisionRepository.Create(ctx, tx, revision)
 	service.Logger.PanicIfErr(errRepo, ErrCustomLinkService)
 
 	customLinkResponse := helper.CustomLinkDomainToResponse(&customLink)
+	if len(request.TargetingRules) > 0 {
+		customLinkResponse.TargetingRules = service.replaceTargetingRules(ctx, tx, customLink.ID, request.TargetingRules)
+	}
+
+	if len(request.Variants) > 0 {
+		customLinkResponse.Variants = service.replaceVariants(ctx, tx, customLink.ID, request.Variants)
+	}
 	customLinkResponse.ThumbnailUrl = thumbnailUrl
-	customLinkResponse.RedirectLink = helper.GetCustomLinkUrl(domainName, customLink.ShortLinkCode)
+	customLinkResponse.RedirectLink = helper.GetCustomLinkUrl(helper.GetLinkDomain(&customLink, domainName), customLink.ShortLinkCode)
+	customLinkResponse.Expired = isExpired(&customLink)
 	return customLinkResponse, nil
 }
 
@@ -142,7 +423,8 @@ func (service *CustomLinkServiceImpl) UpdateLink(ctx context.Context, request we
 		}
 	}
 
-	customLink, errRepo := service.CustomLinkRepository.FindByIdAndUserID(ctx, tx, int(request.CustomLinkID), claims.Id)
+	// It's locking the link, its next revision number is only taken once.
+	customLink, errRepo := service.CustomLinkRepository.FindByIdAndUserIDForUpdate(ctx, tx, int(request.CustomLinkID), claims.Id)
 	if errRepo != nil && !errors.Is(errRepo, gorm.ErrRecordNotFound) {
 		service.Logger.PanicIfErr(errRepo, ErrCustomLinkService)
 	}
@@ -151,13 +433,37 @@ func (service *CustomLinkServiceImpl) UpdateLink(ctx context.Context, request we
 		return web.CustomLinkResponse{}, ErrCustomLinkNotRegistered
 	}
 
-	if request.ShortLinkCode != "" {
-		customLinkShortLink, errRepo := service.CustomLinkRepository.FindByShortLinkCode(ctx, tx, request.ShortLinkCode)
-		if errRepo != nil && !errors.Is(errRepo, gorm.ErrRecordNotFound) {
-			service.Logger.PanicIfErr(errRepo, ErrCustomLinkService)
+	// It's keeping the destination before the change, it's the first revision of links created without history.
+	previousRevision := helper.CustomLinkSnapshot(&customLink)
+	previousDomainID := customLink.DomainID
+
+	// It's checking the short link code on the domain the link ends up on, moving a link keeps its code.
+	customDomain := customLink.Domain
+	if request.DomainID != nil {
+		if *request.DomainID == 0 {
+			customLink.DomainID = nil
+			customDomain = domain.CustomDomain{}
+		} else {
+			var errDomain error
+			customDomain, errDomain = service.findVerifiedDomain(ctx, tx, *request.DomainID, claims.Id)
+			if errDomain != nil {
+				return web.CustomLinkResponse{}, errDomain
+			}
+			customLink.DomainID = &customDomain.ID
 		}
+	}
+
+	isDomainChanged := !isSameDomainID(previousDomainID, customLink.DomainID)
+	if request.ShortLinkCode != "" && request.ShortLinkCode != customLink.ShortLinkCode && service.isShortLinkCodeReserved(ctx, tx, request.ShortLinkCode) {
+		return web.CustomLinkResponse{}, ErrShortLinkCodeReserved
+	}
 
-		if customLinkShortLink.ID != 0 {
+	if (request.ShortLinkCode != "" && request.ShortLinkCode != customLink.ShortLinkCode) || isDomainChanged {
+		shortLinkCode := customLink.ShortLinkCode
+		if request.ShortLinkCode != "" {
+			shortLinkCode = request.ShortLinkCode
+		}
+		if service.isShortLinkCodeTaken(ctx, tx, customLink.DomainID, shortLinkCode, customLink.CaseInsensitive, customLink.ID) {
 			return web.CustomLinkResponse{}, ErrShortLinkCodeRegistered
 		}
 	}
@@ -228,11 +534,159 @@ func (service *CustomLinkServiceImpl) UpdateLink(ctx context.Context, request we
 		customLink.Activate = *request.Activate
 	}
 
+	if request.ExpiresAt != nil {
+		if request.ExpiresAt.IsZero() {
+			customLink.ExpiresAt = nil
+		} else if !request.ExpiresAt.After(time.Now()) {
+			return web.CustomLinkResponse{}, ErrCustomLinkExpiresAt
+		} else {
+			customLink.ExpiresAt = request.ExpiresAt
+		}
+	}
+
+	if request.MaxClicks != nil {
+		if *request.MaxClicks == 0 {
+			customLink.MaxClicks = nil
+		} else {
+			customLink.MaxClicks = request.MaxClicks
+		}
+	}
+
+	if request.Password != nil {
+		if *request.Password == "" {
+			customLink.Password = ""
+		} else if len(*request.Password) < 4 {
+			return web.CustomLinkResponse{}, ErrCustomLinkPassword
+		} else {
+			hashPassword, err := helper.HashPassword(*request.Password)
+			service.Logger.PanicIfErr(err, ErrCustomLinkService)
+			customLink.Password = hashPassword
+		}
+	}
+
+	if request.RedirectStatus != nil {
+		customLink.RedirectStatus = *request.RedirectStatus
+	}
+
+	if request.ForwardQuery != nil {
+		customLink.ForwardQuery = *request.ForwardQuery
+	}
+
+	if request.Interstitial != nil {
+		customLink.Interstitial = *request.Interstitial
+	}
+
+	if request.OgTitle != nil {
+		customLink.OgTitle = *request.OgTitle
+	}
+
+	if request.OgDescription != nil {
+		customLink.OgDescription = *request.OgDescription
+	}
+
+	if request.OgUserThumbnailID != nil {
+		if *request.OgUserThumbnailID == 0 {
+			customLink.OgCustomThumbnailID = nil
+		} else if errOg := service.checkOgThumbnail(ctx, tx, *request.OgUserThumbnailID, claims.Id); errOg != nil {
+			return web.CustomLinkResponse{}, errOg
+		} else {
+			customLink.OgCustomThumbnailID = request.OgUserThumbnailID
+		}
+	}
+
+	if request.ActiveFrom != nil {
+		if request.ActiveFrom.IsZero() {
+			customLink.ActiveFrom = nil
+		} else {
+			customLink.ActiveFrom = request.ActiveFrom
+		}
+	}
+
+	if request.ActiveUntil != nil {
+		if request.ActiveUntil.IsZero() {
+			customLink.ActiveUntil = nil
+		} else {
+			customLink.ActiveUntil = request.ActiveUntil
+		}
+	}
+
+	if request.UtmSource != nil {
+		customLink.Utm.Source = *request.UtmSource
+	}
+
+	if request.UtmMedium != nil {
+		customLink.Utm.Medium = *request.UtmMedium
+	}
+
+	if request.UtmCampaign != nil {
+		customLink.Utm.Campaign = *request.UtmCampaign
+	}
+
+	if request.UtmTerm != nil {
+		customLink.Utm.Term = *request.UtmTerm
+	}
+
+	if request.UtmContent != nil {
+		customLink.Utm.Content = *request.UtmContent
+	}
+
+	if !helper.IsValidWindow(customLink.ActiveFrom, customLink.ActiveUntil) {
+		return web.CustomLinkResponse{}, ErrCustomLinkActiveWindow
+	}
+
+	if request.TargetingRules != nil {
+		if err := validateTargetingRules(*request.TargetingRules); err != nil {
+			return web.CustomLinkResponse{}, err
+		}
+	}
+
+	if request.Variants != nil && len(*request.Variants) == 1 {
+		return web.CustomLinkResponse{}, ErrCustomLinkVariantCount
+	}
+
+	// It's checking the destinations that are changed, the rest were checked when they were set.
+	var targetingRules []web.CustomLinkTargetingRuleRequest
+	if request.TargetingRules != nil {
+		targetingRules = *request.TargetingRules
+	}
+
+	var variants []web.CustomLinkVariantRequest
+	if request.Variants != nil {
+		variants = *request.Variants
+	}
+
+	if err := service.checkDestinations(ctx, tx, request.LongLink, targetingRules, variants, domainName); err != nil {
+		return web.CustomLinkResponse{}, err
+	}
+
+	if request.FolderID != nil {
+		if *request.FolderID == 0 {
+			customLink.FolderID = nil
+		} else if _, errFolder := service.findFolder(ctx, tx, *request.FolderID, claims.Id); errFolder != nil {
+			return web.CustomLinkResponse{}, ErrFolderIDNotFound
+		} else {
+			customLink.FolderID = request.FolderID
+		}
+	}
+
+	tags := customLink.Tags
+	if request.TagIDs != nil {
+		var errTags error
+		tags, errTags = service.findTags(ctx, tx, *request.TagIDs, claims.Id)
+		if errTags != nil {
+			return web.CustomLinkResponse{}, errTags
+		}
+
+		errRepo = service.CustomLinkRepository.ReplaceTags(ctx, tx, customLink, tags)
+		service.Logger.PanicIfErr(errRepo, ErrCustomLinkService)
+	}
+
 	updateCustomThumbnailID := customLink.CustomThumbnailID
 	updateThumbnailID := customLink.ThumbnailID
 
 	customLink, errRepo = service.CustomLinkRepository.Update(ctx, tx, customLink)
 	service.Logger.PanicIfErr(errRepo, ErrCustomLinkService)
+	service.resetHealth(ctx, tx, &customLink, previousRevision.LongLink)
 
 	customLink, errRepo = service.CustomLinkRepository.UpdateThumbnailIDFK(ctx, tx, customLink.ID, updateThumbnailID)
 	service.Logger.PanicIfErr(errRepo, ErrCustomLinkService)
@@ -240,8 +694,24 @@ func (service *CustomLinkServiceImpl) UpdateLink(ctx context.Context, request we
 	customLink, errRepo = service.CustomLinkRepository.UpdateCustomThumbnailIDFK(ctx, tx, customLink.ID, updateCustomThumbnailID)
 	service.Logger.PanicIfErr(errRepo, ErrCustomLinkService)
 
+	service.recordRevision(ctx, tx, previousRevision, &customLink, RevisionActionUpdate, nil, claims)
+
+	customLink.Domain = customDomain
+	customLink.Tags = tags
 	customLinkResponse := helper.CustomLinkDomainToResponse(&customLink)
-	customLinkResponse.RedirectLink = helper.GetCustomLinkUrl(domainName, customLink.ShortLinkCode)
+	customLinkResponse.RedirectLink = helper.GetCustomLinkUrl(helper.GetLinkDomain(&customLink, domainName), customLink.ShortLinkCode)
+
+	if request.TargetingRules != nil {
+		customLinkResponse.TargetingRules = service.replaceTargetingRules(ctx, tx, customLink.ID, *request.TargetingRules)
+	} else {
+		customLinkResponse.TargetingRules = service.getTargetingRules(ctx, tx, customLink.ID)
+	}
+
+	if request.Variants != nil {
+		customLinkResponse.Variants = service.replaceVariants(ctx, tx, customLink.ID, *request.Variants)
+	} else {
+		customLinkResponse.Variants = service.getVariants(ctx, tx, customLink.ID)
+	}
 
 	if thumbnailUrl == "" {
 		if customLink.CustomThumbnailID != nil {
@@ -252,7 +722,8 @@ func (service *CustomLinkServiceImpl) UpdateLink(ctx context.Context, request we
 	}
 
 	customLinkResponse.ThumbnailUrl = thumbnailUrl
-	customLinkResponse.RedirectLink = helper.GetCustomLinkUrl(domainName, customLink.ShortLinkCode)
+	customLinkResponse.RedirectLink = helper.GetCustomLinkUrl(helper.GetLinkDomain(&customLink, domainName), customLink.ShortLinkCode)
+	customLinkResponse.Expired = isExpired(&customLink)
 	return customLinkResponse, nil
 }
 
@@ -284,11 +755,14 @@ func (service *CustomLinkServiceImpl) GetLink(ctx context.Context, request web.C
 
 	customLinkResponse := helper.CustomLinkDomainToResponse(&customLink)
 	customLinkResponse.ThumbnailUrl = thumbnailUrl
-	customLinkResponse.RedirectLink = helper.GetCustomLinkUrl(domainName, customLink.ShortLinkCode)
+	customLinkResponse.RedirectLink = helper.GetCustomLinkUrl(helper.GetLinkDomain(&customLink, domainName), customLink.ShortLinkCode)
+	customLinkResponse.Expired = isExpired(&customLink)
+	customLinkResponse.TargetingRules = service.getTargetingRules(ctx, tx, customLink.ID)
+	customLinkResponse.Variants = service.getVariants(ctx, tx, customLink.ID)
 	return customLinkResponse, nil
 }
 
-func (service *CustomLinkServiceImpl) GetAllLink(ctx context.Context, domainName string, jwtToken string) ([]web.CustomLinkResponse, error) {
+func (service *CustomLinkServiceImpl) GetAllLink(ctx context.Context, request web.CustomLinkGetAllRequest, domainName string, jwtToken string) ([]web.CustomLinkResponse, web.Pagination, error) {
 	// It's getting the claims from the token.
 	claims := service.Jwt.GetClaims(jwtToken)
 
@@ -296,11 +770,63 @@ func (service *CustomLinkServiceImpl) GetAllLink(ctx context.Context, domainName
 	tx := service.DB.Begin()
 	defer helper.CommitOrRollback(tx)
 
-	customLinks, errRepo := service.CustomLinkRepository.FetchAllByUserID(ctx, tx, claims.Id)
+	page := repository.CustomLinkPage{
+		Sort:  request.Sort,
+		Desc:  request.Order == "desc",
+		Limit: request.Limit,
+	}
+	if page.Sort == "" {
+		page.Sort = repository.CustomLinkSortCreated
+	}
+	if page.Limit == 0 {
+		page.Limit = DefaultCustomLinkPageLimit
+	}
+
+	if request.Cursor != "" {
+		cursor, errCursor := helper.DecodePageCursor(request.Cursor, page.Sort)
+		if errCursor != nil {
+			return []web.CustomLinkResponse{}, web.Pagination{}, errCursor
+		}
+
+		cursorValue, errCursor := parseCustomLinkCursorValue(page.Sort, cursor.Value)
+		if errCursor != nil {
+			return []web.CustomLinkResponse{}, web.Pagination{}, errCursor
+		}
+		page.CursorValue = cursorValue
+		page.CursorID = cursor.ID
+	}
+
+	filter := repository.CustomLinkFilter{TagID: request.TagID, Search: request.Search, Broken: request.Broken}
+	if request.FolderID != 0 {
+		if _, errFolder := service.findFolder(ctx, tx, request.FolderID, claims.Id); errFolder != nil {
+			return []web.CustomLinkResponse{}, web.Pagination{}, ErrFolderIDNotFound
+		}
+		filter.FolderIDs = []uint{request.FolderID}
+
+		if request.IncludeSubfolders {
+			folders, errRepo := service.FolderRepository.FetchAllByUserID(ctx, tx, claims.Id)
+			service.Logger.PanicIfErr(errRepo, ErrCustomLinkService)
+			filter.FolderIDs = append(filter.FolderIDs, helper.FolderDescendantIDs(folders, request.FolderID)...)
+		}
+	}
+
+	customLinks, errRepo := service.CustomLinkRepository.FetchPageByUserIDAndFilter(ctx, tx, claims.Id, filter, page)
 	if errRepo != nil && !errors.Is(errRepo, gorm.ErrRecordNotFound) {
 		service.Logger.PanicIfErr(errRepo, ErrCustomLinkService)
 	}
 
+	pagination := web.Pagination{Limit: page.Limit}
+	if len(customLinks) > page.Limit {
+		customLinks = customLinks[:page.Limit]
+		lastLink := customLinks[len(customLinks)-1]
+		pagination.HasMore = true
+		pagination.NextCursor = helper.EncodePageCursor(helper.PageCursor{
+			Sort:  page.Sort,
+			Value: customLinkCursorValue(page.Sort, &lastLink),
+			ID:    lastLink.ID,
+		})
+	}
+
 	var customLinksResponse []web.CustomLinkResponse
 
 	for _, customLink := range customLinks {
@@ -311,31 +837,94 @@ func (service *CustomLinkServiceImpl) GetAllLink(ctx context.Context, domainName
 		if customLink.CustomThumbnailID != nil {
 			customLinkResponse.ThumbnailUrl = helper.GetCustomThumbnailUrl(domainName, customLink.CustomThumbnail.ImageID)
 		}
-		customLinkResponse.RedirectLink = helper.GetCustomLinkUrl(domainName, customLink.ShortLinkCode)
+		customLinkResponse.RedirectLink = helper.GetCustomLinkUrl(helper.GetLinkDomain(&customLink, domainName), customLink.ShortLinkCode)
+		customLinkResponse.Expired = isExpired(&customLink)
 		customLinksResponse = append(customLinksResponse, customLinkResponse)
 	}
-	return customLinksResponse, nil
+	return customLinksResponse, pagination, nil
 }
 
-func (service *CustomLinkServiceImpl) GetAllThumbnail(ctx context.Context) ([]web.ThumbnailResponse, error) {
+func (service *CustomLinkServiceImpl) ExportLink(ctx context.Context, request web.CustomLinkExportRequest, domainName string, jwtToken string, write func(row web.CustomLinkExportResponse) error) error {
+	// It's getting the claims from the token.
+	claims := service.Jwt.GetClaims(jwtToken)
+
 	// It's a transaction.
 	tx := service.DB.Begin()
 	defer helper.CommitOrRollback(tx)
 
-	thumbnails, errRepo := service.ThumbnailRepository.FetchAll(ctx, tx)
-	var thumbnailsResponse []web.ThumbnailResponse
+	return service.CustomLinkRepository.FetchAllByUserIDInBatches(ctx, tx, claims.Id, BatchSizeCustomLinkExport, func(customLinks []domain.CustomLink) error {
+		var totalClickCounts map[uint]int
+		if request.WithAnalytic {
+			var customLinkIDs []uint
+			for _, customLink := range customLinks {
+				customLinkIDs = append(customLinkIDs, customLink.ID)
+			}
+
+			var errRepo error
+			totalClickCounts, errRepo = service.CustomLinkAnalyticRepository.SumClickCountByLinkIDs(ctx, tx, customLinkIDs)
+			service.Logger.PanicIfErr(errRepo, ErrCustomLinkService)
+		}
+
+		for _, customLink := range customLinks {
+			exportResponse := web.CustomLinkExportResponse{
+				ID:                customLink.ID,
+				Title:             customLink.Title,
+				ShortLinkCode:     customLink.ShortLinkCode,
+				LongLink:          customLink.LongLink,
+				RedirectLink:      helper.GetCustomLinkUrl(helper.GetLinkDomain(&customLink, domainName), customLink.ShortLinkCode),
+				ShowOnProfile:     customLink.ShowOnProfile,
+				Activate:          customLink.Activate,
+				Expired:           isExpired(&customLink),
+				PasswordProtected: customLink.Password != "",
+				CreatedAt:         customLink.CreatedAt,
+				UpdatedAt:         customLink.UpdatedAt,
+				ExpiresAt:         customLink.ExpiresAt,
+			}
+
+			if customLink.ThumbnailID != nil {
+				exportResponse.ThumbnailUrl = customLink.Thumbnail.IconUrl
+			}
+			if customLink.CustomThumbnailID != nil {
+				exportResponse.ThumbnailUrl = helper.GetCustomThumbnailUrl(domainName, customLink.CustomThumbnail.ImageID)
+			}
+
+			if request.WithAnalytic {
+				totalClickCount := totalClickCounts[customLink.ID]
+				exportResponse.TotalClickCount = &totalClickCount
+			}
+
+			if err := write(exportResponse); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (service *CustomLinkServiceImpl) DeleteLink(ctx context.Context, request web.CustomLinkDeleteRequest, jwtToken string) error {
+	// It's getting the claims from the token.
+	claims := service.Jwt.GetClaims(jwtToken)
+
+	// It's a transaction.
+	tx := service.DB.Begin()
+	defer helper.CommitOrRollback(tx)
+
+	customLink, errRepo := service.CustomLinkRepository.FindByIdAndUserID(ctx, tx, int(request.LinkID), claims.Id)
 	if errRepo != nil && !errors.Is(errRepo, gorm.ErrRecordNotFound) {
 		service.Logger.PanicIfErr(errRepo, ErrCustomLinkService)
 	}
 
-	for _, thumbnail := range thumbnails {
-		thumbnailResponse := helper.ThumbnailDomainToResponse(&thumbnail)
-		thumbnailsResponse = append(thumbnailsResponse, thumbnailResponse)
+	if errors.Is(errRepo, gorm.ErrRecordNotFound) {
+		return ErrCustomLinkNotRegistered
 	}
-	return thumbnailsResponse, nil
+
+	// It's a soft delete, interactions and analytics of the link are kept for reporting.
+	errRepo = service.CustomLinkRepository.Delete(ctx, tx, customLink)
+	service.Logger.PanicIfErr(errRepo, ErrCustomLinkService)
+	return nil
 }
 
-func (service *CustomLinkServiceImpl) GetUserThumbnail(ctx context.Context, domainName string, jwtToken string) ([]web.ThumbnailResponse, error) {
+func (service *CustomLinkServiceImpl) GetAllDeletedLink(ctx context.Context, domainName string, jwtToken string) ([]web.CustomLinkResponse, error) {
 	// It's getting the claims from the token.
 	claims := service.Jwt.GetClaims(jwtToken)
 
@@ -343,20 +932,34 @@ func (service *CustomLinkServiceImpl) GetUserThumbnail(ctx context.Context, doma
 	tx := service.DB.Begin()
 	defer helper.CommitOrRollback(tx)
 
-	customThumbnails, errRepo := service.CustomThumbnailRepository.FetchAllByUserID(ctx, tx, claims.Id)
+	customLinks, errRepo := service.CustomLinkRepository.FetchAllDeletedByUserID(ctx, tx, claims.Id)
 	if errRepo != nil && !errors.Is(errRepo, gorm.ErrRecordNotFound) {
 		service.Logger.PanicIfErr(errRepo, ErrCustomLinkService)
 	}
 
-	var thumbnailsResponse []web.ThumbnailResponse
-	for _, customThumbnail := range customThumbnails {
-		thumbnailResponse := helper.CustomThumbnailDomainToResponse(&customThumbnail, domainName)
-		thumbnailsResponse = append(thumbnailsResponse, thumbnailResponse)
+	var customLinksResponse []web.CustomLinkResponse
+
+	for _, customLink := range customLinks {
+		customLinkResponse := helper.CustomLinkDomainToResponse(&customLink)
+		if customLink.ThumbnailID != nil {
+			customLinkResponse.ThumbnailUrl = customLink.Thumbnail.IconUrl
+		}
+		if customLink.CustomThumbnailID != nil {
+			customLinkResponse.ThumbnailUrl = helper.GetCustomThumbnailUrl(domainName, customLink.CustomThumbnail.ImageID)
+		}
+		if customLink.ReleasedShortLinkCode != "" {
+			customLinkResponse.ShortLinkCode = customLink.ReleasedShortLinkCode
+		} else {
+			customLinkResponse.RedirectLink = helper.GetCustomLinkUrl(helper.GetLinkDomain(&customLink, domainName), customLink.ShortLinkCode)
+		}
+		releaseAt := customLink.DeletedAt.Time.Add(RetentionDurationDeletedCustomLink)
+		customLinkResponse.ShortLinkCodeReleaseAt = &releaseAt
+		customLinksResponse = append(customLinksResponse, customLinkResponse)
 	}
-	return thumbnailsResponse, nil
+	return customLinksResponse, nil
 }
 
-func (service *CustomLinkServiceImpl) UploadCustomThumbnail(ctx context.Context, imgData []byte, domainName string, jwtToken string) (web.ThumbnailResponse, error) {
+func (service *CustomLinkServiceImpl) RestoreLink(ctx context.Context, request web.CustomLinkRestoreRequest, domainName string, jwtToken string) (web.CustomLinkResponse, error) {
 	// It's getting the claims from the token.
 	claims := service.Jwt.GetClaims(jwtToken)
 
@@ -364,71 +967,1165 @@ func (service *CustomLinkServiceImpl) UploadCustomThumbnail(ctx context.Context,
 	tx := service.DB.Begin()
 	defer helper.CommitOrRollback(tx)
 
-	// It's decoding the image from the byte array.
-	reader := bytes.NewReader(imgData)
-	img, _, err := image.Decode(reader)
-	service.Logger.PanicIfErr(err, ErrCustomLinkService)
+	customLink, errRepo := service.CustomLinkRepository.FindDeletedByIdAndUserID(ctx, tx, int(request.LinkID), claims.Id)
+	if errRepo != nil && !errors.Is(errRepo, gorm.ErrRecordNotFound) {
+		service.Logger.PanicIfErr(errRepo, ErrCustomLinkService)
+	}
 
-	resizeImg := resize.Resize(150, 150, img, resize.Lanczos3)
-	uuid := uuid.New().String()
-	fileName := fmt.Sprintf("%s.jpg", uuid)
+	if errors.Is(errRepo, gorm.ErrRecordNotFound) {
+		return web.CustomLinkResponse{}, ErrCustomLinkNotDeleted
+	}
 
-	thumbnailResourcePath := os.Getenv("THUMBNAIL_IMG_DIR")
-	filePath := path.Join(thumbnailResourcePath, fileName)
+	// It's reclaiming the short link code, only if nobody has registered it in the meantime. A code past its retention
+	// period may be free even when it isn't released yet, the release worker runs on a schedule.
+	if customLink.ReleasedShortLinkCode != "" {
+		customLink.ShortLinkCode = customLink.ReleasedShortLinkCode
+		customLink.ReleasedShortLinkCode = ""
+	}
 
-	out, err := os.Create(filePath)
-	service.Logger.PanicIfErr(err, ErrCustomLinkService)
-	defer out.Close()
-	jpeg.Encode(out, resizeImg, nil)
+	if service.isShortLinkCodeTaken(ctx, tx, customLink.DomainID, customLink.ShortLinkCode, customLink.CaseInsensitive, customLink.ID) {
+		return web.CustomLinkResponse{}, ErrShortLinkCodeReclaimed
+	}
+
+	customLink, errRepo = service.CustomLinkRepository.Restore(ctx, tx, customLink)
+	service.Logger.PanicIfErr(errRepo, ErrCustomLinkService)
+	customLink.DeletedAt = gorm.DeletedAt{}
+
+	customLinkResponse := helper.CustomLinkDomainToResponse(&customLink)
+	if customLink.ThumbnailID != nil {
+		customLinkResponse.ThumbnailUrl = customLink.Thumbnail.IconUrl
+	}
+	if customLink.CustomThumbnailID != nil {
+		customLinkResponse.ThumbnailUrl = helper.GetCustomThumbnailUrl(domainName, customLink.CustomThumbnail.ImageID)
+	}
+	customLinkResponse.RedirectLink = helper.GetCustomLinkUrl(helper.GetLinkDomain(&customLink, domainName), customLink.ShortLinkCode)
+	customLinkResponse.Expired = isExpired(&customLink)
+	return customLinkResponse, nil
+}
+
+func (service *CustomLinkServiceImpl) GetLinkHistory(ctx context.Context, request web.CustomLinkHistoryRequest, jwtToken string) ([]web.CustomLinkRevisionResponse, error) {
+	// It's getting the claims from the token.
+	claims := service.Jwt.GetClaims(jwtToken)
+
+	// It's a transaction.
+	tx := service.DB.Begin()
+	defer helper.CommitOrRollback(tx)
+
+	customLink, errRepo := service.CustomLinkRepository.FindByIdAndUserID(ctx, tx, int(request.LinkID), claims.Id)
+	if errRepo != nil && !errors.Is(errRepo, gorm.ErrRecordNotFound) {
+		service.Logger.PanicIfErr(errRepo, ErrCustomLinkService)
+	}
+
+	if errors.Is(errRepo, gorm.ErrRecordNotFound) {
+		return []web.CustomLinkRevisionResponse{}, ErrCustomLinkNotRegistered
+	}
+
+	revisions, errRepo := service.CustomLinkRevisionRepository.FetchAllByLinkID(ctx, tx, customLink.ID)
+	service.Logger.PanicIfErr(errRepo, ErrCustomLinkService)
+
+	return helper.CustomLinkRevisionsToResponse(revisions), nil
+}
+
+// RevertLink puts back the destination of the given revision, the revert itself is recorded as a new revision.
+func (service *CustomLinkServiceImpl) RevertLink(ctx context.Context, request web.CustomLinkRevertRequest, domainName string, jwtToken string) (web.CustomLinkResponse, error) {
+	// It's getting the claims from the token.
+	claims := service.Jwt.GetClaims(jwtToken)
+
+	// It's a transaction.
+	tx := service.DB.Begin()
+	defer helper.CommitOrRollback(tx)
+
+	// It's locking the link, its next revision number is only taken once.
+	customLink, errRepo := service.CustomLinkRepository.FindByIdAndUserIDForUpdate(ctx, tx, int(request.LinkID), claims.Id)
+	if errRepo != nil && !errors.Is(errRepo, gorm.ErrRecordNotFound) {
+		service.Logger.PanicIfErr(errRepo, ErrCustomLinkService)
+	}
+
+	if errors.Is(errRepo, gorm.ErrRecordNotFound) {
+		return web.CustomLinkResponse{}, ErrCustomLinkNotRegistered
+	}
+
+	revision, errRepo := service.CustomLinkRevisionRepository.FindByLinkIDAndRevision(ctx, tx, customLink.ID, request.Revision)
+	if errRepo != nil && !errors.Is(errRepo, gorm.ErrRecordNotFound) {
+		service.Logger.PanicIfErr(errRepo, ErrCustomLinkService)
+	}
+
+	if errors.Is(errRepo, gorm.ErrRecordNotFound) {
+		return web.CustomLinkResponse{}, ErrCustomLinkRevisionNotFound
+	}
+
+	if revision.ShortLinkCode != customLink.ShortLinkCode && service.isShortLinkCodeReserved(ctx, tx, revision.ShortLinkCode) {
+		return web.CustomLinkResponse{}, ErrShortLinkCodeReserved
+	}
+
+	if revision.ShortLinkCode != customLink.ShortLinkCode && service.isShortLinkCodeTaken(ctx, tx, customLink.DomainID, revision.ShortLinkCode, customLink.CaseInsensitive, customLink.ID) {
+		return web.CustomLinkResponse{}, ErrShortLinkCodeRegistered
+	}
+
+	if err := service.checkDestinations(ctx, tx, revision.LongLink, nil, nil, domainName); err != nil {
+		return web.CustomLinkResponse{}, err
+	}
+
+	previousRevision := helper.CustomLinkSnapshot(&customLink)
+	customLink.Title = revision.Title
+	customLink.ShortLinkCode = revision.ShortLinkCode
+	customLink.LongLink = revision.LongLink
+	customLink.Utm = revision.Utm
+
+	customLink, errRepo = service.CustomLinkRepository.Update(ctx, tx, customLink)
+	service.Logger.PanicIfErr(errRepo, ErrCustomLinkService)
+	service.resetHealth(ctx, tx, &customLink, previousRevision.LongLink)
+
+	service.recordRevision(ctx, tx, previousRevision, &customLink, RevisionActionRevert, &revision.Revision, claims)
+
+	customLinkResponse := helper.CustomLinkDomainToResponse(&customLink)
+	if customLink.CustomThumbnailID != nil {
+		customLinkResponse.ThumbnailUrl = helper.GetCustomThumbnailUrl(domainName, customLink.CustomThumbnail.ImageID)
+	} else if customLink.ThumbnailID != nil {
+		customLinkResponse.ThumbnailUrl = customLink.Thumbnail.IconUrl
+	}
+	customLinkResponse.RedirectLink = helper.GetCustomLinkUrl(helper.GetLinkDomain(&customLink, domainName), customLink.ShortLinkCode)
+	customLinkResponse.Expired = isExpired(&customLink)
+	customLinkResponse.TargetingRules = service.getTargetingRules(ctx, tx, customLink.ID)
+	customLinkResponse.Variants = service.getVariants(ctx, tx, customLink.ID)
+	return customLinkResponse, nil
+}
+
+// resetHealth clears the health check results once the link points somewhere else, they were about the old destination.
+func (service *CustomLinkServiceImpl) resetHealth(ctx context.Context, tx *gorm.DB, customLink *domain.CustomLink, previousLongLink string) {
+	if customLink.LongLink == previousLongLink || customLink.Health.CheckedAt == nil {
+		return
+	}
+
+	customLink.Health = domain.LinkHealth{}
+	errRepo := service.CustomLinkRepository.UpdateHealth(ctx, tx, customLink.ID, customLink.Health)
+	service.Logger.PanicIfErr(errRepo, ErrCustomLinkService)
+}
+
+// recordRevision stores the destination of the link when it differs from the last revision. A link without
+// revisions gets the destination before the change as its first revision, so the change can be reverted.
+func (service *CustomLinkServiceImpl) recordRevision(ctx context.Context, tx *gorm.DB, previousRevision domain.CustomLinkRevision, customLink *domain.CustomLink, action string, revertedFrom *uint, claims helper.JwtUserClaims) {
+	latestRevision, errRepo := service.CustomLinkRevisionRepository.FindLatestByLinkID(ctx, tx, customLink.ID)
+	if errRepo != nil && !errors.Is(errRepo, gorm.ErrRecordNotFound) {
+		service.Logger.PanicIfErr(errRepo, ErrCustomLinkService)
+	}
+
+	if errors.Is(errRepo, gorm.ErrRecordNotFound) {
+		previousRevision.Action = RevisionActionInitial
+		previousRevision.UserID = customLink.UserID
+		latestRevision, errRepo = service.CustomLinkRevisionRepository.Create(ctx, tx, previousRevision)
+		service.Logger.PanicIfErr(errRepo, ErrCustomLinkService)
+	}
+
+	revision := helper.CustomLinkSnapshot(customLink)
+	if helper.IsSameRevision(&latestRevision, &revision) {
+		return
+	}
+
+	revision.Action = action
+	revision.RevertedFrom = revertedFrom
+	revision.UserID = claims.Id
+	revision.Username = claims.Username
+	_, errRepo = service.CustomLinkRevisionRepository.Create(ctx, tx, revision)
+	service.Logger.PanicIfErr(errRepo, ErrCustomLinkService)
+}
+
+// CloneLink creates a new link with the configuration of an existing one, the clone gets its own short link code
+// and its analytics start empty.
+func (service *CustomLinkServiceImpl) CloneLink(ctx context.Context, request web.CustomLinkCloneRequest, domainName string, jwtToken string) (web.CustomLinkResponse, error) {
+	// It's getting the claims from the token.
+	claims := service.Jwt.GetClaims(jwtToken)
+
+	// It's a transaction.
+	tx := service.DB.Begin()
+	defer helper.CommitOrRollback(tx)
+
+	source, errRepo := service.CustomLinkRepository.FindByIdAndUserID(ctx, tx, int(request.LinkID), claims.Id)
+	if errRepo != nil && !errors.Is(errRepo, gorm.ErrRecordNotFound) {
+		service.Logger.PanicIfErr(errRepo, ErrCustomLinkService)
+	}
+
+	if errors.Is(errRepo, gorm.ErrRecordNotFound) {
+		return web.CustomLinkResponse{}, ErrCustomLinkNotRegistered
+	}
+
+	targetingRules, errRepo := service.CustomLinkTargetingRuleRepository.FetchAllByLinkID(ctx, tx, source.ID)
+	service.Logger.PanicIfErr(errRepo, ErrCustomLinkService)
+
+	variants, errRepo := service.CustomLinkVariantRepository.FetchAllByLinkID(ctx, tx, source.ID)
+	service.Logger.PanicIfErr(errRepo, ErrCustomLinkService)
+
+	// It's checking the destinations again, the blocklist may have changed since the link was created.
+	var targetingRuleRequests []web.CustomLinkTargetingRuleRequest
+	for _, targetingRule := range targetingRules {
+		targetingRuleRequests = append(targetingRuleRequests, web.CustomLinkTargetingRuleRequest{TargetLink: targetingRule.TargetLink})
+	}
+
+	var variantRequests []web.CustomLinkVariantRequest
+	for _, variant := range variants {
+		variantRequests = append(variantRequests, web.CustomLinkVariantRequest{TargetLink: variant.TargetLink})
+	}
+
+	if err := service.checkDestinations(ctx, tx, source.LongLink, targetingRuleRequests, variantRequests, domainName); err != nil {
+		return web.CustomLinkResponse{}, err
+	}
+
+	customLink := helper.CloneCustomLink(&source)
+	if request.Title != "" {
+		customLink.Title = request.Title
+	}
+
+	customLink.CaseInsensitive = service.isCaseInsensitive(ctx, tx, claims.Id)
+	if request.ShortLinkCode == "" {
+		shortLinkCode, errGenerate := service.generateShortLinkCode(ctx, tx, customLink.DomainID, customLink.CaseInsensitive)
+		if errGenerate != nil {
+			return web.CustomLinkResponse{}, errGenerate
+		}
+		customLink.ShortLinkCode = shortLinkCode
+	} else if service.isShortLinkCodeReserved(ctx, tx, request.ShortLinkCode) {
+		return web.CustomLinkResponse{}, ErrShortLinkCodeReserved
+	} else if service.isShortLinkCodeTaken(ctx, tx, customLink.DomainID, request.ShortLinkCode, customLink.CaseInsensitive, 0) {
+		return web.CustomLinkResponse{}, ErrShortLinkCodeRegistered
+	} else {
+		customLink.ShortLinkCode = request.ShortLinkCode
+	}
+
+	customLink, errRepo = service.CustomLinkRepository.Create(ctx, tx, customLink)
+	service.Logger.PanicIfErr(errRepo, ErrCustomLinkService)
+	customLink.CustomThumbnail = source.CustomThumbnail
+	customLink.Thumbnail = source.Thumbnail
+	customLink.Domain = source.Domain
+
+	targetingRules, errRepo = service.CustomLinkTargetingRuleRepository.ReplaceByLinkID(ctx, tx, customLink.ID, helper.CloneTargetingRules(targetingRules))
+	service.Logger.PanicIfErr(errRepo, ErrCustomLinkService)
+
+	variants, errRepo = service.CustomLinkVariantRepository.ReplaceByLinkID(ctx, tx, customLink.ID, helper.CloneVariants(variants))
+	service.Logger.PanicIfErr(errRepo, ErrCustomLinkService)
+
+	revision := helper.CustomLinkSnapshot(&customLink)
+	revision.Action = RevisionActionCreate
+	revision.UserID = claims.Id
+	revision.Username = claims.Username
+	_, errRepo = service.CustomLinkRevisionRepository.Create(ctx, tx, revision)
+	service.Logger.PanicIfErr(errRepo, ErrCustomLinkService)
+
+	var thumbnailUrl string
+	if customLink.CustomThumbnailID != nil {
+		thumbnailUrl = helper.GetCustomThumbnailUrl(domainName, customLink.CustomThumbnail.ImageID)
+	}
+
+	if customLink.ThumbnailID != nil {
+		thumbnailUrl = customLink.Thumbnail.IconUrl
+	}
+
+	customLinkResponse := helper.CustomLinkDomainToResponse(&customLink)
+	for _, targetingRule := range targetingRules {
+		customLinkResponse.TargetingRules = append(customLinkResponse.TargetingRules, helper.CustomLinkTargetingRuleDomainToResponse(&targetingRule))
+	}
+
+	for _, variant := range variants {
+		customLinkResponse.Variants = append(customLinkResponse.Variants, helper.CustomLinkVariantDomainToResponse(&variant))
+	}
+	customLinkResponse.ThumbnailUrl = thumbnailUrl
+	customLinkResponse.RedirectLink = helper.GetCustomLinkUrl(helper.GetLinkDomain(&customLink, domainName), customLink.ShortLinkCode)
+	customLinkResponse.Expired = isExpired(&customLink)
+	return customLinkResponse, nil
+}
+
+func (service *CustomLinkServiceImpl) GetAllThumbnail(ctx context.Context) ([]web.ThumbnailResponse, error) {
+	// It's a transaction.
+	tx := service.DB.Begin()
+	defer helper.CommitOrRollback(tx)
+
+	thumbnails, errRepo := service.ThumbnailRepository.FetchAll(ctx, tx)
+	var thumbnailsResponse []web.ThumbnailResponse
+	if errRepo != nil && !errors.Is(errRepo, gorm.ErrRecordNotFound) {
+		service.Logger.PanicIfErr(errRepo, ErrCustomLinkService)
+	}
+
+	for _, thumbnail := range thumbnails {
+		thumbnailResponse := helper.ThumbnailDomainToResponse(&thumbnail)
+		thumbnailsResponse = append(thumbnailsResponse, thumbnailResponse)
+	}
+	return thumbnailsResponse, nil
+}
+
+func (service *CustomLinkServiceImpl) GetUserThumbnail(ctx context.Context, domainName string, jwtToken string) ([]web.ThumbnailResponse, error) {
+	// It's getting the claims from the token.
+	claims := service.Jwt.GetClaims(jwtToken)
+
+	// It's a transaction.
+	tx := service.DB.Begin()
+	defer helper.CommitOrRollback(tx)
+
+	customThumbnails, errRepo := service.CustomThumbnailRepository.FetchAllByUserID(ctx, tx, claims.Id)
+	if errRepo != nil && !errors.Is(errRepo, gorm.ErrRecordNotFound) {
+		service.Logger.PanicIfErr(errRepo, ErrCustomLinkService)
+	}
+
+	var thumbnailsResponse []web.ThumbnailResponse
+	for _, customThumbnail := range customThumbnails {
+		thumbnailResponse := helper.CustomThumbnailDomainToResponse(&customThumbnail, domainName)
+		thumbnailsResponse = append(thumbnailsResponse, thumbnailResponse)
+	}
+	return thumbnailsResponse, nil
+}
+
+func (service *CustomLinkServiceImpl) UploadCustomThumbnail(ctx context.Context, imgData []byte, domainName string, jwtToken string) (web.ThumbnailResponse, error) {
+	// It's getting the claims from the token.
+	claims := service.Jwt.GetClaims(jwtToken)
+
+	// It's a transaction.
+	tx := service.DB.Begin()
+	defer helper.CommitOrRollback(tx)
+
+	// It's decoding the image from the byte array.
+	reader := bytes.NewReader(imgData)
+	img, _, err := image.Decode(reader)
+	service.Logger.PanicIfErr(err, ErrCustomLinkService)
+
+	resizeImg := resize.Resize(150, 150, img, resize.Lanczos3)
+	uuid := uuid.New().String()
+	fileName := fmt.Sprintf("%s.jpg", uuid)
+
+	thumbnailResourcePath := os.Getenv("THUMBNAIL_IMG_DIR")
+	filePath := path.Join(thumbnailResourcePath, fileName)
+
+	out, err := os.Create(filePath)
+	service.Logger.PanicIfErr(err, ErrCustomLinkService)
+	defer out.Close()
+	jpeg.Encode(out, resizeImg, nil)
 
 	thumbnail := domain.CustomThumbnail{
 		UserID:  claims.Id,
 		ImageID: uuid,
 	}
 
-	thumbnail, errRepo := service.CustomThumbnailRepository.Create(ctx, tx, thumbnail)
-	service.Logger.PanicIfErr(errRepo, ErrCustomLinkService)
+	thumbnail, errRepo := service.CustomThumbnailRepository.Create(ctx, tx, thumbnail)
+	service.Logger.PanicIfErr(errRepo, ErrCustomLinkService)
+
+	thumbnailResponse := helper.CustomThumbnailDomainToResponse(&thumbnail, domainName)
+
+	return thumbnailResponse, nil
+}
+
+func (service *CustomLinkServiceImpl) GetLinkQRCode(ctx context.Context, request web.CustomLinkQRCodeRequest, domainName string, jwtToken string) (web.QRCodeResponse, error) {
+	// It's getting the claims from the token.
+	claims := service.Jwt.GetClaims(jwtToken)
+
+	// It's a transaction.
+	tx := service.DB.Begin()
+	defer helper.CommitOrRollback(tx)
+
+	customLink, errRepo := service.CustomLinkRepository.FindByIdAndUserID(ctx, tx, int(request.LinkID), claims.Id)
+	if errRepo != nil && !errors.Is(errRepo, gorm.ErrRecordNotFound) {
+		service.Logger.PanicIfErr(errRepo, ErrCustomLinkService)
+	}
+
+	if errors.Is(errRepo, gorm.ErrRecordNotFound) {
+		return web.QRCodeResponse{}, ErrCustomLinkNotRegistered
+	}
+
+	// It's using the custom thumbnail of the link as the center logo.
+	var logo image.Image
+	if request.Logo {
+		if customLink.CustomThumbnailID == nil {
+			return web.QRCodeResponse{}, ErrQRCodeLogoNotFound
+		}
+
+		thumbnailResourcePath := os.Getenv("THUMBNAIL_IMG_DIR")
+		file, err := os.Open(path.Join(thumbnailResourcePath, fmt.Sprintf("%s.jpg", customLink.CustomThumbnail.ImageID)))
+		service.Logger.PanicIfErr(err, ErrCustomLinkService)
+		defer file.Close()
+
+		logo, _, err = image.Decode(file)
+		service.Logger.PanicIfErr(err, ErrCustomLinkService)
+	}
+
+	content := helper.GetCustomLinkQRCodeUrl(helper.GetLinkDomain(&customLink, domainName), customLink.ShortLinkCode)
+	return helper.RenderQRCode(content, request.QRCodeOptionRequest, logo)
+}
+
+// BatchLink applies one action to every listed link of the user in a single transaction. Ids that aren't links of
+// the user are reported as failed while the rest of the batch is applied.
+func (service *CustomLinkServiceImpl) BatchLink(ctx context.Context, request web.CustomLinkBatchRequest, jwtToken string) (web.CustomLinkBatchResponse, error) {
+	// It's getting the claims from the token.
+	claims := service.Jwt.GetClaims(jwtToken)
+
+	// It's a transaction.
+	tx := service.DB.Begin()
+	defer helper.CommitOrRollback(tx)
+
+	// It's checking the parameters of the action first, they are the same for every link of the batch.
+	var tags []domain.Tag
+	var folderID *uint
+	switch request.Action {
+	case BatchActionRetag:
+		if request.TagIDs == nil {
+			return web.CustomLinkBatchResponse{}, ErrCustomLinkBatchTagIDs
+		}
+
+		var errTags error
+		tags, errTags = service.findTags(ctx, tx, *request.TagIDs, claims.Id)
+		if errTags != nil {
+			return web.CustomLinkBatchResponse{}, errTags
+		}
+	case BatchActionMove:
+		if request.FolderID == nil {
+			return web.CustomLinkBatchResponse{}, ErrCustomLinkBatchFolderID
+		}
+
+		if *request.FolderID != 0 {
+			if _, errFolder := service.findFolder(ctx, tx, *request.FolderID, claims.Id); errFolder != nil {
+				return web.CustomLinkBatchResponse{}, ErrFolderIDNotFound
+			}
+			folderID = request.FolderID
+		}
+	}
+
+	customLinks, errRepo := service.CustomLinkRepository.FetchAllByIDsAndUserID(ctx, tx, request.LinkIDs, claims.Id)
+	service.Logger.PanicIfErr(errRepo, ErrCustomLinkService)
+
+	var linkIDs []uint
+	for _, customLink := range customLinks {
+		linkIDs = append(linkIDs, customLink.ID)
+	}
+
+	if len(linkIDs) > 0 {
+		switch request.Action {
+		case BatchActionActivate, BatchActionDeactivate:
+			errRepo = service.CustomLinkRepository.UpdateColumnsByIDsAndUserID(ctx, tx, linkIDs, claims.Id, map[string]interface{}{"activate": request.Action == BatchActionActivate})
+		case BatchActionShow, BatchActionHide:
+			errRepo = service.CustomLinkRepository.UpdateColumnsByIDsAndUserID(ctx, tx, linkIDs, claims.Id, map[string]interface{}{"show_on_profile": request.Action == BatchActionShow})
+		case BatchActionMove:
+			errRepo = service.CustomLinkRepository.UpdateColumnsByIDsAndUserID(ctx, tx, linkIDs, claims.Id, map[string]interface{}{"folder_id": folderID})
+		case BatchActionRetag:
+			for _, customLink := range customLinks {
+				errRepo = service.CustomLinkRepository.ReplaceTags(ctx, tx, customLink, tags)
+				service.Logger.PanicIfErr(errRepo, ErrCustomLinkService)
+			}
+		case BatchActionDelete:
+			// It's a soft delete like DeleteLink, the links can be restored from the trash.
+			errRepo = service.CustomLinkRepository.DeleteByIDsAndUserID(ctx, tx, linkIDs, claims.Id)
+		}
+		service.Logger.PanicIfErr(errRepo, ErrCustomLinkService)
+	}
+
+	return web.CustomLinkBatchResponse{
+		Action:    request.Action,
+		Succeeded: len(linkIDs),
+		Failed:    len(request.LinkIDs) - len(linkIDs),
+		Results:   helper.BatchResults(request.LinkIDs, linkIDs, ErrCustomLinkNotRegistered.Error()),
+	}, nil
+}
+
+func (service *CustomLinkServiceImpl) ReorderLink(ctx context.Context, request web.CustomLinkReorderRequest, jwtToken string) ([]web.CustomLinkPositionResponse, error) {
+	// It's getting the claims from the token.
+	claims := service.Jwt.GetClaims(jwtToken)
+
+	// It's a transaction.
+	tx := service.DB.Begin()
+	defer helper.CommitOrRollback(tx)
+
+	customLinks, errRepo := service.CustomLinkRepository.FetchAllByUserID(ctx, tx, claims.Id)
+	service.Logger.PanicIfErr(errRepo, ErrCustomLinkService)
+
+	var currentLinkIDs []uint
+	for _, customLink := range customLinks {
+		currentLinkIDs = append(currentLinkIDs, customLink.ID)
+	}
+
+	// It's keeping the links missing from the request after the reordered ones.
+	linkIDs, ok := helper.ReorderIDs(currentLinkIDs, request.LinkIDs)
+	if !ok {
+		return []web.CustomLinkPositionResponse{}, ErrCustomLinkReorderInvalid
+	}
+
+	errRepo = service.CustomLinkRepository.UpdatePositions(ctx, tx, claims.Id, linkIDs)
+	service.Logger.PanicIfErr(errRepo, ErrCustomLinkService)
+
+	var positionsResponse []web.CustomLinkPositionResponse
+	for i, linkID := range linkIDs {
+		positionsResponse = append(positionsResponse, web.CustomLinkPositionResponse{LinkID: linkID, Position: i + 1})
+	}
+	return positionsResponse, nil
+}
+
+func (service *CustomLinkServiceImpl) CreateTag(ctx context.Context, request web.TagCreateRequest, jwtToken string) (web.TagResponse, error) {
+	// It's getting the claims from the token.
+	claims := service.Jwt.GetClaims(jwtToken)
+
+	// It's a transaction.
+	tx := service.DB.Begin()
+	defer helper.CommitOrRollback(tx)
+
+	_, errRepo := service.TagRepository.FindByNameAndUserID(ctx, tx, request.Name, claims.Id)
+	if errRepo == nil {
+		return web.TagResponse{}, ErrTagNameRegistered
+	}
+	if !errors.Is(errRepo, gorm.ErrRecordNotFound) {
+		service.Logger.PanicIfErr(errRepo, ErrCustomLinkService)
+	}
+
+	tag, errRepo := service.TagRepository.Create(ctx, tx, domain.Tag{UserID: claims.Id, Name: request.Name})
+	service.Logger.PanicIfErr(errRepo, ErrCustomLinkService)
+
+	return helper.TagDomainToResponse(&tag), nil
+}
+
+func (service *CustomLinkServiceImpl) GetAllTag(ctx context.Context, jwtToken string) ([]web.TagResponse, error) {
+	// It's getting the claims from the token.
+	claims := service.Jwt.GetClaims(jwtToken)
+
+	// It's a transaction.
+	tx := service.DB.Begin()
+	defer helper.CommitOrRollback(tx)
+
+	tags, errRepo := service.TagRepository.FetchAllByUserID(ctx, tx, claims.Id)
+	service.Logger.PanicIfErr(errRepo, ErrCustomLinkService)
+
+	var tagsResponse []web.TagResponse
+	for _, tag := range tags {
+		tagsResponse = append(tagsResponse, helper.TagDomainToResponse(&tag))
+	}
+	return tagsResponse, nil
+}
+
+func (service *CustomLinkServiceImpl) UpdateTag(ctx context.Context, request web.TagUpdateRequest, jwtToken string) (web.TagResponse, error) {
+	// It's getting the claims from the token.
+	claims := service.Jwt.GetClaims(jwtToken)
+
+	// It's a transaction.
+	tx := service.DB.Begin()
+	defer helper.CommitOrRollback(tx)
+
+	tag, errRepo := service.TagRepository.FindByIdAndUserID(ctx, tx, request.TagID, claims.Id)
+	if errRepo != nil && !errors.Is(errRepo, gorm.ErrRecordNotFound) {
+		service.Logger.PanicIfErr(errRepo, ErrCustomLinkService)
+	}
+
+	if errors.Is(errRepo, gorm.ErrRecordNotFound) {
+		return web.TagResponse{}, ErrTagNotRegistered
+	}
+
+	if request.Name == "" {
+		return helper.TagDomainToResponse(&tag), nil
+	}
+
+	sameNameTag, errRepo := service.TagRepository.FindByNameAndUserID(ctx, tx, request.Name, claims.Id)
+	if errRepo == nil && sameNameTag.ID != tag.ID {
+		return web.TagResponse{}, ErrTagNameRegistered
+	}
+	if errRepo != nil && !errors.Is(errRepo, gorm.ErrRecordNotFound) {
+		service.Logger.PanicIfErr(errRepo, ErrCustomLinkService)
+	}
+
+	tag.Name = request.Name
+	tag, errRepo = service.TagRepository.Update(ctx, tx, tag)
+	service.Logger.PanicIfErr(errRepo, ErrCustomLinkService)
+
+	return helper.TagDomainToResponse(&tag), nil
+}
+
+func (service *CustomLinkServiceImpl) DeleteTag(ctx context.Context, request web.TagDeleteRequest, jwtToken string) error {
+	// It's getting the claims from the token.
+	claims := service.Jwt.GetClaims(jwtToken)
+
+	// It's a transaction.
+	tx := service.DB.Begin()
+	defer helper.CommitOrRollback(tx)
+
+	tag, errRepo := service.TagRepository.FindByIdAndUserID(ctx, tx, request.TagID, claims.Id)
+	if errRepo != nil && !errors.Is(errRepo, gorm.ErrRecordNotFound) {
+		service.Logger.PanicIfErr(errRepo, ErrCustomLinkService)
+	}
+
+	if errors.Is(errRepo, gorm.ErrRecordNotFound) {
+		return ErrTagNotRegistered
+	}
+
+	errRepo = service.TagRepository.Delete(ctx, tx, tag)
+	service.Logger.PanicIfErr(errRepo, ErrCustomLinkService)
+	return nil
+}
+
+func (service *CustomLinkServiceImpl) CreateFolder(ctx context.Context, request web.FolderCreateRequest, jwtToken string) (web.FolderResponse, error) {
+	// It's getting the claims from the token.
+	claims := service.Jwt.GetClaims(jwtToken)
+
+	// It's a transaction.
+	tx := service.DB.Begin()
+	defer helper.CommitOrRollback(tx)
+
+	if request.ParentID != nil && *request.ParentID != 0 {
+		if _, errFolder := service.findFolder(ctx, tx, *request.ParentID, claims.Id); errFolder != nil {
+			return web.FolderResponse{}, ErrFolderParentIDNotFound
+		}
+	} else {
+		request.ParentID = nil
+	}
+
+	folder, errRepo := service.FolderRepository.Create(ctx, tx, domain.Folder{
+		UserID:   claims.Id,
+		Name:     request.Name,
+		ParentID: request.ParentID,
+	})
+	service.Logger.PanicIfErr(errRepo, ErrCustomLinkService)
+
+	return helper.FolderDomainToResponse(&folder), nil
+}
+
+func (service *CustomLinkServiceImpl) GetAllFolder(ctx context.Context, jwtToken string) ([]web.FolderResponse, error) {
+	// It's getting the claims from the token.
+	claims := service.Jwt.GetClaims(jwtToken)
+
+	// It's a transaction.
+	tx := service.DB.Begin()
+	defer helper.CommitOrRollback(tx)
+
+	folders, errRepo := service.FolderRepository.FetchAllByUserID(ctx, tx, claims.Id)
+	service.Logger.PanicIfErr(errRepo, ErrCustomLinkService)
+
+	return helper.FoldersToTree(folders), nil
+}
+
+func (service *CustomLinkServiceImpl) UpdateFolder(ctx context.Context, request web.FolderUpdateRequest, jwtToken string) (web.FolderResponse, error) {
+	// It's getting the claims from the token.
+	claims := service.Jwt.GetClaims(jwtToken)
+
+	// It's a transaction.
+	tx := service.DB.Begin()
+	defer helper.CommitOrRollback(tx)
+
+	folder, errFolder := service.findFolder(ctx, tx, request.FolderID, claims.Id)
+	if errFolder != nil {
+		return web.FolderResponse{}, ErrFolderNotRegistered
+	}
+
+	if request.Name != "" {
+		folder.Name = request.Name
+	}
+
+	if request.ParentID != nil {
+		if *request.ParentID == 0 {
+			folder.ParentID = nil
+		} else {
+			if _, errFolder := service.findFolder(ctx, tx, *request.ParentID, claims.Id); errFolder != nil {
+				return web.FolderResponse{}, ErrFolderParentIDNotFound
+			}
+
+			// It's making sure the folders stay a tree.
+			folders, errRepo := service.FolderRepository.FetchAllByUserID(ctx, tx, claims.Id)
+			service.Logger.PanicIfErr(errRepo, ErrCustomLinkService)
+
+			if *request.ParentID == folder.ID {
+				return web.FolderResponse{}, ErrFolderParentInvalid
+			}
+			for _, descendantID := range helper.FolderDescendantIDs(folders, folder.ID) {
+				if descendantID == *request.ParentID {
+					return web.FolderResponse{}, ErrFolderParentInvalid
+				}
+			}
+			folder.ParentID = request.ParentID
+		}
+	}
+
+	folder, errRepo := service.FolderRepository.Update(ctx, tx, folder)
+	service.Logger.PanicIfErr(errRepo, ErrCustomLinkService)
+
+	return helper.FolderDomainToResponse(&folder), nil
+}
+
+func (service *CustomLinkServiceImpl) DeleteFolder(ctx context.Context, request web.FolderDeleteRequest, jwtToken string) error {
+	// It's getting the claims from the token.
+	claims := service.Jwt.GetClaims(jwtToken)
+
+	// It's a transaction.
+	tx := service.DB.Begin()
+	defer helper.CommitOrRollback(tx)
+
+	folder, errFolder := service.findFolder(ctx, tx, request.FolderID, claims.Id)
+	if errFolder != nil {
+		return ErrFolderNotRegistered
+	}
+
+	errRepo := service.FolderRepository.Delete(ctx, tx, folder)
+	service.Logger.PanicIfErr(errRepo, ErrCustomLinkService)
+	return nil
+}
+
+func (service *CustomLinkServiceImpl) CreateDomain(ctx context.Context, request web.CustomDomainCreateRequest, jwtToken string) (web.CustomDomainResponse, error) {
+	// It's getting the claims from the token.
+	claims := service.Jwt.GetClaims(jwtToken)
+
+	// It's a transaction.
+	tx := service.DB.Begin()
+	defer helper.CommitOrRollback(tx)
+
+	name := helper.NormalizeDomainName(request.Name)
+	_, errRepo := service.CustomDomainRepository.FindByNameAndUserID(ctx, tx, name, claims.Id)
+	if errRepo != nil && !errors.Is(errRepo, gorm.ErrRecordNotFound) {
+		service.Logger.PanicIfErr(errRepo, ErrCustomLinkService)
+	}
+
+	if errRepo == nil || service.isDomainVerified(ctx, tx, name) {
+		return web.CustomDomainResponse{}, ErrCustomDomainRegistered
+	}
+
+	token, errToken := domainverify.NewToken()
+	service.Logger.PanicIfErr(errToken, ErrCustomLinkService)
+
+	customDomain, errRepo := service.CustomDomainRepository.Create(ctx, tx, domain.CustomDomain{
+		UserID:            claims.Id,
+		Name:              name,
+		VerificationToken: token,
+	})
+	service.Logger.PanicIfErr(errRepo, ErrCustomLinkService)
+
+	return helper.CustomDomainDomainToResponse(&customDomain), nil
+}
+
+func (service *CustomLinkServiceImpl) GetAllDomain(ctx context.Context, jwtToken string) ([]web.CustomDomainResponse, error) {
+	// It's getting the claims from the token.
+	claims := service.Jwt.GetClaims(jwtToken)
+
+	// It's a transaction.
+	tx := service.DB.Begin()
+	defer helper.CommitOrRollback(tx)
+
+	customDomains, errRepo := service.CustomDomainRepository.FetchAllByUserID(ctx, tx, claims.Id)
+	service.Logger.PanicIfErr(errRepo, ErrCustomLinkService)
+
+	customDomainsResponse := []web.CustomDomainResponse{}
+	for _, customDomain := range customDomains {
+		customDomainsResponse = append(customDomainsResponse, helper.CustomDomainDomainToResponse(&customDomain))
+	}
+	return customDomainsResponse, nil
+}
+
+// VerifyDomain looks up the TXT record of the domain, a verified domain starts serving the links created on it.
+func (service *CustomLinkServiceImpl) VerifyDomain(ctx context.Context, request web.CustomDomainVerifyRequest, jwtToken string) (web.CustomDomainResponse, error) {
+	// It's getting the claims from the token.
+	claims := service.Jwt.GetClaims(jwtToken)
+
+	// It's a transaction.
+	tx := service.DB.Begin()
+	defer helper.CommitOrRollback(tx)
+
+	customDomain, errRepo := service.CustomDomainRepository.FindByIdAndUserID(ctx, tx, request.DomainID, claims.Id)
+	if errRepo != nil && !errors.Is(errRepo, gorm.ErrRecordNotFound) {
+		service.Logger.PanicIfErr(errRepo, ErrCustomLinkService)
+	}
+
+	if errors.Is(errRepo, gorm.ErrRecordNotFound) {
+		return web.CustomDomainResponse{}, ErrCustomDomainNotRegistered
+	}
+
+	if customDomain.VerifiedAt != nil {
+		return helper.CustomDomainDomainToResponse(&customDomain), nil
+	}
+
+	if service.isDomainVerified(ctx, tx, customDomain.Name) {
+		return web.CustomDomainResponse{}, ErrCustomDomainRegistered
+	}
+
+	if errVerify := service.DomainVerifier.Verify(ctx, customDomain.Name, customDomain.VerificationToken); errVerify != nil {
+		return web.CustomDomainResponse{}, errVerify
+	}
+
+	verifiedAt := time.Now()
+	customDomain.VerifiedAt = &verifiedAt
+	customDomain, errRepo = service.CustomDomainRepository.UpdateVerifiedAt(ctx, tx, customDomain)
+	service.Logger.PanicIfErr(errRepo, ErrCustomLinkService)
+
+	return helper.CustomDomainDomainToResponse(&customDomain), nil
+}
+
+// DeleteDomain deletes a domain without links, the links in the trash count too because they keep their short link codes.
+func (service *CustomLinkServiceImpl) DeleteDomain(ctx context.Context, request web.CustomDomainDeleteRequest, jwtToken string) error {
+	// It's getting the claims from the token.
+	claims := service.Jwt.GetClaims(jwtToken)
+
+	// It's a transaction.
+	tx := service.DB.Begin()
+	defer helper.CommitOrRollback(tx)
+
+	customDomain, errRepo := service.CustomDomainRepository.FindByIdAndUserID(ctx, tx, request.DomainID, claims.Id)
+	if errRepo != nil && !errors.Is(errRepo, gorm.ErrRecordNotFound) {
+		service.Logger.PanicIfErr(errRepo, ErrCustomLinkService)
+	}
+
+	if errors.Is(errRepo, gorm.ErrRecordNotFound) {
+		return ErrCustomDomainNotRegistered
+	}
+
+	linkCount, errRepo := service.CustomDomainRepository.CountLinksByID(ctx, tx, customDomain.ID)
+	service.Logger.PanicIfErr(errRepo, ErrCustomLinkService)
+
+	if linkCount > 0 {
+		return ErrCustomDomainInUse
+	}
+
+	errRepo = service.CustomDomainRepository.Delete(ctx, tx, customDomain)
+	service.Logger.PanicIfErr(errRepo, ErrCustomLinkService)
+	return nil
+}
+
+func (service *CustomLinkServiceImpl) CreateUtmTemplate(ctx context.Context, request web.UtmTemplateCreateRequest, jwtToken string) (web.UtmTemplateResponse, error) {
+	// It's getting the claims from the token.
+	claims := service.Jwt.GetClaims(jwtToken)
+
+	// It's a transaction.
+	tx := service.DB.Begin()
+	defer helper.CommitOrRollback(tx)
+
+	// It's making sure the user has only one default template.
+	if request.IsDefault {
+		errRepo := service.UtmTemplateRepository.UnsetDefaultByUserID(ctx, tx, claims.Id)
+		service.Logger.PanicIfErr(errRepo, ErrCustomLinkService)
+	}
+
+	utmTemplate := domain.UtmTemplate{
+		UserID:    claims.Id,
+		Name:      request.Name,
+		IsDefault: request.IsDefault,
+		Utm: domain.Utm{
+			Source:   request.UtmSource,
+			Medium:   request.UtmMedium,
+			Campaign: request.UtmCampaign,
+			Term:     request.UtmTerm,
+			Content:  request.UtmContent,
+		},
+	}
+
+	utmTemplate, errRepo := service.UtmTemplateRepository.Create(ctx, tx, utmTemplate)
+	service.Logger.PanicIfErr(errRepo, ErrCustomLinkService)
+
+	return helper.UtmTemplateDomainToResponse(&utmTemplate), nil
+}
+
+func (service *CustomLinkServiceImpl) GetAllUtmTemplate(ctx context.Context, jwtToken string) ([]web.UtmTemplateResponse, error) {
+	// It's getting the claims from the token.
+	claims := service.Jwt.GetClaims(jwtToken)
+
+	// It's a transaction.
+	tx := service.DB.Begin()
+	defer helper.CommitOrRollback(tx)
+
+	utmTemplates, errRepo := service.UtmTemplateRepository.FetchAllByUserID(ctx, tx, claims.Id)
+	service.Logger.PanicIfErr(errRepo, ErrCustomLinkService)
+
+	var utmTemplatesResponse []web.UtmTemplateResponse
+	for _, utmTemplate := range utmTemplates {
+		utmTemplatesResponse = append(utmTemplatesResponse, helper.UtmTemplateDomainToResponse(&utmTemplate))
+	}
+	return utmTemplatesResponse, nil
+}
+
+func (service *CustomLinkServiceImpl) UpdateUtmTemplate(ctx context.Context, request web.UtmTemplateUpdateRequest, jwtToken string) (web.UtmTemplateResponse, error) {
+	// It's getting the claims from the token.
+	claims := service.Jwt.GetClaims(jwtToken)
+
+	// It's a transaction.
+	tx := service.DB.Begin()
+	defer helper.CommitOrRollback(tx)
+
+	utmTemplate, errRepo := service.UtmTemplateRepository.FindByIdAndUserID(ctx, tx, request.TemplateID, claims.Id)
+	if errRepo != nil && !errors.Is(errRepo, gorm.ErrRecordNotFound) {
+		service.Logger.PanicIfErr(errRepo, ErrCustomLinkService)
+	}
+
+	if errors.Is(errRepo, gorm.ErrRecordNotFound) {
+		return web.UtmTemplateResponse{}, ErrUtmTemplateNotRegistered
+	}
+
+	if request.Name != "" {
+		utmTemplate.Name = request.Name
+	}
 
-	thumbnailResponse := helper.CustomThumbnailDomainToResponse(&thumbnail, domainName)
+	if request.IsDefault != nil {
+		if *request.IsDefault && !utmTemplate.IsDefault {
+			errRepo := service.UtmTemplateRepository.UnsetDefaultByUserID(ctx, tx, claims.Id)
+			service.Logger.PanicIfErr(errRepo, ErrCustomLinkService)
+		}
+		utmTemplate.IsDefault = *request.IsDefault
+	}
 
-	return thumbnailResponse, nil
+	if request.UtmSource != nil {
+		utmTemplate.Utm.Source = *request.UtmSource
+	}
+
+	if request.UtmMedium != nil {
+		utmTemplate.Utm.Medium = *request.UtmMedium
+	}
+
+	if request.UtmCampaign != nil {
+		utmTemplate.Utm.Campaign = *request.UtmCampaign
+	}
+
+	if request.UtmTerm != nil {
+		utmTemplate.Utm.Term = *request.UtmTerm
+	}
+
+	if request.UtmContent != nil {
+		utmTemplate.Utm.Content = *request.UtmContent
+	}
+
+	utmTemplate, errRepo = service.UtmTemplateRepository.Update(ctx, tx, utmTemplate)
+	service.Logger.PanicIfErr(errRepo, ErrCustomLinkService)
+
+	return helper.UtmTemplateDomainToResponse(&utmTemplate), nil
 }
 
-func (service *CustomLinkServiceImpl) CheckShortLinkAvaibility(ctx context.Context, request web.CustomLinkCheckShortCodeAvaibilityRequest) error {
+func (service *CustomLinkServiceImpl) DeleteUtmTemplate(ctx context.Context, request web.UtmTemplateDeleteRequest, jwtToken string) error {
+	// It's getting the claims from the token.
+	claims := service.Jwt.GetClaims(jwtToken)
+
 	// It's a transaction.
 	tx := service.DB.Begin()
 	defer helper.CommitOrRollback(tx)
 
-	customLink, errRepo := service.CustomLinkRepository.FindByShortLinkCode(ctx, tx, request.Code)
+	utmTemplate, errRepo := service.UtmTemplateRepository.FindByIdAndUserID(ctx, tx, request.TemplateID, claims.Id)
 	if errRepo != nil && !errors.Is(errRepo, gorm.ErrRecordNotFound) {
 		service.Logger.PanicIfErr(errRepo, ErrCustomLinkService)
 	}
 
-	if customLink.ID != 0 {
-		return ErrShortLinkCodeRegistered
+	if errors.Is(errRepo, gorm.ErrRecordNotFound) {
+		return ErrUtmTemplateNotRegistered
 	}
+
+	errRepo = service.UtmTemplateRepository.Delete(ctx, tx, utmTemplate)
+	service.Logger.PanicIfErr(errRepo, ErrCustomLinkService)
 	return nil
 }
 
-func (service *CustomLinkServiceImpl) RedirectLink(ctx context.Context, request web.CustomLinkRedirectRequest) (string, uint, error) {
+func (service *CustomLinkServiceImpl) CheckShortLinkAvaibility(ctx context.Context, request web.CustomLinkCheckShortCodeAvaibilityRequest, jwtToken string) (web.CustomLinkShortCodeAvaibilityResponse, error) {
+	// It's getting the claims from the token.
+	claims := service.Jwt.GetClaims(jwtToken)
+
+	// It's a transaction.
+	tx := service.DB.Begin()
+	defer helper.CommitOrRollback(tx)
+
+	var domainID *uint
+	if request.DomainID != 0 {
+		domainID = &request.DomainID
+	}
+
+	caseInsensitive := service.isCaseInsensitive(ctx, tx, claims.Id)
+	avaibilityResponse := web.CustomLinkShortCodeAvaibilityResponse{Code: request.Code}
+
+	var errAvaibility error
+	if service.isShortLinkCodeReserved(ctx, tx, request.Code) {
+		errAvaibility = ErrShortLinkCodeReserved
+	} else if service.isShortLinkCodeRegistered(ctx, tx, domainID, request.Code, caseInsensitive, 0) {
+		errAvaibility = ErrShortLinkCodeRegistered
+	}
+
+	if errAvaibility != nil {
+		avaibilityResponse.Suggestions = service.suggestShortLinkCodes(ctx, tx, domainID, request.Code, request.Title, caseInsensitive)
+		return avaibilityResponse, errAvaibility
+	}
+
+	avaibilityResponse.Available = true
+	return avaibilityResponse, nil
+}
+
+func (service *CustomLinkServiceImpl) RedirectLink(ctx context.Context, request web.CustomLinkRedirectRequest) (web.CustomLinkRedirectResponse, error) {
+	// It's a transaction.
+	tx := service.DB.Begin()
+	defer helper.CommitOrRollback(tx)
+
+	customLink, errLive := service.findLiveLink(ctx, tx, request.Host, request.ShortLinkCode)
+	if errLive != nil {
+		return web.CustomLinkRedirectResponse{}, errLive
+	}
+
+	redirectResponse := web.CustomLinkRedirectResponse{
+		StatusCode:   helper.RedirectStatusCode(customLink.RedirectStatus),
+		LinkID:       customLink.ID,
+		Title:        customLink.Title,
+		CreatedAt:    customLink.CreatedAt,
+		Interstitial: customLink.Interstitial,
+	}
+
+	if request.Preview {
+		redirectResponse.ThumbnailUrl = service.findThumbnailUrl(ctx, tx, customLink.CustomThumbnailID, customLink.ThumbnailID, customLink.UserID, request.BaseUrl)
+	}
+
+	// It's returning the data of the link without the destination when the link is locked, a preview still shows it.
+	if customLink.Password != "" {
+		if request.Password == "" {
+			return redirectResponse, ErrCustomLinkLocked
+		}
+		if !helper.CheckPasswordHash(request.Password, customLink.Password) {
+			return redirectResponse, ErrCustomLinkUnlockFailed
+		}
+	}
+	// It's counting the click before sending the visitor on, the last clicks of a limited link go to the first
+	// visitors that claim them. Crawlers are only let through without a claim when the link has no click limit,
+	// the user agent is up to the client.
+	if !request.Preview && (!request.Bot || customLink.MaxClicks != nil) {
+		claimed, errRepo := service.CustomLinkRepository.ClaimClick(ctx, tx, customLink.ID)
+		service.Logger.PanicIfErr(errRepo, ErrCustomLinkService)
+		if !claimed {
+			return web.CustomLinkRedirectResponse{}, ErrCustomLinkExpired
+		}
+	}
+	redirectResponse.Destination = customLink.LongLink
+
+	// It's picking the target of the first matching rule, the split variants or the long link are the fallback.
+	targetingRules, errRepo := service.CustomLinkTargetingRuleRepository.FetchAllByLinkID(ctx, tx, customLink.ID)
+	service.Logger.PanicIfErr(errRepo, ErrCustomLinkService)
+
+	isTargeted := false
+	if len(targetingRules) > 0 {
+		visitor := helper.NewTargetingVisitor(request.UserAgent, request.AcceptLanguage, service.GeoIP.Country(request.ClientIP))
+		for _, targetingRule := range targetingRules {
+			if helper.MatchTargetingRule(&targetingRule, &visitor) {
+				redirectResponse.Destination = targetingRule.TargetLink
+				isTargeted = true
+				break
+			}
+		}
+	}
+
+	if !isTargeted {
+		variants, errRepo := service.CustomLinkVariantRepository.FetchAllByLinkID(ctx, tx, customLink.ID)
+		service.Logger.PanicIfErr(errRepo, ErrCustomLinkService)
+
+		if len(variants) > 0 {
+			variant := pickStickyVariant(variants, request)
+			redirectResponse.Destination = variant.TargetLink
+			redirectResponse.VariantID = &variant.ID
+		}
+	}
+
+	// It's assembling the destination, parameters of the link win over the default utm template of the owner.
+	defaultUtmTemplate, errRepo := service.UtmTemplateRepository.FindDefaultByUserID(ctx, tx, customLink.UserID)
+	if errRepo != nil && !errors.Is(errRepo, gorm.ErrRecordNotFound) {
+		service.Logger.PanicIfErr(errRepo, ErrCustomLinkService)
+	}
+
+	utmDestination, errBuild := helper.BuildUtmLink(redirectResponse.Destination, customLink.Utm, defaultUtmTemplate.Utm)
+	if errBuild == nil {
+		redirectResponse.Destination = utmDestination
+	}
+
+	if customLink.ForwardQuery {
+		forwardDestination, errForward := helper.ForwardQuery(redirectResponse.Destination, request.Query)
+		if errForward == nil {
+			redirectResponse.Destination = forwardDestination
+		}
+	}
+
+	return redirectResponse, nil
+}
+
+// GetLinkPreview returns the open graph data of a live link, the password of the link isn't required
+// because the destination is never part of the preview.
+func (service *CustomLinkServiceImpl) GetLinkPreview(ctx context.Context, request web.CustomLinkRedirectRequest, domainName string) (web.CustomLinkPreviewResponse, error) {
 	// It's a transaction.
 	tx := service.DB.Begin()
 	defer helper.CommitOrRollback(tx)
 
-	customLink, errRepo := service.CustomLinkRepository.FindByShortLinkCode(ctx, tx, request.ShortLinkCode)
+	customLink, errLive := service.findLiveLink(ctx, tx, request.Host, request.ShortLinkCode)
+	if errLive != nil {
+		return web.CustomLinkPreviewResponse{}, errLive
+	}
+
+	// A link with a click limit always has a preview, crawlers are served the preview instead of taking its clicks.
+	isPreviewSet := customLink.OgTitle != "" || customLink.OgDescription != "" || customLink.OgCustomThumbnailID != nil
+	if !isPreviewSet && customLink.MaxClicks == nil {
+		return web.CustomLinkPreviewResponse{}, ErrCustomLinkPreviewNotSet
+	}
+
+	previewResponse := web.CustomLinkPreviewResponse{
+		Title:       customLink.OgTitle,
+		Description: customLink.OgDescription,
+		Url:         helper.GetCustomLinkUrl(helper.GetLinkDomain(&customLink, domainName), customLink.ShortLinkCode),
+	}
+
+	if previewResponse.Title == "" {
+		previewResponse.Title = customLink.Title
+	}
+
+	// It's picking the open graph image, the thumbnail of the link is the fallback.
+	customThumbnailID := customLink.OgCustomThumbnailID
+	if customThumbnailID == nil {
+		customThumbnailID = customLink.CustomThumbnailID
+	}
+	previewResponse.ImageUrl = service.findThumbnailUrl(ctx, tx, customThumbnailID, customLink.ThumbnailID, customLink.UserID, domainName)
+
+	return previewResponse, nil
+}
+
+// findThumbnailUrl returns the url of the uploaded thumbnail or else of the default thumbnail, empty when the link has none.
+func (service *CustomLinkServiceImpl) findThumbnailUrl(ctx context.Context, tx *gorm.DB, customThumbnailID *uint, thumbnailID *uint, userID string, domainName string) string {
+	if customThumbnailID != nil {
+		customThumbnail, errRepo := service.CustomThumbnailRepository.FindByThumbnailIDAndUserID(ctx, tx, int(*customThumbnailID), userID)
+		if errRepo != nil && !errors.Is(errRepo, gorm.ErrRecordNotFound) {
+			service.Logger.PanicIfErr(errRepo, ErrCustomLinkService)
+		}
+		if errRepo == nil {
+			return helper.GetCustomThumbnailUrl(domainName, customThumbnail.ImageID)
+		}
+	} else if thumbnailID != nil {
+		thumbnail, errRepo := service.ThumbnailRepository.FindByID(ctx, tx, int(*thumbnailID))
+		if errRepo != nil && !errors.Is(errRepo, gorm.ErrRecordNotFound) {
+			service.Logger.PanicIfErr(errRepo, ErrCustomLinkService)
+		}
+		return thumbnail.IconUrl
+	}
+	return ""
+}
+
+// findLiveLink returns the link of the short link code when it can be visited right now, the host picks the domain.
+// Hosts that aren't a verified domain serve the links of the shared domain.
+func (service *CustomLinkServiceImpl) findLiveLink(ctx context.Context, tx *gorm.DB, host string, shortLinkCode string) (domain.CustomLink, error) {
+	var domainID *uint
+	customDomain, errRepo := service.CustomDomainRepository.FindVerifiedByName(ctx, tx, helper.NormalizeDomainName(host))
+	if errRepo != nil && !errors.Is(errRepo, gorm.ErrRecordNotFound) {
+		service.Logger.PanicIfErr(errRepo, ErrCustomLinkService)
+	}
+
+	if errRepo == nil {
+		domainID = &customDomain.ID
+	}
+
+	customLink, errRepo := service.CustomLinkRepository.FindByShortLinkCode(ctx, tx, domainID, shortLinkCode)
 	if errRepo != nil && !errors.Is(errRepo, gorm.ErrRecordNotFound) {
 		service.Logger.PanicIfErr(errRepo, ErrCustomLinkService)
 	}
 
 	if errors.Is(errRepo, gorm.ErrRecordNotFound) {
-		return "", 0, ErrCustomLinkInvalid
+		return customLink, ErrCustomLinkInvalid
 	}
 
 	if !customLink.Activate {
-		return "", 0, ErrCustomLinkInvalid
+		return customLink, ErrCustomLinkInvalid
+	}
+
+	if isExpired(&customLink) {
+		return customLink, ErrCustomLinkExpired
+	}
+
+	if !helper.IsWithinWindow(time.Now(), customLink.ActiveFrom, customLink.ActiveUntil) {
+		return customLink, ErrCustomLinkNotLive
 	}
+	return customLink, nil
+}
 
-	return customLink.LongLink, customLink.ID, nil
+// checkOgThumbnail makes sure the open graph image is one of the thumbnails uploaded by the user.
+func (service *CustomLinkServiceImpl) checkOgThumbnail(ctx context.Context, tx *gorm.DB, userThumbnailID uint, userID string) error {
+	_, errRepo := service.CustomThumbnailRepository.FindByThumbnailIDAndUserID(ctx, tx, int(userThumbnailID), userID)
+	if errRepo != nil {
+		if errors.Is(errRepo, gorm.ErrRecordNotFound) {
+			return ErrOgUserThumbnailIDNotFound
+		}
+		service.Logger.PanicIfErr(errRepo, ErrCustomLinkService)
+	}
+	return nil
 }
 
 func (service *CustomLinkServiceImpl) GetAllLinkProfile(ctx context.Context, domainName string, userID string, username string) []web.UserProfileCustomLinkResponse {
@@ -443,12 +2140,16 @@ func (service *CustomLinkServiceImpl) GetAllLinkProfile(ctx context.Context, dom
 
 	var userProfileCustomLinksResponse []web.UserProfileCustomLinkResponse
 	for _, customLink := range customLinks {
-		if !customLink.ShowOnProfile {
+		if !customLink.ShowOnProfile || isExpired(&customLink) {
+			continue
+		}
+
+		if !helper.IsWithinWindow(time.Now(), customLink.ActiveFrom, customLink.ActiveUntil) {
 			continue
 		}
 		userProfileCustomLinkResponse := web.UserProfileCustomLinkResponse{
 			Title: customLink.Title,
-			Link:  helper.GetCustomLinkUrl(domainName, customLink.ShortLinkCode),
+			Link:  helper.GetCustomLinkUrl(helper.GetLinkDomain(&customLink, domainName), customLink.ShortLinkCode),
 		}
 
 		if customLink.ThumbnailID != nil {
@@ -463,3 +2164,337 @@ func (service *CustomLinkServiceImpl) GetAllLinkProfile(ctx context.Context, dom
 	}
 	return userProfileCustomLinksResponse
 }
+
+// isShortLinkCodeRegistered checks the short link code against every link including the deleted ones, it's read only.
+// A deleted link holds its code until its retention period has passed, even when the release worker hasn't released
+// the code yet. Codes differing only in case are taken when either link is case insensitive, linkID is the link
+// the code is checked for, 0 for a new link.
+func (service *CustomLinkServiceImpl) isShortLinkCodeRegistered(ctx context.Context, tx *gorm.DB, domainID *uint, shortLinkCode string, caseInsensitive bool, linkID uint) bool {
+	holders, _ := service.fetchShortLinkCodeHolders(ctx, tx, domainID, shortLinkCode, caseInsensitive, linkID)
+	return len(holders) > 0
+}
+
+// isShortLinkCodeTaken is isShortLinkCodeRegistered for the paths about to write the code, the code stays locked
+// until the transaction ends so a concurrent write of any case of the code waits for this one. A free code still
+// held by a deleted link is released right away, the unique index would refuse it otherwise.
+func (service *CustomLinkServiceImpl) isShortLinkCodeTaken(ctx context.Context, tx *gorm.DB, domainID *uint, shortLinkCode string, caseInsensitive bool, linkID uint) bool {
+	errRepo := service.CustomLinkRepository.LockShortLinkCode(ctx, tx, domainID, shortLinkCode)
+	service.Logger.PanicIfErr(errRepo, ErrCustomLinkService)
+
+	holders, expired := service.fetchShortLinkCodeHolders(ctx, tx, domainID, shortLinkCode, caseInsensitive, linkID)
+	if len(holders) > 0 {
+		return true
+	}
+
+	for _, customLink := range expired {
+		_, errRepo = service.CustomLinkRepository.ReleaseShortLinkCode(ctx, tx, customLink)
+		service.Logger.PanicIfErr(errRepo, ErrCustomLinkService)
+	}
+	return false
+}
+
+// fetchShortLinkCodeHolders returns the other links holding the short link code, and apart from them
+// the deleted links whose retention period is over and whose code isn't released yet.
+func (service *CustomLinkServiceImpl) fetchShortLinkCodeHolders(ctx context.Context, tx *gorm.DB, domainID *uint, shortLinkCode string, caseInsensitive bool, linkID uint) ([]domain.CustomLink, []domain.CustomLink) {
+	customLinks, errRepo := service.CustomLinkRepository.FetchAllByShortLinkCodeUnscoped(ctx, tx, domainID, shortLinkCode, caseInsensitive)
+	service.Logger.PanicIfErr(errRepo, ErrCustomLinkService)
+
+	var holders, expired []domain.CustomLink
+	now := time.Now()
+	for _, customLink := range customLinks {
+		if customLink.ID == linkID {
+			continue
+		}
+
+		if customLink.DeletedAt.Valid && helper.IsRetentionOver(customLink.DeletedAt.Time, RetentionDurationDeletedCustomLink, now) {
+			expired = append(expired, customLink)
+			continue
+		}
+		holders = append(holders, customLink)
+	}
+	return holders, expired
+}
+
+// ReleaseShortLinkCodes releases the short link codes of the links whose retention period is over, it's run
+// by the release worker. The links are released all at once, there's no transaction around them.
+func (service *CustomLinkServiceImpl) ReleaseShortLinkCodes(ctx context.Context) (int64, error) {
+	return service.CustomLinkRepository.ReleaseShortLinkCodesDeletedBefore(ctx, service.DB, time.Now().Add(-RetentionDurationDeletedCustomLink))
+}
+
+// isCaseInsensitive returns the case insensitive short code option of the user.
+func (service *CustomLinkServiceImpl) isCaseInsensitive(ctx context.Context, tx *gorm.DB, userID string) bool {
+	user, errRepo := service.UserRepository.FindByID(ctx, tx, userID)
+	service.Logger.PanicIfErr(errRepo, ErrCustomLinkService)
+	return user.CaseInsensitiveShortCode
+}
+
+// suggestShortLinkCodes returns the first available codes of the ranked suggestions for a taken code.
+func (service *CustomLinkServiceImpl) suggestShortLinkCodes(ctx context.Context, tx *gorm.DB, domainID *uint, shortLinkCode string, title string, caseInsensitive bool) []string {
+	suggestions := []string{}
+	for _, suggestion := range helper.ShortCodeSuggestions(shortLinkCode, title) {
+		if len(suggestions) == MaxShortCodeSuggestion {
+			break
+		}
+
+		if service.isShortLinkCodeReserved(ctx, tx, suggestion) || service.isShortLinkCodeRegistered(ctx, tx, domainID, suggestion, caseInsensitive, 0) {
+			continue
+		}
+		suggestions = append(suggestions, suggestion)
+	}
+	return suggestions
+}
+
+// isShortLinkCodeReserved checks the short link code against the reserved words registry.
+func (service *CustomLinkServiceImpl) isShortLinkCodeReserved(ctx context.Context, tx *gorm.DB, shortLinkCode string) bool {
+	reserved, errReserved := isReservedWord(ctx, tx, service.ReservedWordRepository, shortLinkCode)
+	service.Logger.PanicIfErr(errReserved, ErrCustomLinkService)
+	return reserved
+}
+
+// generateShortLinkCode generates a random short link code and retries on collision with a registered
+// or reserved one, random codes can spell an offensive word too.
+func (service *CustomLinkServiceImpl) generateShortLinkCode(ctx context.Context, tx *gorm.DB, domainID *uint, caseInsensitive bool) (string, error) {
+	for i := 0; i < service.ShortCodeGenerator.MaxRetry; i++ {
+		shortLinkCode, err := service.ShortCodeGenerator.Generate()
+		service.Logger.PanicIfErr(err, ErrCustomLinkService)
+
+		if !service.isShortLinkCodeReserved(ctx, tx, shortLinkCode) && !service.isShortLinkCodeTaken(ctx, tx, domainID, shortLinkCode, caseInsensitive, 0) {
+			return shortLinkCode, nil
+		}
+	}
+	return "", ErrShortLinkCodeGenerate
+}
+
+// isExpired checks whether the link has passed its expiry date or has reached its click limit, the click count
+// is a column of the link so listing links doesn't count the interactions of each one.
+func isExpired(customLink *domain.CustomLink) bool {
+	if customLink.ExpiresAt != nil && !time.Now().Before(*customLink.ExpiresAt) {
+		return true
+	}
+	return customLink.MaxClicks != nil && customLink.ClickCount >= *customLink.MaxClicks
+}
+
+// mergeUtm fills the empty parameters of utm with the ones from the template.
+func mergeUtm(utm domain.Utm, template domain.Utm) domain.Utm {
+	if utm.Source == "" {
+		utm.Source = template.Source
+	}
+	if utm.Medium == "" {
+		utm.Medium = template.Medium
+	}
+	if utm.Campaign == "" {
+		utm.Campaign = template.Campaign
+	}
+	if utm.Term == "" {
+		utm.Term = template.Term
+	}
+	if utm.Content == "" {
+		utm.Content = template.Content
+	}
+	return utm
+}
+
+// validateTargetingRules makes sure every rule has a condition, a rule without one would shadow the long link.
+func validateTargetingRules(requests []web.CustomLinkTargetingRuleRequest) error {
+	for _, request := range requests {
+		if request.Device == "" && request.OS == "" && request.Country == "" && request.Language == "" {
+			return ErrTargetingRuleCondition
+		}
+	}
+	return nil
+}
+
+// checkDestinations runs the url safety checks on the long link and the targets of the rules and the variants,
+// an empty long link is skipped. The verified custom domains serve short links too, so they count as our own hosts.
+func (service *CustomLinkServiceImpl) checkDestinations(ctx context.Context, tx *gorm.DB, longLink string, targetingRules []web.CustomLinkTargetingRuleRequest, variants []web.CustomLinkVariantRequest, domainName string) error {
+	customDomains := func(host string) bool {
+		return service.isDomainVerified(ctx, tx, helper.NormalizeDomainName(host))
+	}
+
+	if longLink != "" {
+		if errCheck := service.URLChecker.Check(longLink, domainName, customDomains); errCheck != nil {
+			return fmt.Errorf("long_link invalid, %w", errCheck)
+		}
+	}
+
+	for _, targetingRule := range targetingRules {
+		if errCheck := service.URLChecker.Check(targetingRule.TargetLink, domainName, customDomains); errCheck != nil {
+			return fmt.Errorf("target_link of targeting_rules invalid, %w", errCheck)
+		}
+	}
+
+	for _, variant := range variants {
+		if errCheck := service.URLChecker.Check(variant.TargetLink, domainName, customDomains); errCheck != nil {
+			return fmt.Errorf("target_link of variants invalid, %w", errCheck)
+		}
+	}
+	return nil
+}
+
+// replaceTargetingRules replaces the targeting rules of the link, the order of the requests is the evaluation order.
+func (service *CustomLinkServiceImpl) replaceTargetingRules(ctx context.Context, tx *gorm.DB, linkID uint, requests []web.CustomLinkTargetingRuleRequest) []web.CustomLinkTargetingRuleResponse {
+	var targetingRules []domain.CustomLinkTargetingRule
+	for _, request := range requests {
+		targetingRules = append(targetingRules, domain.CustomLinkTargetingRule{
+			Device:     request.Device,
+			OS:         request.OS,
+			Country:    request.Country,
+			Language:   request.Language,
+			TargetLink: request.TargetLink,
+		})
+	}
+
+	targetingRules, errRepo := service.CustomLinkTargetingRuleRepository.ReplaceByLinkID(ctx, tx, linkID, targetingRules)
+	service.Logger.PanicIfErr(errRepo, ErrCustomLinkService)
+
+	var targetingRulesResponse []web.CustomLinkTargetingRuleResponse
+	for _, targetingRule := range targetingRules {
+		targetingRulesResponse = append(targetingRulesResponse, helper.CustomLinkTargetingRuleDomainToResponse(&targetingRule))
+	}
+	return targetingRulesResponse
+}
+
+func (service *CustomLinkServiceImpl) getTargetingRules(ctx context.Context, tx *gorm.DB, linkID uint) []web.CustomLinkTargetingRuleResponse {
+	targetingRules, errRepo := service.CustomLinkTargetingRuleRepository.FetchAllByLinkID(ctx, tx, linkID)
+	service.Logger.PanicIfErr(errRepo, ErrCustomLinkService)
+
+	var targetingRulesResponse []web.CustomLinkTargetingRuleResponse
+	for _, targetingRule := range targetingRules {
+		targetingRulesResponse = append(targetingRulesResponse, helper.CustomLinkTargetingRuleDomainToResponse(&targetingRule))
+	}
+	return targetingRulesResponse
+}
+
+// pickStickyVariant keeps the variant assigned on a previous visit while it still exists, otherwise it assigns one by weight.
+func pickStickyVariant(variants []domain.CustomLinkVariant, request web.CustomLinkRedirectRequest) domain.CustomLinkVariant {
+	for _, variant := range variants {
+		if variant.ID == request.VariantID {
+			return variant
+		}
+	}
+	return helper.PickVariant(variants, helper.VariantAssignmentKey(request.ClientIP, request.UserAgent, request.ShortLinkCode))
+}
+
+// replaceVariants replaces the split variants of the link, the clicks of the old variants are kept for the analytic.
+func (service *CustomLinkServiceImpl) replaceVariants(ctx context.Context, tx *gorm.DB, linkID uint, requests []web.CustomLinkVariantRequest) []web.CustomLinkVariantResponse {
+	var variants []domain.CustomLinkVariant
+	for _, request := range requests {
+		variants = append(variants, domain.CustomLinkVariant{
+			Name:       request.Name,
+			TargetLink: request.TargetLink,
+			Weight:     request.Weight,
+		})
+	}
+
+	variants, errRepo := service.CustomLinkVariantRepository.ReplaceByLinkID(ctx, tx, linkID, variants)
+	service.Logger.PanicIfErr(errRepo, ErrCustomLinkService)
+
+	var variantsResponse []web.CustomLinkVariantResponse
+	for _, variant := range variants {
+		variantsResponse = append(variantsResponse, helper.CustomLinkVariantDomainToResponse(&variant))
+	}
+	return variantsResponse
+}
+
+func (service *CustomLinkServiceImpl) getVariants(ctx context.Context, tx *gorm.DB, linkID uint) []web.CustomLinkVariantResponse {
+	variants, errRepo := service.CustomLinkVariantRepository.FetchAllByLinkID(ctx, tx, linkID)
+	service.Logger.PanicIfErr(errRepo, ErrCustomLinkService)
+
+	var variantsResponse []web.CustomLinkVariantResponse
+	for _, variant := range variants {
+		variantsResponse = append(variantsResponse, helper.CustomLinkVariantDomainToResponse(&variant))
+	}
+	return variantsResponse
+}
+
+// findFolder returns gorm.ErrRecordNotFound when the folder doesn't belong to the user.
+func (service *CustomLinkServiceImpl) findFolder(ctx context.Context, tx *gorm.DB, folderID uint, userID string) (domain.Folder, error) {
+	folder, errRepo := service.FolderRepository.FindByIdAndUserID(ctx, tx, folderID, userID)
+	if errRepo != nil && !errors.Is(errRepo, gorm.ErrRecordNotFound) {
+		service.Logger.PanicIfErr(errRepo, ErrCustomLinkService)
+	}
+	return folder, errRepo
+}
+
+// findVerifiedDomain returns the domain of the user when it's verified, links can only be created on verified domains.
+func (service *CustomLinkServiceImpl) findVerifiedDomain(ctx context.Context, tx *gorm.DB, domainID uint, userID string) (domain.CustomDomain, error) {
+	customDomain, errRepo := service.CustomDomainRepository.FindByIdAndUserID(ctx, tx, domainID, userID)
+	if errRepo != nil && !errors.Is(errRepo, gorm.ErrRecordNotFound) {
+		service.Logger.PanicIfErr(errRepo, ErrCustomLinkService)
+	}
+
+	if errRepo != nil || customDomain.VerifiedAt == nil {
+		return customDomain, ErrCustomDomainIDNotFound
+	}
+	return customDomain, nil
+}
+
+func (service *CustomLinkServiceImpl) isDomainVerified(ctx context.Context, tx *gorm.DB, name string) bool {
+	_, errRepo := service.CustomDomainRepository.FindVerifiedByName(ctx, tx, name)
+	if errRepo != nil && !errors.Is(errRepo, gorm.ErrRecordNotFound) {
+		service.Logger.PanicIfErr(errRepo, ErrCustomLinkService)
+	}
+	return errRepo == nil
+}
+
+func isSameDomainID(a *uint, b *uint) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// findTags resolves the tag ids of a request, every id must belong to a tag of the user.
+func (service *CustomLinkServiceImpl) findTags(ctx context.Context, tx *gorm.DB, tagIDs []uint, userID string) ([]domain.Tag, error) {
+	uniqueTagIDs := make(map[uint]bool)
+	for _, tagID := range tagIDs {
+		uniqueTagIDs[tagID] = true
+	}
+
+	if len(uniqueTagIDs) == 0 {
+		return []domain.Tag{}, nil
+	}
+
+	tags, errRepo := service.TagRepository.FindByIDsAndUserID(ctx, tx, tagIDs, userID)
+	service.Logger.PanicIfErr(errRepo, ErrCustomLinkService)
+
+	if len(tags) != len(uniqueTagIDs) {
+		return nil, ErrTagIDNotFound
+	}
+	return tags, nil
+}
+
+// customLinkCursorValue returns the sort value of a link the way parseCustomLinkCursorValue reads it back.
+func customLinkCursorValue(sort string, customLink *domain.CustomLink) string {
+	switch sort {
+	case repository.CustomLinkSortUpdated:
+		return customLink.UpdatedAt.Format(time.RFC3339Nano)
+	case repository.CustomLinkSortTitle:
+		return customLink.Title
+	case repository.CustomLinkSortPosition:
+		return strconv.Itoa(customLink.Position)
+	case repository.CustomLinkSortClicks:
+		return strconv.FormatUint(uint64(customLink.ClickCount), 10)
+	default:
+		return customLink.CreatedAt.Format(time.RFC3339Nano)
+	}
+}
+
+func parseCustomLinkCursorValue(sort string, value string) (interface{}, error) {
+	switch sort {
+	case repository.CustomLinkSortTitle:
+		return value, nil
+	case repository.CustomLinkSortClicks, repository.CustomLinkSortPosition:
+		number, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, helper.ErrPageCursorInvalid
+		}
+		return number, nil
+	default:
+		date, err := time.Parse(time.RFC3339Nano, value)
+		if err != nil {
+			return nil, helper.ErrPageCursorInvalid
+		}
+		return date, nil
+	}
+}
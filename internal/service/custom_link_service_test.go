@@ -0,0 +1,617 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/ilhamfzri/pendek.in/config"
+	"github.com/ilhamfzri/pendek.in/helper"
+	"github.com/ilhamfzri/pendek.in/helper/urlsafety"
+	"github.com/ilhamfzri/pendek.in/internal/model/domain"
+	"github.com/ilhamfzri/pendek.in/internal/model/web"
+	"github.com/ilhamfzri/pendek.in/internal/repository"
+	"github.com/ilhamfzri/pendek.in/internal/repository/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func newCustomLink(id uint, createdAt time.Time, updatedAt time.Time, title string, position int) domain.CustomLink {
+	link := domain.CustomLink{Title: title, Position: position, ShortLinkCode: fmt.Sprintf("code%d", id)}
+	link.ID = id
+	link.CreatedAt = createdAt
+	link.UpdatedAt = updatedAt
+	return link
+}
+
+func withClickCount(link domain.CustomLink, clickCount uint) domain.CustomLink {
+	link.ClickCount = clickCount
+	return link
+}
+
+// Every sort value is shared by several links so the pages have to break ties by id.
+var pageTime = time.Date(2022, 11, 1, 10, 0, 0, 123456000, time.UTC)
+var pageCustomLinks = []domain.CustomLink{
+	withClickCount(newCustomLink(1, pageTime, pageTime.Add(time.Hour), "beta", 2), 5),
+	withClickCount(newCustomLink(2, pageTime, pageTime, "alpha", 1), 5),
+	withClickCount(newCustomLink(3, pageTime.Add(time.Minute), pageTime.Add(time.Hour), "beta", 2), 0),
+	withClickCount(newCustomLink(4, pageTime, pageTime.Add(2*time.Hour), "alpha", 3), 7),
+	withClickCount(newCustomLink(5, pageTime.Add(2*time.Minute), pageTime.Add(time.Hour), "gamma", 1), 5),
+	withClickCount(newCustomLink(6, pageTime.Add(time.Minute), pageTime, "beta", 2), 0),
+	withClickCount(newCustomLink(7, pageTime.Add(2*time.Minute), pageTime.Add(2*time.Hour), "alpha", 3), 7),
+}
+
+// comparePageValue compares two sort values the way postgres does, a cursor value of the wrong type fails the test.
+func comparePageValue(t *testing.T, a interface{}, b interface{}) int {
+	switch a := a.(type) {
+	case time.Time:
+		bTime, ok := b.(time.Time)
+		if !ok {
+			t.Fatalf("cursor value %v isn't a time", b)
+		}
+		switch {
+		case a.Before(bTime):
+			return -1
+		case a.After(bTime):
+			return 1
+		}
+		return 0
+	case string:
+		bString, ok := b.(string)
+		if !ok {
+			t.Fatalf("cursor value %v isn't a string", b)
+		}
+		switch {
+		case a < bString:
+			return -1
+		case a > bString:
+			return 1
+		}
+		return 0
+	default:
+		bInt, ok := b.(int)
+		if !ok {
+			t.Fatalf("cursor value %v isn't an int", b)
+		}
+		return a.(int) - bInt
+	}
+}
+
+func pageSortValue(sort string, link domain.CustomLink) interface{} {
+	switch sort {
+	case repository.CustomLinkSortUpdated:
+		return link.UpdatedAt
+	case repository.CustomLinkSortTitle:
+		return link.Title
+	case repository.CustomLinkSortPosition:
+		return link.Position
+	case repository.CustomLinkSortClicks:
+		return int(link.ClickCount)
+	default:
+		return link.CreatedAt
+	}
+}
+
+// sortedPageCustomLinks returns the ids in the order of FetchPageByUserIDAndFilter, the sort value first and the id after.
+func sortedPageCustomLinks(t *testing.T, page repository.CustomLinkPage) []domain.CustomLink {
+	links := append([]domain.CustomLink{}, pageCustomLinks...)
+	sort.Slice(links, func(i, j int) bool {
+		compare := comparePageValue(t, pageSortValue(page.Sort, links[i]), pageSortValue(page.Sort, links[j]))
+		if compare == 0 {
+			compare = int(links[i].ID) - int(links[j].ID)
+		}
+		if page.Desc {
+			return compare > 0
+		}
+		return compare < 0
+	})
+	return links
+}
+
+// fetchPageCustomLinks is a keyset page over pageCustomLinks with the same predicate as the repository.
+func fetchPageCustomLinks(t *testing.T) func(ctx context.Context, tx *gorm.DB, userID string, filter repository.CustomLinkFilter, page repository.CustomLinkPage) []domain.CustomLink {
+	return func(ctx context.Context, tx *gorm.DB, userID string, filter repository.CustomLinkFilter, page repository.CustomLinkPage) []domain.CustomLink {
+		var links []domain.CustomLink
+		for _, link := range sortedPageCustomLinks(t, page) {
+			if page.CursorValue != nil {
+				compare := comparePageValue(t, pageSortValue(page.Sort, link), page.CursorValue)
+				if page.Desc {
+					compare = -compare
+				}
+
+				after := compare > 0 || (compare == 0 && ((!page.Desc && link.ID > page.CursorID) || (page.Desc && link.ID < page.CursorID)))
+				if !after {
+					continue
+				}
+			}
+
+			links = append(links, link)
+			if len(links) == page.Limit+1 {
+				break
+			}
+		}
+		return links
+	}
+}
+
+func TestCustomLinkServiceGetAllLinkCursor(t *testing.T) {
+	var jwt = new(helper.JwtMock)
+	dummyJwt := "ASDEFGHJKDSANEQWENEWNQENWN"
+	host := "http://pendek.in"
+
+	jwt.Mock.On("GetClaims", dummyJwt).Return(helper.JwtUserClaims{
+		Id:       "123456",
+		Username: "testuser",
+		Email:    "testuser@mail.com",
+	})
+
+	var customLinkRepository = mocks.NewCustomLinkRepository(t)
+
+	// The analytic repository is left out, the clicks of a link are sorted on its click count.
+	var customLinkService = NewCustomLinkService(CustomLinkServiceDependencies{
+		CustomLinkRepository: customLinkRepository,
+	}, db, log, jwt)
+
+	customLinkRepository.Mock.On("FetchPageByUserIDAndFilter", mock.Anything, mock.Anything, "123456", mock.Anything, mock.Anything).Return(fetchPageCustomLinks(t), nil)
+
+	sorts := []string{
+		repository.CustomLinkSortCreated,
+		repository.CustomLinkSortUpdated,
+		repository.CustomLinkSortTitle,
+		repository.CustomLinkSortClicks,
+		repository.CustomLinkSortPosition,
+	}
+
+	for _, sortKey := range sorts {
+		for _, order := range []string{"asc", "desc"} {
+			t.Run(fmt.Sprintf("[Get All Link][Cursor: %s %s]", sortKey, order), func(t *testing.T) {
+				var expectedIDs []uint
+				for _, link := range sortedPageCustomLinks(t, repository.CustomLinkPage{Sort: sortKey, Desc: order == "desc"}) {
+					expectedIDs = append(expectedIDs, link.ID)
+				}
+
+				var ids []uint
+				request := web.CustomLinkGetAllRequest{Sort: sortKey, Order: order, Limit: 2}
+				for pages := 0; pages < len(pageCustomLinks); pages++ {
+					customLinks, pagination, err := customLinkService.GetAllLink(ctx, request, host, dummyJwt)
+					assert.Nil(t, err)
+					assert.LessOrEqual(t, len(customLinks), request.Limit)
+
+					for _, customLink := range customLinks {
+						ids = append(ids, customLink.ID)
+					}
+
+					if !pagination.HasMore {
+						assert.Empty(t, pagination.NextCursor)
+						break
+					}
+					request.Cursor = pagination.NextCursor
+				}
+
+				assert.Equal(t, expectedIDs, ids)
+			})
+		}
+	}
+
+	t.Run("[Get All Link][Failed: Cursor Of Another Sort]", func(t *testing.T) {
+		request := web.CustomLinkGetAllRequest{Sort: repository.CustomLinkSortTitle, Limit: 2}
+		_, pagination, err := customLinkService.GetAllLink(ctx, request, host, dummyJwt)
+		assert.Nil(t, err)
+
+		request.Sort = repository.CustomLinkSortPosition
+		request.Cursor = pagination.NextCursor
+		customLinks, _, err := customLinkService.GetAllLink(ctx, request, host, dummyJwt)
+		assert.Equal(t, helper.ErrPageCursorInvalid, err)
+		assert.Empty(t, customLinks)
+	})
+}
+
+func TestCustomLinkServiceCreateLinkCaseConflict(t *testing.T) {
+	var jwt = new(helper.JwtMock)
+	dummyJwt := "ASDEFGHJKDSANEQWENEWNQENWN"
+	host := "http://pendek.in"
+
+	jwt.Mock.On("GetClaims", dummyJwt).Return(helper.JwtUserClaims{
+		Id:       "123456",
+		Username: "testuser",
+		Email:    "testuser@mail.com",
+	})
+
+	urlChecker, err := urlsafety.NewChecker(config.URLSafetyConfig{})
+	assert.Nil(t, err)
+
+	var customLinkRepository = mocks.NewCustomLinkRepository(t)
+	var reservedWordRepository = mocks.NewReservedWordRepository(t)
+	var userRepository = mocks.NewUserRepository(t)
+
+	var customLinkService = NewCustomLinkService(CustomLinkServiceDependencies{
+		CustomLinkRepository:   customLinkRepository,
+		ReservedWordRepository: reservedWordRepository,
+		UserRepository:         userRepository,
+		URLChecker:             urlChecker,
+	}, db, log, jwt)
+
+	reservedWordRepository.Mock.On("FindMatch", mock.Anything, mock.Anything, mock.Anything, helper.ReservedSubstringCategories).Return(domain.ReservedWord{}, gorm.ErrRecordNotFound)
+
+	caseInsensitiveLink := newCustomLink(1, pageTime, pageTime, "promo", 0)
+	caseInsensitiveLink.ShortLinkCode = "promo1"
+	caseInsensitiveLink.CaseInsensitive = true
+
+	caseSensitiveLink := newCustomLink(2, pageTime, pageTime, "promo", 0)
+	caseSensitiveLink.ShortLinkCode = "Promo2"
+
+	customLinkRepository.Mock.On("LockShortLinkCode", mock.Anything, mock.Anything, (*uint)(nil), mock.Anything).Return(nil)
+	customLinkRepository.Mock.On("FetchAllByShortLinkCodeUnscoped", mock.Anything, mock.Anything, (*uint)(nil), "Promo1", false).Return([]domain.CustomLink{caseInsensitiveLink}, nil)
+	customLinkRepository.Mock.On("FetchAllByShortLinkCodeUnscoped", mock.Anything, mock.Anything, (*uint)(nil), "promo2", true).Return([]domain.CustomLink{caseSensitiveLink}, nil)
+
+	tests := []struct {
+		TestName        string
+		CaseInsensitive bool
+		ShortLinkCode   string
+	}{
+		{TestName: "[Create Link][Failed: Case Sensitive Code Of A Case Insensitive Link]", CaseInsensitive: false, ShortLinkCode: "Promo1"},
+		{TestName: "[Create Link][Failed: Case Insensitive Code Of A Case Sensitive Link]", CaseInsensitive: true, ShortLinkCode: "promo2"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.TestName, func(t *testing.T) {
+			userRepository.Mock.On("FindByID", mock.Anything, mock.Anything, "123456").Return(domain.User{CaseInsensitiveShortCode: test.CaseInsensitive}, nil).Once()
+			calls := len(customLinkRepository.Calls)
+
+			request := web.CustomLinkCreateRequest{
+				Title:         "promo",
+				ShortLinkCode: test.ShortLinkCode,
+				LongLink:      "https://example.com/promo",
+			}
+
+			customLinkResponse, err := customLinkService.CreateLink(ctx, request, host, dummyJwt)
+			assert.Equal(t, ErrShortLinkCodeRegistered, err)
+			assert.Equal(t, web.CustomLinkResponse{}, customLinkResponse)
+
+			// The code is locked before it's checked, a concurrent create waits for this transaction.
+			assert.Equal(t, "LockShortLinkCode", customLinkRepository.Calls[calls].Method)
+			assert.Equal(t, test.ShortLinkCode, customLinkRepository.Calls[calls].Arguments.Get(3))
+			assert.Equal(t, "FetchAllByShortLinkCodeUnscoped", customLinkRepository.Calls[calls+1].Method)
+		})
+	}
+}
+
+func TestCustomLinkServiceShortLinkCodeRetention(t *testing.T) {
+	var jwt = new(helper.JwtMock)
+	dummyJwt := "ASDEFGHJKDSANEQWENEWNQENWN"
+	host := "http://pendek.in"
+
+	jwt.Mock.On("GetClaims", dummyJwt).Return(helper.JwtUserClaims{
+		Id:       "123456",
+		Username: "testuser",
+		Email:    "testuser@mail.com",
+	})
+
+	var customLinkRepository = mocks.NewCustomLinkRepository(t)
+	var reservedWordRepository = mocks.NewReservedWordRepository(t)
+	var userRepository = mocks.NewUserRepository(t)
+
+	var customLinkService = NewCustomLinkService(CustomLinkServiceDependencies{
+		CustomLinkRepository:   customLinkRepository,
+		ReservedWordRepository: reservedWordRepository,
+		UserRepository:         userRepository,
+	}, db, log, jwt)
+
+	reservedWordRepository.Mock.On("FindMatch", mock.Anything, mock.Anything, mock.Anything, helper.ReservedSubstringCategories).Return(domain.ReservedWord{}, gorm.ErrRecordNotFound)
+	userRepository.Mock.On("FindByID", mock.Anything, mock.Anything, "123456").Return(domain.User{}, nil)
+
+	now := time.Now()
+	activeLink := newCustomLink(11, now, now, "promo", 0)
+	activeLink.UserID = "123456"
+	activeLink.ShortLinkCode = "promo1"
+
+	deletedLink := activeLink
+	deletedLink.DeletedAt = gorm.DeletedAt{Time: now.Add(-time.Hour), Valid: true}
+
+	expiredLink := newCustomLink(12, now, now, "promo", 0)
+	expiredLink.ShortLinkCode = "promo2"
+	expiredLink.DeletedAt = gorm.DeletedAt{Time: now.Add(-RetentionDurationDeletedCustomLink - time.Hour), Valid: true}
+
+	otherLink := newCustomLink(13, now, now, "promo", 0)
+	otherLink.ShortLinkCode = "promo3"
+
+	customLinkRepository.Mock.On("LockShortLinkCode", mock.Anything, mock.Anything, (*uint)(nil), mock.Anything).Return(nil)
+	customLinkRepository.Mock.On("FetchAllByShortLinkCodeUnscoped", mock.Anything, mock.Anything, (*uint)(nil), "promo1", false).Return([]domain.CustomLink{deletedLink}, nil)
+	customLinkRepository.Mock.On("FetchAllByShortLinkCodeUnscoped", mock.Anything, mock.Anything, (*uint)(nil), "promo2", false).Return([]domain.CustomLink{expiredLink}, nil)
+	customLinkRepository.Mock.On("FetchAllByShortLinkCodeUnscoped", mock.Anything, mock.Anything, (*uint)(nil), "promo3", false).Return([]domain.CustomLink{otherLink}, nil)
+	customLinkRepository.Mock.On("FetchAllByShortLinkCodeUnscoped", mock.Anything, mock.Anything, (*uint)(nil), mock.Anything, false).Return([]domain.CustomLink{}, nil)
+
+	t.Run("[Delete Link][Success]", func(t *testing.T) {
+		customLinkRepository.Mock.On("FindByIdAndUserID", mock.Anything, mock.Anything, 11, "123456").Return(activeLink, nil).Once()
+		customLinkRepository.Mock.On("Delete", mock.Anything, mock.Anything, activeLink).Return(nil).Once()
+
+		err := customLinkService.DeleteLink(ctx, web.CustomLinkDeleteRequest{LinkID: 11}, dummyJwt)
+		assert.Nil(t, err)
+	})
+
+	t.Run("[Check Avaibility][Deleted Link Within Retention Holds The Code]", func(t *testing.T) {
+		request := web.CustomLinkCheckShortCodeAvaibilityRequest{Code: "promo1"}
+		avaibilityResponse, err := customLinkService.CheckShortLinkAvaibility(ctx, request, dummyJwt)
+		assert.Equal(t, ErrShortLinkCodeRegistered, err)
+		assert.False(t, avaibilityResponse.Available)
+		assert.NotEmpty(t, avaibilityResponse.Suggestions)
+	})
+
+	t.Run("[Check Avaibility][Deleted Link Past Retention Doesn't Hold The Code]", func(t *testing.T) {
+		request := web.CustomLinkCheckShortCodeAvaibilityRequest{Code: "promo2"}
+		avaibilityResponse, err := customLinkService.CheckShortLinkAvaibility(ctx, request, dummyJwt)
+		assert.Nil(t, err)
+		assert.True(t, avaibilityResponse.Available)
+
+		// Checking is read only, the release worker releases the code.
+		customLinkRepository.AssertNotCalled(t, "ReleaseShortLinkCode", mock.Anything, mock.Anything, mock.Anything)
+		customLinkRepository.AssertNotCalled(t, "LockShortLinkCode", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("[Release Short Link Codes]", func(t *testing.T) {
+		customLinkRepository.Mock.On("ReleaseShortLinkCodesDeletedBefore", mock.Anything, mock.Anything, mock.MatchedBy(func(deletedBefore time.Time) bool {
+			cutoff := time.Now().Add(-RetentionDurationDeletedCustomLink)
+			return !deletedBefore.After(cutoff) && cutoff.Sub(deletedBefore) < time.Minute
+		})).Return(int64(2), nil).Once()
+
+		released, err := customLinkService.ReleaseShortLinkCodes(ctx)
+		assert.Nil(t, err)
+		assert.Equal(t, int64(2), released)
+	})
+
+	t.Run("[Restore Link][Failed: Code Reclaimed]", func(t *testing.T) {
+		releasedLink := newCustomLink(14, now, now, "promo", 0)
+		releasedLink.ShortLinkCode = "~14"
+		releasedLink.ReleasedShortLinkCode = "promo3"
+		releasedLink.DeletedAt = gorm.DeletedAt{Time: now.Add(-RetentionDurationDeletedCustomLink - time.Hour), Valid: true}
+		customLinkRepository.Mock.On("FindDeletedByIdAndUserID", mock.Anything, mock.Anything, 14, "123456").Return(releasedLink, nil).Once()
+
+		customLinkResponse, err := customLinkService.RestoreLink(ctx, web.CustomLinkRestoreRequest{LinkID: 14}, host, dummyJwt)
+		assert.Equal(t, ErrShortLinkCodeReclaimed, err)
+		assert.Equal(t, web.CustomLinkResponse{}, customLinkResponse)
+		customLinkRepository.AssertNotCalled(t, "Restore", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("[Restore Link][Success: Code Of A Link Past Retention]", func(t *testing.T) {
+		releasedLink := newCustomLink(15, now, now, "promo", 0)
+		releasedLink.ShortLinkCode = "~15"
+		releasedLink.ReleasedShortLinkCode = "promo2"
+		releasedLink.DeletedAt = gorm.DeletedAt{Time: now.Add(-time.Hour), Valid: true}
+		customLinkRepository.Mock.On("FindDeletedByIdAndUserID", mock.Anything, mock.Anything, 15, "123456").Return(releasedLink, nil).Once()
+
+		// The other link lost its code to the retention period but the worker hasn't released it yet.
+		customLinkRepository.Mock.On("ReleaseShortLinkCode", mock.Anything, mock.Anything, expiredLink).Return(expiredLink, nil).Once()
+		customLinkRepository.Mock.On("Restore", mock.Anything, mock.Anything, mock.Anything).Return(
+			func(ctx context.Context, tx *gorm.DB, link domain.CustomLink) domain.CustomLink {
+				return link
+			}, nil).Once()
+
+		customLinkResponse, err := customLinkService.RestoreLink(ctx, web.CustomLinkRestoreRequest{LinkID: 15}, host, dummyJwt)
+		assert.Nil(t, err)
+		assert.Equal(t, "promo2", customLinkResponse.ShortLinkCode)
+		assert.Equal(t, host+"/l/promo2", customLinkResponse.RedirectLink)
+
+		restored := customLinkRepository.Calls[len(customLinkRepository.Calls)-1].Arguments.Get(2).(domain.CustomLink)
+		assert.Equal(t, "promo2", restored.ShortLinkCode)
+		assert.Empty(t, restored.ReleasedShortLinkCode)
+	})
+}
+
+func TestCustomLinkServiceRedirectLinkClickLimit(t *testing.T) {
+	var customLinkRepository = mocks.NewCustomLinkRepository(t)
+	var customDomainRepository = mocks.NewCustomDomainRepository(t)
+	var utmTemplateRepository = mocks.NewUtmTemplateRepository(t)
+	var targetingRuleRepository = mocks.NewCustomLinkTargetingRuleRepository(t)
+	var variantRepository = mocks.NewCustomLinkVariantRepository(t)
+
+	var customLinkService = NewCustomLinkService(CustomLinkServiceDependencies{
+		CustomLinkRepository:              customLinkRepository,
+		UtmTemplateRepository:             utmTemplateRepository,
+		CustomLinkTargetingRuleRepository: targetingRuleRepository,
+		CustomLinkVariantRepository:       variantRepository,
+		CustomDomainRepository:            customDomainRepository,
+	}, db, log, nil)
+
+	customDomainRepository.Mock.On("FindVerifiedByName", mock.Anything, mock.Anything, "pendek.in").Return(domain.CustomDomain{}, gorm.ErrRecordNotFound)
+
+	maxClicks := uint(2)
+	now := time.Now()
+	limitedLink := newCustomLink(21, now, now, "promo", 0)
+	limitedLink.ShortLinkCode = "promo1"
+	limitedLink.Activate = true
+	limitedLink.MaxClicks = &maxClicks
+
+	request := web.CustomLinkRedirectRequest{ShortLinkCode: "promo1", Host: "pendek.in"}
+
+	t.Run("[Failed:Limit Reached]", func(t *testing.T) {
+		reachedLink := limitedLink
+		reachedLink.ClickCount = 2
+		customLinkRepository.Mock.On("FindByShortLinkCode", mock.Anything, mock.Anything, (*uint)(nil), "promo1").Return(reachedLink, nil).Once()
+
+		_, err := customLinkService.RedirectLink(ctx, request)
+		assert.Equal(t, ErrCustomLinkExpired, err)
+		customLinkRepository.AssertNotCalled(t, "ClaimClick", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("[Failed:Last Click Claimed By Another Visit]", func(t *testing.T) {
+		lastClickLink := limitedLink
+		lastClickLink.ClickCount = 1
+		customLinkRepository.Mock.On("FindByShortLinkCode", mock.Anything, mock.Anything, (*uint)(nil), "promo1").Return(lastClickLink, nil).Once()
+		customLinkRepository.Mock.On("ClaimClick", mock.Anything, mock.Anything, uint(21)).Return(false, nil).Once()
+
+		_, err := customLinkService.RedirectLink(ctx, request)
+		assert.Equal(t, ErrCustomLinkExpired, err)
+	})
+
+	t.Run("[Failed:Bot Claims A Click Of A Limited Link]", func(t *testing.T) {
+		lastClickLink := limitedLink
+		lastClickLink.ClickCount = 1
+		customLinkRepository.Mock.On("FindByShortLinkCode", mock.Anything, mock.Anything, (*uint)(nil), "promo1").Return(lastClickLink, nil).Once()
+		customLinkRepository.Mock.On("ClaimClick", mock.Anything, mock.Anything, uint(21)).Return(false, nil).Once()
+
+		// A client sending the user agent of a crawler can't follow the link past its limit.
+		botRequest := request
+		botRequest.Bot = true
+		_, err := customLinkService.RedirectLink(ctx, botRequest)
+		assert.Equal(t, ErrCustomLinkExpired, err)
+	})
+
+	t.Run("[Success:Bot Doesn't Claim A Click Of An Unlimited Link]", func(t *testing.T) {
+		unlimitedLink := limitedLink
+		unlimitedLink.MaxClicks = nil
+		unlimitedLink.LongLink = "https://example.com/promo"
+		customLinkRepository.Mock.On("FindByShortLinkCode", mock.Anything, mock.Anything, (*uint)(nil), "promo1").Return(unlimitedLink, nil).Once()
+		targetingRuleRepository.Mock.On("FetchAllByLinkID", mock.Anything, mock.Anything, uint(21)).Return([]domain.CustomLinkTargetingRule{}, nil).Once()
+		variantRepository.Mock.On("FetchAllByLinkID", mock.Anything, mock.Anything, uint(21)).Return([]domain.CustomLinkVariant{}, nil).Once()
+		utmTemplateRepository.Mock.On("FindDefaultByUserID", mock.Anything, mock.Anything, mock.Anything).Return(domain.UtmTemplate{}, gorm.ErrRecordNotFound).Once()
+
+		botRequest := request
+		botRequest.Bot = true
+		redirectResponse, err := customLinkService.RedirectLink(ctx, botRequest)
+		assert.Nil(t, err)
+		assert.Equal(t, "https://example.com/promo", redirectResponse.Destination)
+
+		// The only claims are the ones of the visits before.
+		customLinkRepository.AssertNumberOfCalls(t, "ClaimClick", 2)
+	})
+
+	t.Run("[Preview][Limited Link Without Open Graph Data]", func(t *testing.T) {
+		customLinkRepository.Mock.On("FindByShortLinkCode", mock.Anything, mock.Anything, (*uint)(nil), "promo1").Return(limitedLink, nil).Once()
+
+		previewResponse, err := customLinkService.GetLinkPreview(ctx, request, "https://pendek.in")
+		assert.Nil(t, err)
+		assert.Equal(t, "promo", previewResponse.Title)
+		assert.Equal(t, "https://pendek.in/l/promo1", previewResponse.Url)
+	})
+
+	t.Run("[Preview][Failed:Unlimited Link Without Open Graph Data]", func(t *testing.T) {
+		unlimitedLink := limitedLink
+		unlimitedLink.MaxClicks = nil
+		customLinkRepository.Mock.On("FindByShortLinkCode", mock.Anything, mock.Anything, (*uint)(nil), "promo1").Return(unlimitedLink, nil).Once()
+
+		_, err := customLinkService.GetLinkPreview(ctx, request, "https://pendek.in")
+		assert.Equal(t, ErrCustomLinkPreviewNotSet, err)
+	})
+}
+
+func TestCustomLinkServiceImportLink(t *testing.T) {
+	var jwt = new(helper.JwtMock)
+	dummyJwt := "ASDEFGHJKDSANEQWENEWNQENWN"
+	host := "http://pendek.in"
+
+	jwt.Mock.On("GetClaims", dummyJwt).Return(helper.JwtUserClaims{
+		Id:       "123456",
+		Username: "testuser",
+		Email:    "testuser@mail.com",
+	})
+
+	urlChecker, err := urlsafety.NewChecker(config.URLSafetyConfig{})
+	assert.Nil(t, err)
+
+	takenLink := newCustomLink(1, pageTime, pageTime, "taken", 0)
+	takenLink.ShortLinkCode = "taken1"
+
+	validRow := web.CustomLinkCreateRequest{Title: "promo", ShortLinkCode: "promo1", LongLink: "https://example.com/promo"}
+	takenRow := web.CustomLinkCreateRequest{Title: "taken", ShortLinkCode: "taken1", LongLink: "https://example.com/taken"}
+	invalidRow := web.CustomLinkCreateRequest{Title: "invalid", ShortLinkCode: "invalid1"}
+
+	// The savepoints every import has to go through, a failing row rolls back to its own savepoint only.
+	savepoint := "SAVEPOINT import_row"
+	rollbackRow := "ROLLBACK TO SAVEPOINT import_row"
+	releaseRow := "RELEASE SAVEPOINT import_row"
+	rollbackImport := "ROLLBACK TO SAVEPOINT import"
+
+	tests := []struct {
+		TestName   string
+		Request    web.CustomLinkImportRequest
+		Statements []string
+		Statuses   []string
+		Succeeded  int
+		Failed     int
+	}{
+		{
+			TestName:   "[Dry Run]",
+			Request:    web.CustomLinkImportRequest{DryRun: true, Links: []web.CustomLinkCreateRequest{validRow, takenRow}},
+			Statements: []string{savepoint, releaseRow, savepoint, rollbackRow, releaseRow, rollbackImport},
+			Statuses:   []string{"valid", "failed"},
+			Succeeded:  1,
+			Failed:     1,
+		},
+		{
+			TestName:   "[Atomic][One Failing Row]",
+			Request:    web.CustomLinkImportRequest{Atomic: true, Links: []web.CustomLinkCreateRequest{validRow, takenRow}},
+			Statements: []string{savepoint, releaseRow, savepoint, rollbackRow, releaseRow, rollbackImport},
+			Statuses:   []string{"rolled_back", "failed"},
+			Succeeded:  0,
+			Failed:     1,
+		},
+		{
+			TestName:   "[Partial Failure]",
+			Request:    web.CustomLinkImportRequest{Links: []web.CustomLinkCreateRequest{validRow, takenRow, invalidRow}},
+			Statements: []string{savepoint, releaseRow, savepoint, rollbackRow, releaseRow},
+			Statuses:   []string{"created", "failed", "failed"},
+			Succeeded:  1,
+			Failed:     2,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.TestName, func(t *testing.T) {
+			sqlDB, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+			assert.Nil(t, err)
+			gormDB, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{})
+			assert.Nil(t, err)
+
+			sqlMock.ExpectBegin()
+			sqlMock.ExpectExec("SAVEPOINT import").WillReturnResult(sqlmock.NewResult(0, 0))
+			for _, statement := range test.Statements {
+				sqlMock.ExpectExec(statement).WillReturnResult(sqlmock.NewResult(0, 0))
+			}
+			sqlMock.ExpectCommit()
+
+			var customLinkRepository = mocks.NewCustomLinkRepository(t)
+			var customLinkRevisionRepository = mocks.NewCustomLinkRevisionRepository(t)
+			var reservedWordRepository = mocks.NewReservedWordRepository(t)
+			var userRepository = mocks.NewUserRepository(t)
+
+			var customLinkService = NewCustomLinkService(CustomLinkServiceDependencies{
+				CustomLinkRepository:         customLinkRepository,
+				CustomLinkRevisionRepository: customLinkRevisionRepository,
+				ReservedWordRepository:       reservedWordRepository,
+				UserRepository:               userRepository,
+				URLChecker:                   urlChecker,
+			}, gormDB, log, jwt)
+
+			reservedWordRepository.Mock.On("FindMatch", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(domain.ReservedWord{}, gorm.ErrRecordNotFound)
+			userRepository.Mock.On("FindByID", mock.Anything, mock.Anything, "123456").Return(domain.User{}, nil)
+			customLinkRepository.Mock.On("LockShortLinkCode", mock.Anything, mock.Anything, (*uint)(nil), mock.Anything).Return(nil)
+			customLinkRepository.Mock.On("FetchAllByShortLinkCodeUnscoped", mock.Anything, mock.Anything, (*uint)(nil), "promo1", false).Return([]domain.CustomLink{}, nil)
+			customLinkRepository.Mock.On("FetchAllByShortLinkCodeUnscoped", mock.Anything, mock.Anything, (*uint)(nil), "taken1", false).Return([]domain.CustomLink{takenLink}, nil)
+			customLinkRepository.Mock.On("Create", mock.Anything, mock.Anything, mock.Anything).Return(func(ctx context.Context, tx *gorm.DB, customLink domain.CustomLink) domain.CustomLink {
+				customLink.ID = 2
+				return customLink
+			}, nil)
+			customLinkRevisionRepository.Mock.On("Create", mock.Anything, mock.Anything, mock.Anything).Return(domain.CustomLinkRevision{}, nil)
+
+			importResponse, err := customLinkService.ImportLink(ctx, test.Request, host, dummyJwt)
+			assert.Nil(t, err)
+			assert.Nil(t, sqlMock.ExpectationsWereMet())
+
+			assert.Equal(t, len(test.Request.Links), importResponse.Total)
+			assert.Equal(t, test.Succeeded, importResponse.Succeeded)
+			assert.Equal(t, test.Failed, importResponse.Failed)
+			assert.Len(t, importResponse.Results, len(test.Statuses))
+			for i, status := range test.Statuses {
+				assert.Equal(t, i+1, importResponse.Results[i].Row)
+				assert.Equal(t, status, importResponse.Results[i].Status)
+				assert.Equal(t, status == "created" || status == "valid", importResponse.Results[i].Link != nil)
+			}
+			assert.Equal(t, ErrShortLinkCodeRegistered.Error(), importResponse.Results[1].Message)
+		})
+	}
+}
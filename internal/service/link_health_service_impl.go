@@ -0,0 +1,119 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ilhamfzri/pendek.in/app/logger"
+	"github.com/ilhamfzri/pendek.in/app/mail"
+	"github.com/ilhamfzri/pendek.in/config"
+	"github.com/ilhamfzri/pendek.in/helper"
+	"github.com/ilhamfzri/pendek.in/helper/healthcheck"
+	"github.com/ilhamfzri/pendek.in/internal/model/domain"
+	"github.com/ilhamfzri/pendek.in/internal/repository"
+	"gorm.io/gorm"
+)
+
+type LinkHealthServiceImpl struct {
+	CustomLinkRepository repository.CustomLinkRepository
+	UserRepository       repository.UserRepository
+	MailClient           *mail.MailClient
+	DB                   *gorm.DB
+	Logger               *logger.Logger
+	Checker              *healthcheck.Checker
+	Config               config.LinkHealthConfig
+}
+
+func NewLinkHealthService(clr repository.CustomLinkRepository, ur repository.UserRepository, mailClient *mail.MailClient, DB *gorm.DB, logger *logger.Logger, checker *healthcheck.Checker, cfg config.LinkHealthConfig) LinkHealthService {
+	if cfg.Concurrency <= 0 {
+		cfg.Concurrency = 1
+	}
+
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = 100
+	}
+
+	if cfg.FailureThreshold <= 0 {
+		cfg.FailureThreshold = 1
+	}
+
+	return &LinkHealthServiceImpl{
+		CustomLinkRepository: clr,
+		UserRepository:       ur,
+		MailClient:           mailClient,
+		DB:                   DB,
+		Logger:               logger,
+		Checker:              checker,
+		Config:               cfg,
+	}
+}
+
+var (
+	ErrLinkHealthService   = "[Link Health Service] Failed Execute Link Health Service"
+	ErrSendBrokenLinkEmail = "[Link Health Service] Failed Send Broken Link Email"
+)
+
+// CheckAllLinks checks the destination of every activated link, at most Concurrency checks run at the same time.
+// There's no transaction around the run, every link is updated on its own as soon as its check is done.
+func (service *LinkHealthServiceImpl) CheckAllLinks(ctx context.Context) error {
+	semaphore := make(chan struct{}, service.Config.Concurrency)
+
+	return service.CustomLinkRepository.FetchAllActiveInBatches(ctx, service.DB, service.Config.BatchSize, func(links []domain.CustomLink) error {
+		var wg sync.WaitGroup
+		for _, link := range links {
+			if ctx.Err() != nil {
+				break
+			}
+
+			semaphore <- struct{}{}
+			wg.Add(1)
+			go func(link domain.CustomLink) {
+				defer func() {
+					<-semaphore
+					wg.Done()
+				}()
+				service.checkLink(ctx, link)
+			}(link)
+		}
+		wg.Wait()
+		return ctx.Err()
+	})
+}
+
+func (service *LinkHealthServiceImpl) checkLink(ctx context.Context, link domain.CustomLink) {
+	result := service.Checker.Check(ctx, link.LongLink)
+	if ctx.Err() != nil {
+		// The run was stopped, the failure is ours and not the destination's.
+		return
+	}
+
+	health, notify := helper.ApplyLinkHealth(link.Health, result, service.Config.FailureThreshold, time.Now())
+	errRepo := service.CustomLinkRepository.UpdateHealth(ctx, service.DB, link.ID, health)
+	if errRepo != nil {
+		service.Logger.Error().Err(errRepo).Uint("link_id", link.ID).Msg(ErrLinkHealthService)
+		return
+	}
+
+	if !notify {
+		return
+	}
+
+	// The owner is told once per breakage, a failed email isn't retried on the next run.
+	user, errRepo := service.UserRepository.FindByID(ctx, service.DB, link.UserID)
+	if errRepo != nil {
+		service.Logger.Error().Err(errRepo).Uint("link_id", link.ID).Msg(ErrLinkHealthService)
+		return
+	}
+
+	reason := health.Error
+	if reason == "" {
+		reason = fmt.Sprintf("HTTP %d", health.StatusCode)
+	}
+
+	errMail := service.MailClient.SendBrokenLinkEmail(user.Email, link.Title, link.ShortLinkCode, link.LongLink, reason)
+	if errMail != nil {
+		service.Logger.Warn().Err(errMail).Uint("link_id", link.ID).Msg(ErrSendBrokenLinkEmail)
+	}
+}
@@ -0,0 +1,97 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ilhamfzri/pendek.in/app/logger"
+	"github.com/ilhamfzri/pendek.in/helper"
+	"github.com/ilhamfzri/pendek.in/internal/model/domain"
+	"github.com/ilhamfzri/pendek.in/internal/model/web"
+	"github.com/ilhamfzri/pendek.in/internal/repository"
+	"gorm.io/gorm"
+)
+
+type ReservedWordServiceImpl struct {
+	ReservedWordRepository repository.ReservedWordRepository
+	DB                     *gorm.DB
+	Logger                 *logger.Logger
+}
+
+func NewReservedWordService(rwr repository.ReservedWordRepository, DB *gorm.DB, logger *logger.Logger) ReservedWordService {
+	return &ReservedWordServiceImpl{
+		ReservedWordRepository: rwr,
+		DB:                     DB,
+		Logger:                 logger,
+	}
+}
+
+var (
+	ErrReservedWordService       = "[Reserved Word Service] Failed Execute Reserved Word Service"
+	ErrReservedWordRegistered    = errors.New("reserved word is already registered")
+	ErrReservedWordNotRegistered = errors.New("reserved word isn't registered")
+)
+
+func (service *ReservedWordServiceImpl) CreateReservedWord(ctx context.Context, request web.ReservedWordCreateRequest) (web.ReservedWordResponse, error) {
+	// It's a transaction.
+	tx := service.DB.Begin()
+	defer helper.CommitOrRollback(tx)
+
+	word := helper.NormalizeReservedWord(request.Word)
+	_, errRepo := service.ReservedWordRepository.FindByWord(ctx, tx, word)
+	if errRepo == nil {
+		return web.ReservedWordResponse{}, ErrReservedWordRegistered
+	}
+
+	if !errors.Is(errRepo, gorm.ErrRecordNotFound) {
+		service.Logger.PanicIfErr(errRepo, ErrReservedWordService)
+	}
+
+	reservedWord, errRepo := service.ReservedWordRepository.Create(ctx, tx, domain.ReservedWord{
+		Word:     word,
+		Category: request.Category,
+	})
+	service.Logger.PanicIfErr(errRepo, ErrReservedWordService)
+
+	return helper.ReservedWordDomainToResponse(&reservedWord), nil
+}
+
+func (service *ReservedWordServiceImpl) GetAllReservedWord(ctx context.Context) ([]web.ReservedWordResponse, error) {
+	// It's a transaction.
+	tx := service.DB.Begin()
+	defer helper.CommitOrRollback(tx)
+
+	reservedWords, errRepo := service.ReservedWordRepository.FetchAll(ctx, tx)
+	service.Logger.PanicIfErr(errRepo, ErrReservedWordService)
+
+	reservedWordsResponse := []web.ReservedWordResponse{}
+	for _, reservedWord := range reservedWords {
+		reservedWordsResponse = append(reservedWordsResponse, helper.ReservedWordDomainToResponse(&reservedWord))
+	}
+	return reservedWordsResponse, nil
+}
+
+func (service *ReservedWordServiceImpl) DeleteReservedWord(ctx context.Context, request web.ReservedWordDeleteRequest) error {
+	// It's a transaction.
+	tx := service.DB.Begin()
+	defer helper.CommitOrRollback(tx)
+
+	reservedWord, errRepo := service.ReservedWordRepository.FindByID(ctx, tx, request.WordID)
+	if errors.Is(errRepo, gorm.ErrRecordNotFound) {
+		return ErrReservedWordNotRegistered
+	}
+	service.Logger.PanicIfErr(errRepo, ErrReservedWordService)
+
+	errRepo = service.ReservedWordRepository.Delete(ctx, tx, reservedWord)
+	service.Logger.PanicIfErr(errRepo, ErrReservedWordService)
+	return nil
+}
+
+// isReservedWord is shared by the services that let users pick a public name, such as a username or a short link code.
+func isReservedWord(ctx context.Context, tx *gorm.DB, rwr repository.ReservedWordRepository, name string) (bool, error) {
+	_, errRepo := rwr.FindMatch(ctx, tx, helper.NormalizeReservedWord(name), helper.ReservedSubstringCategories)
+	if errors.Is(errRepo, gorm.ErrRecordNotFound) {
+		return false, nil
+	}
+	return errRepo == nil, errRepo
+}
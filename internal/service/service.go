@@ -22,7 +22,8 @@ type SocialMediaLinkService interface {
 	GetAllTypes(ctx context.Context) ([]web.SocialMediaTypeResponse, error)
 	CreateLink(ctx context.Context, request web.SocialMediaLinkCreateRequest, host string, jwtToken string) (web.SocialMediaLinkResponse, error)
 	UpdateLink(ctx context.Context, request web.SocialMediaLinkUpdateRequest, host string, jwtToken string) (web.SocialMediaLinkResponse, error)
-	GetAllLink(ctx context.Context, host string, jwtToken string) ([]web.SocialMediaLinkResponse, error)
+	GetAllLink(ctx context.Context, request web.SocialMediaLinkGetAllRequest, host string, jwtToken string) ([]web.SocialMediaLinkResponse, web.Pagination, error)
+	ReorderLink(ctx context.Context, request web.SocialMediaLinkReorderRequest, jwtToken string) ([]web.SocialMediaLinkPositionResponse, error)
 	RedirectLink(ctx context.Context, request web.SocialMediaLinkRedirectRequest) (string, uint, error)
 	GetAllLinkProfile(ctx context.Context, domainName string, userID string, username string) []web.UserProfileSocialMediaResponse
 }
@@ -35,15 +36,44 @@ type SocialMediaAnalytic interface {
 
 type CustomLinkService interface {
 	CreateLink(ctx context.Context, request web.CustomLinkCreateRequest, domainName string, jwtToken string) (web.CustomLinkResponse, error)
+	ImportLink(ctx context.Context, request web.CustomLinkImportRequest, domainName string, jwtToken string) (web.CustomLinkImportResponse, error)
 	UpdateLink(ctx context.Context, request web.CustomLinkUpdateRequest, domainName string, jwtToken string) (web.CustomLinkResponse, error)
 	GetLink(ctx context.Context, request web.CustomLinkGetRequest, domainName string, jwtToken string) (web.CustomLinkResponse, error)
-	GetAllLink(ctx context.Context, domainName string, jwtToken string) ([]web.CustomLinkResponse, error)
+	GetAllLink(ctx context.Context, request web.CustomLinkGetAllRequest, domainName string, jwtToken string) ([]web.CustomLinkResponse, web.Pagination, error)
+	ExportLink(ctx context.Context, request web.CustomLinkExportRequest, domainName string, jwtToken string, write func(row web.CustomLinkExportResponse) error) error
+	DeleteLink(ctx context.Context, request web.CustomLinkDeleteRequest, jwtToken string) error
+	GetAllDeletedLink(ctx context.Context, domainName string, jwtToken string) ([]web.CustomLinkResponse, error)
+	RestoreLink(ctx context.Context, request web.CustomLinkRestoreRequest, domainName string, jwtToken string) (web.CustomLinkResponse, error)
+	GetLinkHistory(ctx context.Context, request web.CustomLinkHistoryRequest, jwtToken string) ([]web.CustomLinkRevisionResponse, error)
+	RevertLink(ctx context.Context, request web.CustomLinkRevertRequest, domainName string, jwtToken string) (web.CustomLinkResponse, error)
+	CloneLink(ctx context.Context, request web.CustomLinkCloneRequest, domainName string, jwtToken string) (web.CustomLinkResponse, error)
 	GetAllThumbnail(ctx context.Context) ([]web.ThumbnailResponse, error)
 	GetUserThumbnail(ctx context.Context, domainName string, jwtToken string) ([]web.ThumbnailResponse, error)
 	UploadCustomThumbnail(ctx context.Context, imgData []byte, domainName string, jwtToken string) (web.ThumbnailResponse, error)
-	CheckShortLinkAvaibility(ctx context.Context, request web.CustomLinkCheckShortCodeAvaibilityRequest) error
-	RedirectLink(ctx context.Context, request web.CustomLinkRedirectRequest) (string, uint, error)
+	GetLinkQRCode(ctx context.Context, request web.CustomLinkQRCodeRequest, domainName string, jwtToken string) (web.QRCodeResponse, error)
+	ReorderLink(ctx context.Context, request web.CustomLinkReorderRequest, jwtToken string) ([]web.CustomLinkPositionResponse, error)
+	BatchLink(ctx context.Context, request web.CustomLinkBatchRequest, jwtToken string) (web.CustomLinkBatchResponse, error)
+	CreateTag(ctx context.Context, request web.TagCreateRequest, jwtToken string) (web.TagResponse, error)
+	GetAllTag(ctx context.Context, jwtToken string) ([]web.TagResponse, error)
+	UpdateTag(ctx context.Context, request web.TagUpdateRequest, jwtToken string) (web.TagResponse, error)
+	DeleteTag(ctx context.Context, request web.TagDeleteRequest, jwtToken string) error
+	CreateFolder(ctx context.Context, request web.FolderCreateRequest, jwtToken string) (web.FolderResponse, error)
+	GetAllFolder(ctx context.Context, jwtToken string) ([]web.FolderResponse, error)
+	UpdateFolder(ctx context.Context, request web.FolderUpdateRequest, jwtToken string) (web.FolderResponse, error)
+	DeleteFolder(ctx context.Context, request web.FolderDeleteRequest, jwtToken string) error
+	CreateDomain(ctx context.Context, request web.CustomDomainCreateRequest, jwtToken string) (web.CustomDomainResponse, error)
+	GetAllDomain(ctx context.Context, jwtToken string) ([]web.CustomDomainResponse, error)
+	VerifyDomain(ctx context.Context, request web.CustomDomainVerifyRequest, jwtToken string) (web.CustomDomainResponse, error)
+	DeleteDomain(ctx context.Context, request web.CustomDomainDeleteRequest, jwtToken string) error
+	CreateUtmTemplate(ctx context.Context, request web.UtmTemplateCreateRequest, jwtToken string) (web.UtmTemplateResponse, error)
+	GetAllUtmTemplate(ctx context.Context, jwtToken string) ([]web.UtmTemplateResponse, error)
+	UpdateUtmTemplate(ctx context.Context, request web.UtmTemplateUpdateRequest, jwtToken string) (web.UtmTemplateResponse, error)
+	DeleteUtmTemplate(ctx context.Context, request web.UtmTemplateDeleteRequest, jwtToken string) error
+	CheckShortLinkAvaibility(ctx context.Context, request web.CustomLinkCheckShortCodeAvaibilityRequest, jwtToken string) (web.CustomLinkShortCodeAvaibilityResponse, error)
+	RedirectLink(ctx context.Context, request web.CustomLinkRedirectRequest) (web.CustomLinkRedirectResponse, error)
+	GetLinkPreview(ctx context.Context, request web.CustomLinkRedirectRequest, domainName string) (web.CustomLinkPreviewResponse, error)
 	GetAllLinkProfile(ctx context.Context, domainName string, userID string, username string) []web.UserProfileCustomLinkResponse
+	ReleaseShortLinkCodes(ctx context.Context) (int64, error)
 }
 
 type CustomLinkAnalyticService interface {
@@ -51,3 +81,13 @@ type CustomLinkAnalyticService interface {
 	GetLinkAnalytic(ctx context.Context, request web.CustomLinkAnalyticGetRequest, jwtToken string) ([]web.CustomLinkAnalyticResponse, error)
 	GetSummaryLinkAnalytic(ctx context.Context, jwtToken string) (web.CustomLinkAnalyticSummaryResponse, error)
 }
+
+type ReservedWordService interface {
+	CreateReservedWord(ctx context.Context, request web.ReservedWordCreateRequest) (web.ReservedWordResponse, error)
+	GetAllReservedWord(ctx context.Context) ([]web.ReservedWordResponse, error)
+	DeleteReservedWord(ctx context.Context, request web.ReservedWordDeleteRequest) error
+}
+
+type LinkHealthService interface {
+	CheckAllLinks(ctx context.Context) error
+}
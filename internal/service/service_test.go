@@ -1,11 +1,16 @@
 package service
 
 import (
+	"bufio"
 	"context"
+	"net"
+	"strings"
 	"testing"
 
 	"github.com/ilhamfzri/pendek.in/app/database"
 	"github.com/ilhamfzri/pendek.in/app/logger"
+	"github.com/ilhamfzri/pendek.in/app/mail"
+	"github.com/ilhamfzri/pendek.in/config"
 )
 
 var ctx = context.Background()
@@ -15,3 +20,67 @@ var log = new(logger.Logger)
 func TestMain(m *testing.M) {
 	m.Run()
 }
+
+// newMailClientMock starts a local SMTP server accepting every message, so services sending emails can be tested.
+func newMailClientMock(t *testing.T) *mail.MailClient {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go serveSmtp(conn)
+		}
+	}()
+
+	address := listener.Addr().(*net.TCPAddr)
+	return mail.NewMailClient(config.MailConfig{
+		StmpHost:   address.IP.String(),
+		StmpPort:   address.Port,
+		SenderName: "noreply@pendek.in",
+	})
+}
+
+func serveSmtp(conn net.Conn) {
+	defer conn.Close()
+
+	reader := bufio.NewReader(conn)
+	reply := func(line string) {
+		conn.Write([]byte(line + "\r\n"))
+	}
+
+	reply("220 localhost")
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return
+		}
+
+		command := strings.ToUpper(strings.TrimSpace(line))
+		switch {
+		case strings.HasPrefix(command, "DATA"):
+			reply("354 end data with <CR><LF>.<CR><LF>")
+			for {
+				data, err := reader.ReadString('\n')
+				if err != nil {
+					return
+				}
+				if data == ".\r\n" {
+					break
+				}
+			}
+			reply("250 OK")
+		case strings.HasPrefix(command, "QUIT"):
+			reply("221 Bye")
+			return
+		default:
+			reply("250 OK")
+		}
+	}
+}
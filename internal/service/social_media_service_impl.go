@@ -28,6 +28,12 @@ var (
 	ErrSocialMediaTypeInvalid        = errors.New("social media type id invalid")
 	ErrSocialMediaInvalidLink        = errors.New("invalid link")
 	ErrSocialMediaLinkUsernameOrLink = errors.New("username or link format is not valid")
+	ErrSocialMediaReorderInvalid     = errors.New("type_ids invalid, make sure every type_id is registered")
+)
+
+var (
+	DefaultSocialMediaLinkPageLimit = 20
+	SortSocialMediaLinkType         = "type" // social media links are listed by type, the cursor id is the type id
 )
 
 func NewSocialMediaLinkService(userRepository repository.UserRepository, socialMediaLinkRepository repository.SocialMediaLinkRepository, socialMediaTypeRepository repository.SocialMediaTypeRepository, DB *gorm.DB, logger *logger.Logger, jwt helper.IJwt) SocialMediaLinkService {
@@ -152,7 +158,7 @@ func (service *SocialMediaLinkServiceImpl) UpdateLink(ctx context.Context, reque
 	return socialMediaLinkResponse, nil
 }
 
-func (service *SocialMediaLinkServiceImpl) GetAllLink(ctx context.Context, host string, jwtToken string) ([]web.SocialMediaLinkResponse, error) {
+func (service *SocialMediaLinkServiceImpl) GetAllLink(ctx context.Context, request web.SocialMediaLinkGetAllRequest, host string, jwtToken string) ([]web.SocialMediaLinkResponse, web.Pagination, error) {
 	// It's getting the claims from the token.
 	claims := service.Jwt.GetClaims(jwtToken)
 
@@ -160,17 +166,79 @@ func (service *SocialMediaLinkServiceImpl) GetAllLink(ctx context.Context, host
 	tx := service.DB.Begin()
 	defer helper.CommitOrRollback(tx)
 
-	// It's getting all social media link data from the database.
-	socialMediaLinks, repoErr := service.SocialMediaLinkRepository.FindByUserID(ctx, tx, claims.Id)
+	limit := request.Limit
+	if limit == 0 {
+		limit = DefaultSocialMediaLinkPageLimit
+	}
+
+	// It's reading the type id of the last link of the previous page.
+	var afterTypeID uint
+	if request.Cursor != "" {
+		cursor, errCursor := helper.DecodePageCursor(request.Cursor, SortSocialMediaLinkType)
+		if errCursor != nil {
+			return []web.SocialMediaLinkResponse{}, web.Pagination{}, errCursor
+		}
+		afterTypeID = cursor.ID
+	}
+
+	// It's getting a page of social media link data from the database.
+	socialMediaLinks, repoErr := service.SocialMediaLinkRepository.FindPageByUserID(ctx, tx, claims.Id, request.Search, afterTypeID, limit)
 	service.Logger.PanicIfErr(repoErr, ErrSocialMediaLinkService)
 
+	pagination := web.Pagination{Limit: limit}
+	if len(socialMediaLinks) > limit {
+		socialMediaLinks = socialMediaLinks[:limit]
+		pagination.HasMore = true
+		pagination.NextCursor = helper.EncodePageCursor(helper.PageCursor{
+			Sort: SortSocialMediaLinkType,
+			ID:   socialMediaLinks[limit-1].TypeID,
+		})
+	}
+
 	var socialMediaLinksReponse []web.SocialMediaLinkResponse
 	for _, socialMediaLink := range socialMediaLinks {
 		socialMediaLinkReponse := helper.SocialMediaLinkDomainToResponse(&socialMediaLink, host, claims.Username)
 		socialMediaLinksReponse = append(socialMediaLinksReponse, socialMediaLinkReponse)
 	}
 
-	return socialMediaLinksReponse, nil
+	return socialMediaLinksReponse, pagination, nil
+}
+
+func (service *SocialMediaLinkServiceImpl) ReorderLink(ctx context.Context, request web.SocialMediaLinkReorderRequest, jwtToken string) ([]web.SocialMediaLinkPositionResponse, error) {
+	// It's getting the claims from the token.
+	claims := service.Jwt.GetClaims(jwtToken)
+
+	// It's a transaction.
+	tx := service.DB.Begin()
+	defer helper.CommitOrRollback(tx)
+
+	socialMediaLinks, repoErr := service.SocialMediaLinkRepository.FindByUserID(ctx, tx, claims.Id)
+	service.Logger.PanicIfErr(repoErr, ErrSocialMediaLinkService)
+
+	// It's keeping the links missing from the request after the reordered ones.
+	linkIDs := map[uint]uint{}
+	var currentTypeIDs []uint
+	for _, socialMediaLink := range socialMediaLinks {
+		linkIDs[socialMediaLink.TypeID] = socialMediaLink.ID
+		currentTypeIDs = append(currentTypeIDs, socialMediaLink.TypeID)
+	}
+
+	typeIDs, ok := helper.ReorderIDs(currentTypeIDs, request.TypeIDs)
+	if !ok {
+		return []web.SocialMediaLinkPositionResponse{}, ErrSocialMediaReorderInvalid
+	}
+
+	var orderedLinkIDs []uint
+	var positionsResponse []web.SocialMediaLinkPositionResponse
+	for i, typeID := range typeIDs {
+		orderedLinkIDs = append(orderedLinkIDs, linkIDs[typeID])
+		positionsResponse = append(positionsResponse, web.SocialMediaLinkPositionResponse{TypeID: typeID, Position: i + 1})
+	}
+
+	repoErr = service.SocialMediaLinkRepository.UpdatePositions(ctx, tx, claims.Id, orderedLinkIDs)
+	service.Logger.PanicIfErr(repoErr, ErrSocialMediaLinkService)
+
+	return positionsResponse, nil
 }
 
 func (service *SocialMediaLinkServiceImpl) RedirectLink(ctx context.Context, request web.SocialMediaLinkRedirectRequest) (string, uint, error) {
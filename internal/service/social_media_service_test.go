@@ -339,12 +339,27 @@ func TestSocialMediaServiceGetAllLink(t *testing.T) {
 	socialMediaLinks[0].ID = 1
 	socialMediaLinks[0].ID = 2
 
-	socialMediaLinkRepository.Mock.On("FindByUserID", mock.Anything, mock.Anything, "123456").Return(socialMediaLinks, nil)
+	socialMediaLinkRepository.Mock.On("FindPageByUserID", mock.Anything, mock.Anything, "123456", "", uint(0), DefaultSocialMediaLinkPageLimit).Return(socialMediaLinks, nil)
+	socialMediaLinkRepository.Mock.On("FindPageByUserID", mock.Anything, mock.Anything, "123456", "", uint(0), 2).Return(socialMediaLinks, nil)
 
 	t.Run("[Get All Link][Success]", func(t *testing.T) {
-		socialMediaLinkResponses, err := socialMediaService.GetAllLink(ctx, host, dummyJwt)
+		socialMediaLinkResponses, pagination, err := socialMediaService.GetAllLink(ctx, web.SocialMediaLinkGetAllRequest{}, host, dummyJwt)
 		assert.Nil(t, err)
 		assert.IsType(t, []web.SocialMediaLinkResponse{}, socialMediaLinkResponses)
+		assert.False(t, pagination.HasMore)
+	})
+
+	t.Run("[Get All Link][Has More]", func(t *testing.T) {
+		socialMediaLinkResponses, pagination, err := socialMediaService.GetAllLink(ctx, web.SocialMediaLinkGetAllRequest{Limit: 2}, host, dummyJwt)
+		assert.Nil(t, err)
+		assert.Len(t, socialMediaLinkResponses, 2)
+		assert.True(t, pagination.HasMore)
+		assert.NotEmpty(t, pagination.NextCursor)
+	})
+
+	t.Run("[Get All Link][Invalid Cursor]", func(t *testing.T) {
+		_, _, err := socialMediaService.GetAllLink(ctx, web.SocialMediaLinkGetAllRequest{Cursor: "invalid"}, host, dummyJwt)
+		assert.ErrorIs(t, err, helper.ErrPageCursorInvalid)
 	})
 
 }
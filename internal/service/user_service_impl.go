@@ -23,32 +23,38 @@ import (
 )
 
 type UserServiceImpl struct {
-	Repository repository.UserRepository
-	MailClient *mail.MailClient
-	DB         *gorm.DB
-	Logger     *logger.Logger
-	Jwt        helper.IJwt
+	Repository             repository.UserRepository
+	ReservedWordRepository repository.ReservedWordRepository
+	CustomLinkRepository   repository.CustomLinkRepository
+	MailClient             *mail.MailClient
+	DB                     *gorm.DB
+	Logger                 *logger.Logger
+	Jwt                    helper.IJwt
 }
 
-func NewUserService(repository repository.UserRepository, mailClient *mail.MailClient, DB *gorm.DB, logger *logger.Logger, jwt helper.IJwt) UserService {
+func NewUserService(repository repository.UserRepository, rwr repository.ReservedWordRepository, clr repository.CustomLinkRepository, mailClient *mail.MailClient, DB *gorm.DB, logger *logger.Logger, jwt helper.IJwt) UserService {
 	return &UserServiceImpl{
-		Repository: repository,
-		MailClient: mailClient,
-		DB:         DB,
-		Logger:     logger,
-		Jwt:        jwt,
+		Repository:             repository,
+		ReservedWordRepository: rwr,
+		CustomLinkRepository:   clr,
+		MailClient:             mailClient,
+		DB:                     DB,
+		Logger:                 logger,
+		Jwt:                    jwt,
 	}
 }
 
 var (
-	ErrUserService              = "[User Service] Failed Execute User Service"
-	ErrUsernameFound            = errors.New("username is already used")
-	ErrEmailFound               = errors.New("email is already registered")
-	ErrEmailNotFound            = errors.New("email isn't registered")
-	ErrEmailNotVerified         = errors.New("email isn't verified")
-	ErrPasswordIncorrect        = errors.New("password incorrect")
-	ErrCurrentPasswordIncorrect = errors.New("current password incorrect")
-	ErrVerificationCodeInvalid  = errors.New("verification code expired or invalid")
+	ErrUserService               = "[User Service] Failed Execute User Service"
+	ErrUsernameFound             = errors.New("username is already used")
+	ErrUsernameReserved          = errors.New("username is reserved")
+	ErrEmailFound                = errors.New("email is already registered")
+	ErrEmailNotFound             = errors.New("email isn't registered")
+	ErrEmailNotVerified          = errors.New("email isn't verified")
+	ErrPasswordIncorrect         = errors.New("password incorrect")
+	ErrCurrentPasswordIncorrect  = errors.New("current password incorrect")
+	ErrVerificationCodeInvalid   = errors.New("verification code expired or invalid")
+	ErrShortLinkCodeCaseConflict = errors.New("some of your short link codes only differ in case from other links, change them before enabling case insensitive short codes")
 )
 
 func (service *UserServiceImpl) Register(ctx context.Context, request web.UserRegisterRequest) (web.UserResponse, error) {
@@ -62,6 +68,13 @@ func (service *UserServiceImpl) Register(ctx context.Context, request web.UserRe
 		Password: request.Password,
 	}
 
+	// It's checking if the username is reserved, e.g. a route prefix or a brand name.
+	reserved, errReserved := isReservedWord(ctx, tx, service.ReservedWordRepository, user.Username)
+	service.Logger.PanicIfErr(errReserved, ErrUserService)
+	if reserved {
+		return web.UserResponse{}, ErrUsernameReserved
+	}
+
 	// It's checking if the username is already used or not.
 	userData, repoErr := service.Repository.FindByUsername(ctx, tx, user.Username)
 	if repoErr == nil && userData.Username != "" {
@@ -199,6 +212,28 @@ func (service *UserServiceImpl) Update(ctx context.Context, request web.UserUpda
 		user.Bio = request.Bio
 	}
 
+	// It's applying the case insensitive short code option to every link of the user.
+	if request.CaseInsensitiveShortCode != nil && *request.CaseInsensitiveShortCode != user.CaseInsensitiveShortCode {
+		if *request.CaseInsensitiveShortCode {
+			// The codes are locked first, a link created meanwhile could differ only in case from one of them.
+			errRepo := service.CustomLinkRepository.LockShortLinkCodesByUserID(ctx, tx, user.ID)
+			service.Logger.PanicIfErr(errRepo, ErrUserService)
+
+			conflicts, errRepo := service.CustomLinkRepository.CountCaseConflictsByUserID(ctx, tx, user.ID)
+			service.Logger.PanicIfErr(errRepo, ErrUserService)
+			if conflicts > 0 {
+				return web.UserResponse{}, ErrShortLinkCodeCaseConflict
+			}
+		}
+
+		errRepo = service.CustomLinkRepository.UpdateCaseInsensitiveByUserID(ctx, tx, user.ID, *request.CaseInsensitiveShortCode)
+		service.Logger.PanicIfErr(errRepo, ErrUserService)
+
+		errRepo = service.Repository.UpdateCaseInsensitiveShortCode(ctx, tx, user.ID, *request.CaseInsensitiveShortCode)
+		service.Logger.PanicIfErr(errRepo, ErrUserService)
+		user.CaseInsensitiveShortCode = *request.CaseInsensitiveShortCode
+	}
+
 	// It's updating the user data in the database.
 	user, errRepo = service.Repository.Update(ctx, tx, user)
 	service.Logger.PanicIfErr(errRepo, ErrUserService)
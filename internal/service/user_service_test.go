@@ -31,7 +31,12 @@ var userFound = domain.User{
 func TestUserServiceRegister(t *testing.T) {
 	var jwt = new(helper.JwtMock)
 	var userRepository = mocks.NewUserRepository(t)
-	var userService = NewUserService(userRepository, db, log, jwt)
+	var reservedWordRepository = mocks.NewReservedWordRepository(t)
+	var userService = NewUserService(userRepository, reservedWordRepository, nil, newMailClientMock(t), db, log, jwt)
+
+	reservedWordRepository.Mock.On("FindMatch", mock.Anything, mock.Anything, "v1", helper.ReservedSubstringCategories).Return(domain.ReservedWord{Word: "v1", Category: helper.ReservedCategoryRoute}, nil)
+	reservedWordRepository.Mock.On("FindMatch", mock.Anything, mock.Anything, "xxbadwordxx", helper.ReservedSubstringCategories).Return(domain.ReservedWord{Word: "badword", Category: helper.ReservedCategoryOffensive}, nil)
+	reservedWordRepository.Mock.On("FindMatch", mock.Anything, mock.Anything, mock.Anything, helper.ReservedSubstringCategories).Return(domain.ReservedWord{}, gorm.ErrRecordNotFound)
 
 	userRepository.Mock.On("Create", mock.Anything, mock.Anything, mock.Anything).Return(userNotFound, nil)
 	userRepository.Mock.On("FindByUsername", mock.Anything, mock.Anything, userNotFound.Username).Return(domain.User{}, gorm.ErrRecordNotFound)
@@ -88,13 +93,46 @@ func TestUserServiceRegister(t *testing.T) {
 		},
 	)
 
+	t.Run(
+		"[Register][Failed:Username Reserved]", func(t *testing.T) {
+			request := web.UserRegisterRequest{
+				Username: "V1",
+				Email:    userNotFound.Email,
+				Password: userNotFound.Password,
+			}
+
+			user, err := userService.Register(ctx, request)
+
+			assert.Equal(t, web.UserResponse{}, user)
+			assert.NotNil(t, err)
+			assert.Equal(t, ErrUsernameReserved, err)
+		},
+	)
+
+	t.Run(
+		"[Register][Failed:Username Offensive]", func(t *testing.T) {
+			request := web.UserRegisterRequest{
+				Username: "xxBadWordxx",
+				Email:    userNotFound.Email,
+				Password: userNotFound.Password,
+			}
+
+			user, err := userService.Register(ctx, request)
+
+			assert.Equal(t, web.UserResponse{}, user)
+			assert.NotNil(t, err)
+			assert.Equal(t, ErrUsernameReserved, err)
+		},
+	)
+
 	userRepository.AssertExpectations(t)
+	reservedWordRepository.AssertExpectations(t)
 }
 
 func TestUserServiceLogin(t *testing.T) {
 	var jwt = new(helper.JwtMock)
 	var userRepository = mocks.NewUserRepository(t)
-	var userService = NewUserService(userRepository, db, log, jwt)
+	var userService = NewUserService(userRepository, nil, nil, nil, db, log, jwt)
 
 	newUserFound := userFound
 	newUserFound.Password = "$2a$14$SIxTHeN2csRDv.WqW2H5M.0pDPli7p1OAsikanREUi2B5tt.KQy.i"
@@ -167,7 +205,7 @@ func TestUserServiceLogin(t *testing.T) {
 func TestUserChangePassword(t *testing.T) {
 	var jwt = new(helper.JwtMock)
 	var userRepository = mocks.NewUserRepository(t)
-	var userService = NewUserService(userRepository, db, log, jwt)
+	var userService = NewUserService(userRepository, nil, nil, nil, db, log, jwt)
 
 	newUserFound := userFound
 	newUserFound.Password = "$2a$14$SIxTHeN2csRDv.WqW2H5M.0pDPli7p1OAsikanREUi2B5tt.KQy.i"
@@ -209,7 +247,7 @@ func TestUserChangePassword(t *testing.T) {
 func TestUserEmailVerification(t *testing.T) {
 	var jwt = new(helper.JwtMock)
 	var userRepository = mocks.NewUserRepository(t)
-	var userService = NewUserService(userRepository, db, log, jwt)
+	var userService = NewUserService(userRepository, nil, nil, nil, db, log, jwt)
 
 	var newUserFound = userFound
 	newUserFound.VerificationCode = "ABCDEF"
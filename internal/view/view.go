@@ -0,0 +1,12 @@
+package view
+
+import (
+	"embed"
+	"html/template"
+)
+
+//go:embed templates/*.html
+var templateFS embed.FS
+
+// Templates holds every server rendered page, it's registered to the router with SetHTMLTemplate.
+var Templates = template.Must(template.ParseFS(templateFS, "templates/*.html"))
@@ -0,0 +1,113 @@
+package view
+
+import (
+	"bytes"
+	"html/template"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTemplates(t *testing.T) {
+	t.Run("[LinkPassword][Escaped Message]", func(t *testing.T) {
+		var buffer bytes.Buffer
+		err := Templates.ExecuteTemplate(&buffer, "link_password.html", map[string]interface{}{
+			"ShortLinkCode": "promo1",
+			"Message":       "<script>",
+		})
+		assert.Nil(t, err)
+		assert.Contains(t, buffer.String(), `action="/l/promo1"`)
+		assert.NotContains(t, buffer.String(), "<script>")
+		assert.NotContains(t, buffer.String(), `name="source"`)
+	})
+
+	t.Run("[LinkPassword][QR Code Scan]", func(t *testing.T) {
+		var buffer bytes.Buffer
+		err := Templates.ExecuteTemplate(&buffer, "link_password.html", map[string]interface{}{
+			"ShortLinkCode": "promo1",
+			"Source":        "qr",
+		})
+		assert.Nil(t, err)
+		assert.Contains(t, buffer.String(), `<input type="hidden" name="source" value="qr">`)
+	})
+
+	t.Run("[LinkPassword][Forwarded Query]", func(t *testing.T) {
+		var buffer bytes.Buffer
+		err := Templates.ExecuteTemplate(&buffer, "link_password.html", map[string]interface{}{
+			"ShortLinkCode": "promo1",
+			"Query":         template.URL(url.Values{"ref": {"x"}, "q": {`a"b`}}.Encode()),
+		})
+		assert.Nil(t, err)
+		assert.Contains(t, buffer.String(), `action="/l/promo1?q=a%22b&amp;ref=x"`)
+	})
+
+	t.Run("[LinkPreview][Open Graph Tags]", func(t *testing.T) {
+		var buffer bytes.Buffer
+		err := Templates.ExecuteTemplate(&buffer, "link_preview.html", map[string]interface{}{
+			"Title":       `Promo "Summer"`,
+			"Description": "<b>50% off</b>",
+			"ImageUrl":    "https://pendek.in/v1/resources/thumbnail/abc.jpg",
+			"Url":         "https://pendek.in/l/promo1",
+		})
+		assert.Nil(t, err)
+		assert.Contains(t, buffer.String(), `<meta property="og:title" content="Promo &#34;Summer&#34;">`)
+		assert.Contains(t, buffer.String(), `<meta property="og:image" content="https://pendek.in/v1/resources/thumbnail/abc.jpg">`)
+		assert.Contains(t, buffer.String(), `<meta property="og:url" content="https://pendek.in/l/promo1">`)
+		assert.Contains(t, buffer.String(), `content="summary_large_image"`)
+		assert.NotContains(t, buffer.String(), "<b>")
+	})
+
+	t.Run("[LinkDetails][Destination]", func(t *testing.T) {
+		var buffer bytes.Buffer
+		err := Templates.ExecuteTemplate(&buffer, "link_details.html", map[string]interface{}{
+			"ShortLinkCode": "promo1",
+			"Title":         "<i>Promo</i>",
+			"ThumbnailUrl":  "https://pendek.in/v1/resources/thumbnail/abc.jpg",
+			"Destination":   "https://example.com/summer?utm_source=x&a=1",
+			"CreatedAt":     time.Date(2023, 1, 10, 8, 0, 0, 0, time.UTC),
+		})
+		assert.Nil(t, err)
+		assert.Contains(t, buffer.String(), "https://example.com/summer?utm_source=x&amp;a=1")
+		assert.Contains(t, buffer.String(), "10 January 2023")
+		assert.Contains(t, buffer.String(), `<img src="https://pendek.in/v1/resources/thumbnail/abc.jpg"`)
+		assert.Contains(t, buffer.String(), `href="/l/promo1"`)
+		assert.NotContains(t, buffer.String(), "<i>")
+	})
+
+	t.Run("[LinkDetails][Locked]", func(t *testing.T) {
+		var buffer bytes.Buffer
+		err := Templates.ExecuteTemplate(&buffer, "link_details.html", map[string]interface{}{
+			"ShortLinkCode": "promo1",
+			"Title":         "Promo",
+			"CreatedAt":     time.Date(2023, 1, 10, 8, 0, 0, 0, time.UTC),
+			"Locked":        true,
+		})
+		assert.Nil(t, err)
+		assert.Contains(t, buffer.String(), "password protected")
+		assert.NotContains(t, buffer.String(), "<img")
+	})
+
+	t.Run("[LinkInterstitial][Destination]", func(t *testing.T) {
+		var buffer bytes.Buffer
+		err := Templates.ExecuteTemplate(&buffer, "link_interstitial.html", map[string]interface{}{
+			"Title":           "Promo",
+			"Destination":     "https://example.com/summer",
+			"DestinationHost": "example.com",
+		})
+		assert.Nil(t, err)
+		assert.Contains(t, buffer.String(), "You are leaving to example.com")
+		assert.Contains(t, buffer.String(), `href="https://example.com/summer"`)
+	})
+
+	t.Run("[LinkInterstitial][Unsafe Destination]", func(t *testing.T) {
+		var buffer bytes.Buffer
+		err := Templates.ExecuteTemplate(&buffer, "link_interstitial.html", map[string]interface{}{
+			"Destination":     "javascript:alert(1)",
+			"DestinationHost": "javascript:alert(1)",
+		})
+		assert.Nil(t, err)
+		assert.NotContains(t, buffer.String(), `href="javascript:`)
+	})
+}